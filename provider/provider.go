@@ -17,6 +17,7 @@ import (
 	"github.com/dell/csi-vxflexos/v2/service"
 	"github.com/dell/gocsi"
 	logrus "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
 )
 
 // Log init
@@ -31,6 +32,12 @@ func New() gocsi.StoragePluginProvider {
 		Node:                      svc,
 		BeforeServe:               svc.BeforeServe,
 		RegisterAdditionalServers: svc.RegisterAdditionalServers,
+		Interceptors: []grpc.UnaryServerInterceptor{
+			service.RequestIDInterceptor,
+			service.TracingInterceptor,
+			service.RPCMetricsInterceptor,
+			svc.AuditInterceptor,
+		},
 
 		EnvVars: []string{
 			// Enable request validation
@@ -0,0 +1,171 @@
+// Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// reloadArrayConfig applies a change of the array config file to the connected arrays. Removed arrays are
+// disconnected, arrays whose connection changed are logged in to again with a new client, and added arrays are
+// left to the next probe. When the file does not load the current config stays active, as does the connection
+// of an array that cannot log in with its new config.
+func (s *service) reloadArrayConfig(ctx context.Context) error {
+	arrays, err := getArrayConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	px.Lock()
+	defer px.Unlock()
+
+	current := make(map[string]*ArrayConnectionData)
+	for key, array := range s.arrayConfigs() {
+		// the probe also adds the arrays configured by name under their ID
+		if key == array.SystemID {
+			current[key] = array
+		}
+	}
+
+	for systemID, array := range current {
+		updated, ok := arrays[systemID]
+		if !ok {
+			Log.Infof("array %s removed from the array config, disconnecting", systemID)
+			s.disconnectArray(array, true)
+			continue
+		}
+		if !arrayConnectionChanged(array, updated) {
+			continue
+		}
+
+		c, proxy, err := s.connectArray(ctx, updated)
+		if err != nil {
+			Log.WithError(err).Errorf("unable to connect to array %s with its new config, keeping the current connection", systemID)
+			kept := *updated
+			kept.Endpoint, kept.Username, kept.Password = array.Endpoint, array.Username, array.Password
+			kept.SkipCertificateValidation, kept.Insecure = array.SkipCertificateValidation, array.Insecure
+			kept.AllSystemNames = array.AllSystemNames
+			kept.CACertificate, kept.ClientCertificate, kept.ClientKey = array.CACertificate, array.ClientCertificate, array.ClientKey
+			kept.TLSServerName, kept.Endpoints = array.TLSServerName, array.Endpoints
+			kept.UsernameFile, kept.PasswordFile, kept.Vault = array.UsernameFile, array.PasswordFile, array.Vault
+			arrays[systemID] = &kept
+			continue
+		}
+		Log.Infof("connection of array %s changed, reconnected to %s", systemID, updated.Endpoint)
+		s.disconnectArray(array, false)
+		s.connectionsRWL.Lock()
+		if proxy != nil {
+			if s.gatewayProxies == nil {
+				s.gatewayProxies = make(map[string]*gatewayProxy)
+			}
+			s.gatewayProxies[systemID] = proxy
+		}
+		s.adminClients[systemID] = c
+		for _, name := range arraySystemNames(updated) {
+			s.adminClients[name] = c
+		}
+		s.connectionsRWL.Unlock()
+	}
+	for systemID := range arrays {
+		if current[systemID] == nil {
+			Log.Infof("array %s added to the array config", systemID)
+		}
+	}
+
+	defaultSystemID := ""
+	for _, array := range arrays {
+		if array.IsDefault {
+			defaultSystemID = array.SystemID
+			if id, ok := s.systemIDByName(array.SystemID); ok {
+				defaultSystemID = id
+			}
+		}
+	}
+	s.optsRWL.Lock()
+	s.opts.arrays = arrays
+	s.opts.defaultSystemID = defaultSystemID
+	s.optsRWL.Unlock()
+	Log.Infof("array config reloaded, default array: %s", defaultSystemID)
+	return nil
+}
+
+// arrayConnectionChanged returns true when the arrays are reached or logged in to differently
+func arrayConnectionChanged(current, updated *ArrayConnectionData) bool {
+	return !slices.Equal(current.gatewayEndpoints(), updated.gatewayEndpoints()) ||
+		current.Username != updated.Username ||
+		current.Password != updated.Password ||
+		current.SkipCertificateValidation != updated.SkipCertificateValidation ||
+		current.Insecure != updated.Insecure ||
+		current.AllSystemNames != updated.AllSystemNames ||
+		current.CACertificate != updated.CACertificate ||
+		current.ClientCertificate != updated.ClientCertificate ||
+		current.ClientKey != updated.ClientKey ||
+		current.TLSServerName != updated.TLSServerName
+}
+
+// arraySystemNames returns the alternate names of the system of array
+func arraySystemNames(array *ArrayConnectionData) []string {
+	if array.AllSystemNames == "" {
+		return nil
+	}
+	return strings.Split(array.AllSystemNames, ",")
+}
+
+// disconnectArray drops the client and the system of array under its configured ID, its ID and name on the array
+// and its alternate names, and stops its TLS proxy. The volume prefixes and the probe metric of a removed array
+// are dropped too.
+func (s *service) disconnectArray(array *ArrayConnectionData, removed bool) {
+	if proxy := s.gatewayProxyOf(array.SystemID); proxy != nil {
+		proxy.close()
+		s.connectionsRWL.Lock()
+		delete(s.gatewayProxies, array.SystemID)
+		s.connectionsRWL.Unlock()
+	}
+	names := append([]string{array.SystemID}, arraySystemNames(array)...)
+	if id, ok := s.systemIDByName(array.SystemID); ok {
+		names = append(names, id)
+	}
+	if system := s.systemOf(array.SystemID); system != nil && system.System != nil {
+		names = append(names, system.System.ID, system.System.Name)
+	}
+
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		s.connectionsRWL.Lock()
+		delete(s.adminClients, name)
+		delete(s.systems, name)
+		delete(s.connectedSystemNameToID, name)
+		s.connectionsRWL.Unlock()
+		if removed {
+			driverMetrics.deleteSeries(metricArrayProbeSuccess, metricLabels{"system_id": name})
+			for prefix, systemIDs := range s.volumePrefixToSystems {
+				kept := make([]string, 0, len(systemIDs))
+				for _, systemID := range systemIDs {
+					if systemID != name {
+						kept = append(kept, systemID)
+					}
+				}
+				if len(kept) == 0 {
+					delete(s.volumePrefixToSystems, prefix)
+				} else {
+					s.volumePrefixToSystems[prefix] = kept
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"path"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/dell/csi-vxflexos/v2/replicationext"
+	"github.com/dell/dell-csi-extensions/replication"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	defaultAuditLogMaxSizeMB  = 100
+	defaultAuditLogMaxBackups = 10
+
+	auditOutcomeSuccess = "success"
+	auditOutcomeFailure = "failure"
+
+	// the changes the driver makes to the storage outside of the audited RPCs, by the task that makes them
+	auditNFSExportHostsRemoved = "NFSExportReconcile/RemoveHosts"
+	auditAutoReprotectReverse  = "AutoReprotect/Reverse"
+	auditAutoReprotectResume   = "AutoReprotect/Resume"
+	auditReplicationRollback   = "CreateStorageProtectionGroup/DeleteReplicationConsistencyGroup"
+)
+
+// auditedMethods are the RPCs that change the storage, by method name. The CSI and the extension services
+// are matched by method name only, as their names do not overlap.
+var auditedMethods = map[string]bool{
+	"CreateVolume":                 true,
+	"DeleteVolume":                 true,
+	"ControllerPublishVolume":      true,
+	"ControllerUnpublishVolume":    true,
+	"ControllerExpandVolume":       true,
+	"CreateSnapshot":               true,
+	"DeleteSnapshot":               true,
+	"CreateVolumeGroupSnapshot":    true,
+	"CreateRemoteVolume":           true,
+	"DeleteLocalVolume":            true,
+	"CreateStorageProtectionGroup": true,
+	"DeleteStorageProtectionGroup": true,
+	"ExecuteAction":                true,
+
+	"ModifyStorageProtectionGroup":             true,
+	"AddVolumeToProtectionGroup":               true,
+	"RemoveVolumeFromProtectionGroup":          true,
+	"CreateVolumesFromProtectionGroupSnapshot": true,
+	"ExecuteMultiGroupAction":                  true,
+}
+
+// auditRequesterKeys are the parameters and volume context keys the provisioner and the snapshotter set to
+// identify the Kubernetes objects a request is made for
+var auditRequesterKeys = []string{
+	CSIPersistentVolumeName,
+	CSIPersistentVolumeClaimName,
+	CSIPersistentVolumeClaimNamespace,
+	"csi.storage.k8s.io/volumesnapshot/name",
+	"csi.storage.k8s.io/volumesnapshot/namespace",
+	"csi.storage.k8s.io/volumesnapshotcontent/name",
+}
+
+// auditRecord is one line of the audit log
+type auditRecord struct {
+	Timestamp string            `json:"timestamp"`
+	RequestID string            `json:"requestId,omitempty"`
+	Method    string            `json:"method"`
+	ArrayID   string            `json:"arrayId,omitempty"`
+	Objects   map[string]string `json:"objects,omitempty"`
+	Requester map[string]string `json:"requester,omitempty"`
+	Outcome   string            `json:"outcome"`
+	Code      string            `json:"code"`
+	Error     string            `json:"error,omitempty"`
+}
+
+// auditLog appends the audit records as JSON lines to a file that is rotated by size
+type auditLog struct {
+	mu sync.Mutex
+	w  io.WriteCloser
+}
+
+// newAuditLog opens the audit log at path, keeping maxBackups rotated files of maxSizeMB megabytes
+func newAuditLog(path string, maxSizeMB, maxBackups int) *auditLog {
+	return &auditLog{
+		w: &lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    maxSizeMB,
+			MaxBackups: maxBackups,
+		},
+	}
+}
+
+// write appends record to the audit log
+func (a *auditLog) write(record *auditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, err = a.w.Write(append(line, '\n'))
+	return err
+}
+
+// lastRequestID is the last request ID generated by RequestIDInterceptor
+var lastRequestID uint64
+
+// RequestIDInterceptor gives every request without a csi.requestid one. It runs before the interceptors of
+// gocsi, so the audit log, the traces and the driver logs all see the same request ID.
+func RequestIDInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	}
+	if len(md.Get(csiRequestIDKey)) != 1 {
+		md.Set(csiRequestIDKey, strconv.FormatUint(atomic.AddUint64(&lastRequestID, 1), 10))
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+	return handler(ctx, req)
+}
+
+// AuditInterceptor writes a record of every RPC that changes the storage to the audit log, when one is configured
+func (s *service) AuditInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if s.auditLog == nil || !auditedMethods[path.Base(info.FullMethod)] {
+		return handler(ctx, req)
+	}
+
+	resp, err := handler(ctx, req)
+
+	record := &auditRecord{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Method:    info.FullMethod,
+		Objects:   auditObjects(req, resp),
+		Requester: auditRequester(req),
+		Outcome:   auditOutcomeSuccess,
+		Code:      status.Code(err).String(),
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(csiRequestIDKey)) > 0 {
+		record.RequestID = md.Get(csiRequestIDKey)[0]
+	}
+	if err != nil {
+		record.Outcome = auditOutcomeFailure
+		record.Error = status.Convert(err).Message()
+	}
+	record.ArrayID = s.auditArrayID(req, record.Objects)
+
+	if werr := s.auditLog.write(record); werr != nil {
+		Log.WithError(werr).Errorf("unable to write audit record of %s: %+v", info.FullMethod, record)
+	}
+	return resp, err
+}
+
+// auditOperation writes a record of a change the driver makes to the storage on its own, outside of the audited
+// RPCs, when an audit log is configured. operation names the task and the change. The record carries the request
+// ID of ctx when the change is made while serving a request.
+func (s *service) auditOperation(ctx context.Context, operation, arrayID string, objects map[string]string, err error) {
+	if s.auditLog == nil {
+		return
+	}
+
+	record := &auditRecord{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Method:    operation,
+		ArrayID:   arrayID,
+		Objects:   objects,
+		Outcome:   auditOutcomeSuccess,
+		Code:      status.Code(err).String(),
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(csiRequestIDKey)) > 0 {
+		record.RequestID = md.Get(csiRequestIDKey)[0]
+	}
+	if err != nil {
+		record.Outcome = auditOutcomeFailure
+		record.Error = status.Convert(err).Message()
+	}
+
+	if werr := s.auditLog.write(record); werr != nil {
+		Log.WithError(werr).Errorf("unable to write audit record of %s: %+v", operation, record)
+	}
+}
+
+// auditObjects returns the IDs of the objects a request is made for and, on success, of the objects it created,
+// with the replication action, the new size or the QoS limits the request asks for
+func auditObjects(req, resp interface{}) map[string]string {
+	objects := make(map[string]string)
+	add := func(key, value string) {
+		if value != "" {
+			objects[key] = value
+		}
+	}
+
+	if r, ok := req.(interface{ GetName() string }); ok {
+		add("name", r.GetName())
+	}
+	if r, ok := req.(interface{ GetVolumeId() string }); ok {
+		add("volumeId", r.GetVolumeId())
+	}
+	if r, ok := req.(interface{ GetVolumeHandle() string }); ok {
+		add("volumeId", r.GetVolumeHandle())
+	}
+	if r, ok := req.(interface{ GetSourceVolumeId() string }); ok {
+		add("sourceVolumeId", r.GetSourceVolumeId())
+	}
+	if r, ok := req.(interface{ GetSnapshotId() string }); ok {
+		add("snapshotId", r.GetSnapshotId())
+	}
+	if r, ok := req.(interface{ GetNodeId() string }); ok {
+		add("nodeId", r.GetNodeId())
+	}
+	if r, ok := req.(interface{ GetProtectionGroupId() string }); ok {
+		add("protectionGroupId", r.GetProtectionGroupId())
+	}
+	if r, ok := req.(interface{ GetSourceVolumeIDs() []string }); ok && len(r.GetSourceVolumeIDs()) > 0 {
+		ids, _ := json.Marshal(r.GetSourceVolumeIDs())
+		add("sourceVolumeIds", string(ids))
+	}
+
+	switch r := req.(type) {
+	case *replication.ExecuteActionRequest:
+		if r.GetAction() != nil {
+			add("action", r.GetAction().GetActionTypes().String())
+		}
+	case *replicationext.ExecuteMultiGroupActionRequest:
+		add("action", r.GetActionType().String())
+	case *csi.ControllerExpandVolumeRequest:
+		if r.GetCapacityRange() != nil {
+			add("requiredBytes", strconv.FormatInt(r.GetCapacityRange().GetRequiredBytes(), 10))
+		}
+	case *csi.ControllerPublishVolumeRequest:
+		add(KeyBandwidthLimitInKbps, r.GetVolumeContext()[KeyBandwidthLimitInKbps])
+		add(KeyIopsLimit, r.GetVolumeContext()[KeyIopsLimit])
+	}
+
+	switch r := resp.(type) {
+	case *csi.CreateVolumeResponse:
+		add("volumeId", r.GetVolume().GetVolumeId())
+	case *csi.CreateSnapshotResponse:
+		add("snapshotId", r.GetSnapshot().GetSnapshotId())
+	case interface{ GetLocalProtectionGroupId() string }:
+		add("protectionGroupId", r.GetLocalProtectionGroupId())
+	}
+	return objects
+}
+
+// auditRequester returns the Kubernetes identity of the requester from the parameters or the volume context
+func auditRequester(req interface{}) map[string]string {
+	params := make(map[string]string)
+	if r, ok := req.(interface{ GetParameters() map[string]string }); ok {
+		for k, v := range r.GetParameters() {
+			params[k] = v
+		}
+	}
+	if r, ok := req.(interface{ GetVolumeContext() map[string]string }); ok {
+		for k, v := range r.GetVolumeContext() {
+			params[k] = v
+		}
+	}
+
+	requester := make(map[string]string)
+	for _, key := range auditRequesterKeys {
+		if value := params[key]; value != "" {
+			requester[key] = value
+		}
+	}
+	return requester
+}
+
+// auditArrayID returns the ID of the array a request is made on, from the IDs of its objects or its parameters
+func (s *service) auditArrayID(req interface{}, objects map[string]string) string {
+	for _, key := range []string{"volumeId", "sourceVolumeId", "snapshotId"} {
+		if id := objects[key]; id != "" {
+			if systemID := s.getSystemIDFromCsiVolumeID(id); systemID != "" {
+				return systemID
+			}
+		}
+	}
+	if r, ok := req.(interface{ GetParameters() map[string]string }); ok && r.GetParameters()[KeySystemID] != "" {
+		return r.GetParameters()[KeySystemID]
+	}
+	return s.defaultSystemID()
+}
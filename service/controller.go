@@ -33,6 +33,7 @@ import (
 	"github.com/dell/goscaleio"
 	siotypes "github.com/dell/goscaleio/types/v1"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/propagation"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -189,7 +190,7 @@ func (s *service) CreateVolume(
 	if accessibility != nil && len(accessibility.GetPreferred()) > 0 {
 		requestedSystem := ""
 		sID := ""
-		system := s.systems[systemID]
+		system := s.systemOf(systemID)
 		if system != nil {
 			sID = system.System.ID
 		}
@@ -260,6 +261,10 @@ func (s *service) CreateVolume(
 		}
 	}
 
+	if isNFS && strings.EqualFold(params[s.WithRP(KeyReplicationEnabled)], "true") {
+		return nil, status.Error(codes.Unimplemented, nfsReplicationNotSupported)
+	}
+
 	// fetch volume name
 	name := req.GetName()
 	if name == "" {
@@ -274,8 +279,8 @@ func (s *service) CreateVolume(
 	}
 
 	var arr *ArrayConnectionData
-	sysID := s.opts.defaultSystemID
-	arr = s.opts.arrays[sysID]
+	sysID := s.defaultSystemID()
+	arr = s.arrayOf(sysID)
 	volName := name
 
 	if isNFS {
@@ -287,7 +292,7 @@ func (s *service) CreateVolume(
 			Log.Debug("nasName not present in storage class, value taken from secret")
 			nasName = arr.NasName // Secret next
 		}
-		nasServerID, err := s.getNASServerIDFromName(systemID, nasName)
+		nasServerID, err := s.getNASServerIDFromName(ctx, systemID, nasName)
 		if err != nil {
 			return nil, err
 		}
@@ -298,7 +303,7 @@ func (s *service) CreateVolume(
 		if !ok {
 			Log.Printf("Protection Domain name not provided; there could be conflicts if two storage pools share a name")
 		} else {
-			pdID, err = s.getProtectionDomainIDFromName(systemID, pd)
+			pdID, err = s.getProtectionDomainIDFromName(ctx, systemID, pd)
 			if err != nil {
 				return nil, err
 			}
@@ -310,7 +315,7 @@ func (s *service) CreateVolume(
 			return nil, status.Errorf(codes.InvalidArgument,
 				"%s is a required parameter", KeyStoragePool)
 		}
-		storagePoolID, err := s.getStoragePoolID(storagePoolName, systemID, pdID)
+		storagePoolID, err := s.getStoragePoolID(ctx, storagePoolName, systemID, pdID)
 		if err != nil {
 			return nil, err
 		}
@@ -327,7 +332,7 @@ func (s *service) CreateVolume(
 			snapshotSource := contentSource.GetSnapshot()
 			if snapshotSource != nil {
 				Log.Printf("snapshot %s specified as volume content source", snapshotSource.SnapshotId)
-				return s.createVolumeFromSnapshot(req, snapshotSource, name, size, storagePoolName)
+				return s.createVolumeFromSnapshot(ctx, req, snapshotSource, name, size, storagePoolName)
 			}
 		}
 		// log all parameters used in CreateVolume call
@@ -350,15 +355,23 @@ func (s *service) CreateVolume(
 		}
 
 		// Idempotency check
-		system, err := s.adminClients[systemID].FindSystem(systemID, "", "")
+		var system *goscaleio.System
+		err = s.gatewayCall(ctx, systemID, "FindSystem", func() (err error) {
+			system, err = s.adminClientOf(systemID).FindSystem(systemID, "", "")
+			return err
+		})
 		if err != nil {
 			return nil, err
 		}
-		existingFS, err := system.GetFileSystemByIDName("", volName)
+		var existingFS *siotypes.FileSystem
+		err = s.gatewayCall(ctx, systemID, "GetFileSystemByIDName", func() (err error) {
+			existingFS, err = system.GetFileSystemByIDName("", volName)
+			return err
+		})
 
 		if existingFS != nil {
 			if existingFS.SizeTotal == int(size) {
-				vi := s.getCSIVolumeFromFilesystem(existingFS, systemID)
+				vi := s.getCSIVolumeFromFilesystem(ctx, existingFS, systemID)
 				vi.VolumeContext[KeyNasName] = nasName
 				vi.VolumeContext[KeyFsType] = fsType
 				nfsTopology := s.GetNfsTopology(systemID)
@@ -373,17 +386,25 @@ func (s *service) CreateVolume(
 			return nil, status.Error(codes.AlreadyExists, "'Volume name' already exists and size is different.")
 		}
 		Log.Debug("Volume does not exist, proceeding to create new volume")
-		fsResp, err := system.CreateFileSystem(volumeParam)
+		var fsResp *siotypes.FileSystemResp
+		err = s.gatewayCall(ctx, systemID, "CreateFileSystem", func() (err error) {
+			fsResp, err = system.CreateFileSystem(volumeParam)
+			return err
+		})
 		if err != nil {
 			Log.Debugf("Create volume response error:%v", err)
 			return nil, status.Errorf(codes.Unknown, "Create Volume %s failed with error: %v", volName, err)
 		}
 
 		// set quota limits, if specified in NFS storage class
-		isQuotaEnabled := s.opts.IsQuotaEnabled
+		isQuotaEnabled := s.reloadable().IsQuotaEnabled
 		if isQuotaEnabled {
 			// get filesystem (NFS volume), newly created
-			fs, err := system.GetFileSystemByIDName(fsResp.ID, "")
+			var fs *siotypes.FileSystem
+			err := s.gatewayCall(ctx, systemID, "GetFileSystemByIDName", func() (err error) {
+				fs, err = system.GetFileSystemByIDName(fsResp.ID, "")
+				return err
+			})
 			if err != nil {
 				Log.Debugf("Find Volume response error: %v", err)
 				return nil, status.Errorf(codes.Unknown, "Find Volume response error: %v", err)
@@ -404,10 +425,12 @@ func (s *service) CreateVolume(
 			}
 
 			// create quota for the filesystem
-			quotaID, err := s.createQuota(fsResp.ID, path, softLimit, gracePeriod, int(size), isQuotaEnabled, systemID)
+			quotaID, err := s.createQuota(ctx, fsResp.ID, path, softLimit, gracePeriod, int(size), isQuotaEnabled, systemID)
 			if err != nil {
 				// roll back, delete the newly created volume
-				if delErr := system.DeleteFileSystem(fs.Name); delErr != nil {
+				if delErr := s.gatewayCall(ctx, systemID, "DeleteFileSystem", func() error {
+					return system.DeleteFileSystem(fs.Name)
+				}); delErr != nil {
 					return nil, status.Errorf(codes.Internal,
 						"rollback (deleting volume '%s') failed with error : '%v'", fs.Name, delErr.Error())
 				}
@@ -418,13 +441,17 @@ func (s *service) CreateVolume(
 			Log.Infof("Tree quota set for: %d bytes on directory: '%s', quota ID: %s", size, path, quotaID)
 		}
 
-		newFs, err := system.GetFileSystemByIDName(fsResp.ID, "")
+		var newFs *siotypes.FileSystem
+		err = s.gatewayCall(ctx, systemID, "GetFileSystemByIDName", func() (err error) {
+			newFs, err = system.GetFileSystemByIDName(fsResp.ID, "")
+			return err
+		})
 		if err != nil {
 			Log.Debugf("Find Volume response error: %v", err)
 			return nil, status.Errorf(codes.Unknown, "Find Volume response error: %v", err)
 		}
 		if newFs != nil {
-			vi := s.getCSIVolumeFromFilesystem(newFs, systemID)
+			vi := s.getCSIVolumeFromFilesystem(ctx, newFs, systemID)
 			vi.VolumeContext[KeyNasName] = nasName
 			vi.VolumeContext[KeyFsType] = fsType
 			nfsTopology := s.GetNfsTopology(systemID)
@@ -454,7 +481,7 @@ func (s *service) CreateVolume(
 		if !ok {
 			Log.Printf("Protection Domain name not provided; there could be conflicts if two storage pools share a name")
 		} else {
-			pdID, err = s.getProtectionDomainIDFromName(systemID, pd)
+			pdID, err = s.getProtectionDomainIDFromName(ctx, systemID, pd)
 			if err != nil {
 				return nil, err
 			}
@@ -467,12 +494,12 @@ func (s *service) CreateVolume(
 			volumeSource := contentSource.GetVolume()
 			if volumeSource != nil {
 				Log.Printf("volume %s specified as volume content source", volumeSource.VolumeId)
-				return s.Clone(req, volumeSource, name, size, sp)
+				return s.Clone(ctx, req, volumeSource, name, size, sp)
 			}
 			snapshotSource := contentSource.GetSnapshot()
 			if snapshotSource != nil {
 				Log.Printf("snapshot %s specified as volume content source", snapshotSource.SnapshotId)
-				return s.createVolumeFromSnapshot(req, snapshotSource, name, size, sp)
+				return s.createVolumeFromSnapshot(ctx, req, snapshotSource, name, size, sp)
 			}
 		}
 
@@ -505,11 +532,16 @@ func (s *service) CreateVolume(
 			t.MetaData().Set(HeaderPersistentVolumeClaimNamespace, params[CSIPersistentVolumeClaimNamespace])
 			t.MetaData().Set(HeaderCSIPluginIdentifier, Name)
 			t.MetaData().Set(HeaderSystemIdentifier, systemID)
+			injectTraceContext(ctx, propagation.HeaderCarrier(t.MetaData()))
 		} else {
 			Log.Println("warning: goscaleio.VolumeParam: no MetaData method exists, consider updating goscaleio library.")
 		}
 
-		createResp, err := s.adminClients[systemID].CreateVolume(volumeParam, sp, pdID)
+		var createResp *siotypes.VolumeResp
+		err = s.gatewayCall(ctx, systemID, "CreateVolume", func() (err error) {
+			createResp, err = s.adminClientOf(systemID).CreateVolume(volumeParam, sp, pdID)
+			return err
+		})
 		if err != nil {
 			// handle case where volume already exists
 			if !strings.EqualFold(err.Error(), sioGatewayVolumeNameInUse) {
@@ -522,7 +554,10 @@ func (s *service) CreateVolume(
 		var id string
 		if createResp == nil {
 			// volume already exists, look it up by name
-			id, err = s.adminClients[systemID].FindVolumeID(name)
+			err = s.gatewayCall(ctx, systemID, "FindVolumeID", func() (err error) {
+				id, err = s.adminClientOf(systemID).FindVolumeID(name)
+				return err
+			})
 			if err != nil {
 				return nil, status.Errorf(codes.Internal, "%s", err.Error())
 			}
@@ -530,17 +565,17 @@ func (s *service) CreateVolume(
 			id = createResp.ID
 		}
 
-		vol, err := s.getVolByID(id, systemID)
+		vol, err := s.getVolByID(ctx, id, systemID)
 		if err != nil {
 			return nil, status.Errorf(codes.Unavailable,
 				"error retrieving volume details: %s", err.Error())
 		}
-		vi := s.getCSIVolume(vol, systemID)
+		vi := s.getCSIVolume(ctx, vol, systemID)
 		vi.AccessibleTopology = volumeTopology
 
 		// since the volume could have already exists, double check that the
 		// volume has the expected parameters
-		spID, err := s.getStoragePoolID(sp, systemID, pdID)
+		spID, err := s.getStoragePoolID(ctx, sp, systemID, pdID)
 		if err != nil {
 			return nil, status.Errorf(codes.Unavailable,
 				"volume exists, but could not verify parameters: %s",
@@ -566,13 +601,13 @@ func (s *service) CreateVolume(
 		s.clearCache()
 
 		volumeID := getVolumeIDFromCsiVolumeID(vi.VolumeId)
-		vol, err = s.getVolByID(volumeID, systemID)
+		vol, err = s.getVolByID(ctx, volumeID, systemID)
 
 		counter := 0
 
 		for err != nil && counter < 100 {
 			time.Sleep(3 * time.Millisecond)
-			vol, err = s.getVolByID(volumeID, systemID)
+			vol, err = s.getVolByID(ctx, volumeID, systemID)
 			counter = counter + 1
 		}
 		return csiResp, err
@@ -581,14 +616,22 @@ func (s *service) CreateVolume(
 	return nil, status.Errorf(codes.NotFound, "Volume not found after create. %v", err)
 }
 
-func (s *service) createQuota(fsID, path, softLimit, gracePeriod string, size int, isQuotaEnabled bool, systemID string) (string, error) {
-	system, err := s.adminClients[systemID].FindSystem(systemID, "", "")
+func (s *service) createQuota(ctx context.Context, fsID, path, softLimit, gracePeriod string, size int, isQuotaEnabled bool, systemID string) (string, error) {
+	var system *goscaleio.System
+	err := s.gatewayCall(ctx, systemID, "FindSystem", func() (err error) {
+		system, err = s.adminClientOf(systemID).FindSystem(systemID, "", "")
+		return err
+	})
 	if err != nil {
 		return "", err
 	}
 
 	// enabling quota on FS
-	fs, err := system.GetFileSystemByIDName(fsID, "")
+	var fs *siotypes.FileSystem
+	err = s.gatewayCall(ctx, systemID, "GetFileSystemByIDName", func() (err error) {
+		fs, err = system.GetFileSystemByIDName(fsID, "")
+		return err
+	})
 	if err != nil {
 		Log.Debugf("Find Volume response error: %v", err)
 		return "", status.Errorf(codes.Unknown, "Find Volume response error: %v", err)
@@ -608,13 +651,18 @@ func (s *service) createQuota(fsID, path, softLimit, gracePeriod string, size in
 		IsQuotaEnabled: isQuotaEnabled,
 	}
 
-	err = system.ModifyFileSystem(fsModify, fs.ID)
+	err = s.gatewayCall(ctx, systemID, "ModifyFileSystem", func() error {
+		return system.ModifyFileSystem(fsModify, fs.ID)
+	})
 	if err != nil {
 		Log.Debugf("Modify NFS volume failed with error: %v", err)
 		return "", status.Errorf(codes.Unknown, "Modify NFS volume failed with error: %v", err)
 	}
 
-	fs, err = system.GetFileSystemByIDName(fsID, "")
+	err = s.gatewayCall(ctx, systemID, "GetFileSystemByIDName", func() (err error) {
+		fs, err = system.GetFileSystemByIDName(fsID, "")
+		return err
+	})
 	if err != nil {
 		Log.Debugf("Find NFS volume response error: %v", err)
 		return "", status.Errorf(codes.Unknown, "Find NFS volume response error: %v", err)
@@ -655,7 +703,11 @@ func (s *service) createQuota(fsID, path, softLimit, gracePeriod string, size in
 		SoftLimit:    int(softLimitInt),
 		GracePeriod:  int(gracePeriodInt),
 	}
-	quota, err := system.CreateTreeQuota(createQuotaParams)
+	var quota *siotypes.TreeQuotaCreateResponse
+	err = s.gatewayCall(ctx, systemID, "CreateTreeQuota", func() (err error) {
+		quota, err = system.CreateTreeQuota(createQuotaParams)
+		return err
+	})
 	if err != nil {
 		Log.Debugf("Creating quota failed with error: %v", err)
 		return "", status.Errorf(codes.Unknown, "Creating quota failed with error: %v", err)
@@ -719,10 +771,11 @@ func (s *service) getSystemIDFromParameters(params map[string]string) (string, e
 
 	// systemID not found in storage class params, use the default array
 	if systemID == "" {
-		if s.opts.defaultSystemID != "" {
-			systemID = s.opts.defaultSystemID
-		} else if len(s.opts.arrays) == 1 {
-			for id := range s.opts.arrays { // use the only provided array
+		arrays := s.arrayConfigs()
+		if defaultSystemID := s.defaultSystemID(); defaultSystemID != "" {
+			systemID = defaultSystemID
+		} else if len(arrays) == 1 {
+			for id := range arrays { // use the only provided array
 				systemID = id
 			}
 		} else {
@@ -733,7 +786,7 @@ func (s *service) getSystemIDFromParameters(params map[string]string) (string, e
 
 	// if name set for array.SystemID use id instead
 	// names can change , id will remain unique
-	if id, ok := s.connectedSystemNameToID[systemID]; ok {
+	if id, ok := s.systemIDByName(systemID); ok {
 		systemID = id
 	}
 	Log.Printf("Use systemID as %s", systemID)
@@ -742,7 +795,7 @@ func (s *service) getSystemIDFromParameters(params map[string]string) (string, e
 
 // Create a volume (which is actually a snapshot) from an existing snapshot.
 // The snapshotSource gives the SnapshotId which is the volume to be replicated.
-func (s *service) createVolumeFromSnapshot(req *csi.CreateVolumeRequest,
+func (s *service) createVolumeFromSnapshot(ctx context.Context, req *csi.CreateVolumeRequest,
 	snapshotSource *csi.VolumeContentSource_SnapshotSource,
 	name string, sizeInKbytes int64, storagePool string,
 ) (*csi.CreateVolumeResponse, error) {
@@ -759,7 +812,7 @@ func (s *service) createVolumeFromSnapshot(req *csi.CreateVolumeRequest,
 	systemID := s.getSystemIDFromCsiVolumeID(snapshotSource.SnapshotId)
 	if systemID == "" {
 		// use default system
-		systemID = s.opts.defaultSystemID
+		systemID = s.defaultSystemID()
 	}
 	if systemID == "" {
 		return nil, status.Error(codes.InvalidArgument,
@@ -770,7 +823,7 @@ func (s *service) createVolumeFromSnapshot(req *csi.CreateVolumeRequest,
 		// Look up the snapshot
 		fmt.Println("snapshotSource.SnapshotId", snapshotSource.SnapshotId)
 		snapID := getFilesystemIDFromCsiVolumeID(snapshotSource.SnapshotId)
-		srcVol, err := s.getFilesystemByID(snapID, systemID)
+		srcVol, err := s.getFilesystemByID(ctx, snapID, systemID)
 		if err != nil {
 			return nil, status.Errorf(codes.NotFound, "Snapshot not found: %s, error: %s", snapshotSource.SnapshotId, err.Error())
 		}
@@ -782,30 +835,37 @@ func (s *service) createVolumeFromSnapshot(req *csi.CreateVolumeRequest,
 				snapshotSource.SnapshotId, srcVol.SizeTotal, sizeInKbytes)
 		}
 
-		system := s.systems[systemID]
+		system := s.systemOf(systemID)
 
 		// Validate the storagePool is the same.
-		snapStoragePool := s.getStoragePoolNameFromID(systemID, srcVol.StoragePoolID)
+		snapStoragePool := s.getStoragePoolNameFromID(ctx, systemID, srcVol.StoragePoolID)
 		if snapStoragePool != storagePool {
 			return nil, status.Errorf(codes.InvalidArgument,
 				"Snapshot storage pool %s is different than the requested storage pool %s", snapStoragePool, storagePool)
 		}
 
-		_, err = system.RestoreFileSystemFromSnapshot(&siotypes.RestoreFsSnapParam{
-			SnapshotID: snapID,
-		}, srcVol.ParentID)
+		err = s.gatewayCall(ctx, systemID, "RestoreFileSystemFromSnapshot", func() error {
+			_, err := system.RestoreFileSystemFromSnapshot(&siotypes.RestoreFsSnapParam{
+				SnapshotID: snapID,
+			}, srcVol.ParentID)
+			return err
+		})
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "error during fs creation from snapshot: %s, error: %s", snapshotSource.SnapshotId, err.Error())
 		}
 
-		restoreFs, err := system.GetFileSystemByIDName(srcVol.ParentID, "")
+		var restoreFs *siotypes.FileSystem
+		err = s.gatewayCall(ctx, systemID, "GetFileSystemByIDName", func() (err error) {
+			restoreFs, err = system.GetFileSystemByIDName(srcVol.ParentID, "")
+			return err
+		})
 		if err != nil {
 			if strings.Contains(err.Error(), sioGatewayFileSystemNotFound) {
 				return nil, status.Errorf(codes.NotFound, "NFS volume not found: %s, error: %s", srcVol.ID, err.Error())
 			}
 		}
 
-		csiVolume := s.getCSIVolumeFromFilesystem(restoreFs, systemID)
+		csiVolume := s.getCSIVolumeFromFilesystem(ctx, restoreFs, systemID)
 
 		csiVolume.ContentSource = req.GetVolumeContentSource()
 		copyInterestingParameters(req.GetParameters(), csiVolume.VolumeContext)
@@ -818,7 +878,7 @@ func (s *service) createVolumeFromSnapshot(req *csi.CreateVolumeRequest,
 
 	// Look up the snapshot
 	snapID := getVolumeIDFromCsiVolumeID(snapshotSource.SnapshotId)
-	srcVol, err := s.getVolByID(snapID, systemID)
+	srcVol, err := s.getVolByID(ctx, snapID, systemID)
 	if err != nil {
 		if err != nil {
 			return nil, status.Errorf(codes.NotFound, "Snapshot not found: %s, error: %s", snapshotSource.SnapshotId, err.Error())
@@ -831,18 +891,22 @@ func (s *service) createVolumeFromSnapshot(req *csi.CreateVolumeRequest,
 			snapshotSource.SnapshotId, srcVol.SizeInKb, sizeInKbytes)
 	}
 
-	adminClient := s.adminClients[systemID]
-	system := s.systems[systemID]
+	adminClient := s.adminClientOf(systemID)
+	system := s.systemOf(systemID)
 
 	// Validate the storagePool is the same.
-	snapStoragePool := s.getStoragePoolNameFromID(systemID, srcVol.StoragePoolID)
+	snapStoragePool := s.getStoragePoolNameFromID(ctx, systemID, srcVol.StoragePoolID)
 	if snapStoragePool != storagePool {
 		return nil, status.Errorf(codes.InvalidArgument,
 			"Snapshot storage pool %s is different than the requested storage pool %s", snapStoragePool, storagePool)
 	}
 
 	// Check for idempotent request
-	existingVols, err := adminClient.GetVolume("", "", "", name, false)
+	var existingVols []*siotypes.Volume
+	err = s.gatewayCall(ctx, systemID, "GetVolume", func() (err error) {
+		existingVols, err = adminClient.GetVolume("", "", "", name, false)
+		return err
+	})
 	noVolErrString1 := "Error: problem finding volume: Volume not found"
 	noVolErrString2 := "Error: problem finding volume: Could not find the volume"
 	if (err != nil) && !(strings.Contains(err.Error(), noVolErrString1) || strings.Contains(err.Error(), noVolErrString2)) {
@@ -853,7 +917,7 @@ func (s *service) createVolumeFromSnapshot(req *csi.CreateVolumeRequest,
 	for _, vol := range existingVols {
 		if vol.Name == name && vol.StoragePoolID == srcVol.StoragePoolID {
 			Log.Printf("Requested volume %s already exists", name)
-			csiVolume := s.getCSIVolume(vol, systemID)
+			csiVolume := s.getCSIVolume(ctx, vol, systemID)
 			csiVolume.ContentSource = req.GetVolumeContentSource()
 			copyInterestingParameters(req.GetParameters(), csiVolume.VolumeContext)
 			Log.Printf("Requested volume (from snap) already exists %s (%s) storage pool %s",
@@ -869,7 +933,11 @@ func (s *service) createVolumeFromSnapshot(req *csi.CreateVolumeRequest,
 	snapParam := &siotypes.SnapshotVolumesParam{SnapshotDefs: snapshotDefs, AccessMode: "ReadWrite"}
 
 	// Create snapshot
-	snapResponse, err := system.CreateSnapshotConsistencyGroup(snapParam)
+	var snapResponse *siotypes.SnapshotVolumesResp
+	err = s.gatewayCall(ctx, systemID, "CreateSnapshotConsistencyGroup", func() (err error) {
+		snapResponse, err = system.CreateSnapshotConsistencyGroup(snapParam)
+		return err
+	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Failed to create snapshot: %s", err.Error())
 	}
@@ -879,13 +947,13 @@ func (s *service) createVolumeFromSnapshot(req *csi.CreateVolumeRequest,
 
 	// Retrieve created destination volume
 	dstID := snapResponse.VolumeIDList[0]
-	dstVol, err := s.getVolByID(dstID, systemID)
+	dstVol, err := s.getVolByID(ctx, dstID, systemID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not retrieve created volume: %s, error: %s", dstID, err.Error())
 	}
 	// Create a volume response and return it
 	s.clearCache()
-	csiVolume := s.getCSIVolume(dstVol, systemID)
+	csiVolume := s.getCSIVolume(ctx, dstVol, systemID)
 	csiVolume.ContentSource = req.GetVolumeContentSource()
 	copyInterestingParameters(req.GetParameters(), csiVolume.VolumeContext)
 
@@ -965,7 +1033,7 @@ func (s *service) DeleteVolume(
 
 	isNFS := strings.Contains(csiVolID, "/")
 	// ensure no ambiguity if legacy vol
-	err := s.checkVolumesMap(csiVolID)
+	err := s.checkVolumesMap(ctx, csiVolID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal,
 			"checkVolumesMap for id: %s failed : %s", csiVolID, err.Error())
@@ -976,7 +1044,7 @@ func (s *service) DeleteVolume(
 		systemID := s.getSystemIDFromCsiVolumeID(csiVolID)
 		if systemID == "" {
 			// use default system
-			systemID = s.opts.defaultSystemID
+			systemID = s.defaultSystemID()
 		}
 
 		if systemID == "" {
@@ -989,12 +1057,20 @@ func (s *service) DeleteVolume(
 		}
 
 		s.logStatistics()
-		system, err := s.adminClients[systemID].FindSystem(systemID, "", "")
+		var system *goscaleio.System
+		err := s.gatewayCall(ctx, systemID, "FindSystem", func() (err error) {
+			system, err = s.adminClientOf(systemID).FindSystem(systemID, "", "")
+			return err
+		})
 		if err != nil {
 			return nil, err
 		}
 		fsID := getFilesystemIDFromCsiVolumeID(csiVolID)
-		toBeDeletedFS, err := system.GetFileSystemByIDName(fsID, "")
+		var toBeDeletedFS *siotypes.FileSystem
+		err = s.gatewayCall(ctx, systemID, "GetFileSystemByIDName", func() (err error) {
+			toBeDeletedFS, err = system.GetFileSystemByIDName(fsID, "")
+			return err
+		})
 		if err != nil {
 			if strings.Contains(err.Error(), sioGatewayFileSystemNotFound) {
 				Log.WithFields(logrus.Fields{"id": fsID}).Debug("NFS volume does not exist", fsID)
@@ -1002,7 +1078,11 @@ func (s *service) DeleteVolume(
 			}
 		}
 
-		listSnaps, err := system.GetFsSnapshotsByVolumeID(fsID)
+		var listSnaps []siotypes.FileSystem
+		err = s.gatewayCall(ctx, systemID, "GetFsSnapshotsByVolumeID", func() (err error) {
+			listSnaps, err = system.GetFsSnapshotsByVolumeID(fsID)
+			return err
+		})
 		if err != nil {
 			return nil, status.Errorf(codes.Unknown, "failure getting snapshot: %s", err.Error())
 		}
@@ -1016,9 +1096,9 @@ func (s *service) DeleteVolume(
 		fsName := toBeDeletedFS.Name
 
 		// Check if nfs export exists for the File system
-		client := s.adminClients[systemID]
+		client := s.adminClientOf(systemID)
 
-		nfsExport, err := s.getNFSExport(toBeDeletedFS, client)
+		nfsExport, err := s.getNFSExport(ctx, systemID, toBeDeletedFS, client)
 		if err != nil {
 			if !strings.Contains(err.Error(), "not found") {
 				return nil, status.Errorf(codes.Internal,
@@ -1033,8 +1113,8 @@ func (s *service) DeleteVolume(
 				len(nfsExport.ReadWriteRootHosts) > 0) {
 			// if one entry is there for RWRootHosts or RWHosts, check if this is the same externalAccess defined in value.yaml
 			// if yes modifyNFSExport and remove externalAccess from the HostAcceesList on the array
-			if (len(nfsExport.ReadWriteRootHosts) == 1 || len(nfsExport.ReadWriteHosts) == 1) && s.opts.ExternalAccess != "" {
-				externalAccess := s.opts.ExternalAccess
+			externalAccess := s.reloadable().ExternalAccess
+			if (len(nfsExport.ReadWriteRootHosts) == 1 || len(nfsExport.ReadWriteHosts) == 1) && externalAccess != "" {
 				modifyNFSExport := false
 				// we need to construct the payload dynamically otherwise 400 error will be thrown
 				var modifyParam *siotypes.NFSExportModify = &siotypes.NFSExportModify{}
@@ -1051,7 +1131,9 @@ func (s *service) DeleteVolume(
 				}
 				// call ModifyNFSExport API only when modifyParam payload is not empty i.e. something is there to modify
 				if modifyNFSExport {
-					err = client.ModifyNFSExport(modifyParam, fsID)
+					err = s.gatewayCall(ctx, systemID, "ModifyNFSExport", func() error {
+						return client.ModifyNFSExport(modifyParam, fsID)
+					})
 					if err != nil {
 						Log.Warn("failure when removing externalAccess from nfs export: ", err.Error())
 					}
@@ -1069,7 +1151,9 @@ func (s *service) DeleteVolume(
 		}
 
 		Log.WithFields(logrus.Fields{"name": fsName, "id": fsID}).Info("Deleting NFS volume")
-		err = system.DeleteFileSystem(fsName)
+		err = s.gatewayCall(ctx, systemID, "DeleteFileSystem", func() error {
+			return system.DeleteFileSystem(fsName)
+		})
 		if err != nil {
 			if strings.Contains(err.Error(), sioGatewayFileSystemNotFound) {
 				return &csi.DeleteVolumeResponse{}, nil
@@ -1084,7 +1168,7 @@ func (s *service) DeleteVolume(
 	systemID := s.getSystemIDFromCsiVolumeID(csiVolID)
 	if systemID == "" {
 		// use default system
-		systemID = s.opts.defaultSystemID
+		systemID = s.defaultSystemID()
 	}
 
 	if systemID == "" {
@@ -1099,7 +1183,7 @@ func (s *service) DeleteVolume(
 	s.logStatistics()
 
 	volID := getVolumeIDFromCsiVolumeID(csiVolID)
-	vol, err := s.getVolByID(volID, systemID)
+	vol, err := s.getVolByID(ctx, volID, systemID)
 	if err != nil {
 		if strings.EqualFold(err.Error(), sioGatewayVolumeNotFound) {
 			Log.WithFields(logrus.Fields{"id": csiVolID}).Debug("volume is already deleted", csiVolID)
@@ -1131,7 +1215,7 @@ func (s *service) DeleteVolume(
 	// If volume is marked for replication, remove the replication pair first.
 	if vol.VolumeReplicationState != "UnmarkedForReplication" {
 		Log.Printf("[DeleteVolume] - vol: %+v", vol)
-		pair, err := s.removeVolumeFromReplicationPair(systemID, volID)
+		pair, err := s.removeVolumeFromReplicationPair(ctx, systemID, volID)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal,
 				"error removing replication pair: %s", err.Error())
@@ -1140,20 +1224,22 @@ func (s *service) DeleteVolume(
 	}
 
 	Log.WithFields(logrus.Fields{"name": vol.Name, "id": csiVolID}).Info("Deleting volume")
-	tgtVol := goscaleio.NewVolume(s.adminClients[systemID])
+	tgtVol := goscaleio.NewVolume(s.adminClientOf(systemID))
 	tgtVol.Volume = vol
-	err = tgtVol.RemoveVolume(removeModeOnlyMe)
+	err = s.gatewayCall(ctx, systemID, "RemoveVolume", func() error {
+		return tgtVol.RemoveVolume(removeModeOnlyMe)
+	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal,
 			"error removing volume: %s", err.Error())
 	}
 
-	vol, err = s.getVolByID(volID, systemID)
+	vol, err = s.getVolByID(ctx, volID, systemID)
 	counter := 0
 
 	for err != nil && strings.Contains(err.Error(), sioVolumeRemovalOperationInProgress) && counter < 100 {
 		time.Sleep(3 * time.Millisecond)
-		vol, err = s.getVolByID(volID, systemID)
+		vol, err = s.getVolByID(ctx, volID, systemID)
 		counter = counter + 1
 	}
 
@@ -1175,7 +1261,7 @@ func (s *service) ControllerPublishVolume(
 	if volumeContext != nil {
 		Log.Printf("VolumeContext:")
 		for key, value := range volumeContext {
-			Log.Printf("    [%s]=%s", key, value)
+			Log.Printf("    [%s]=%s", key, logRedactor.redactValue(key, value))
 		}
 	}
 
@@ -1195,7 +1281,7 @@ func (s *service) ControllerPublishVolume(
 	systemID := s.getSystemIDFromCsiVolumeID(csiVolID)
 	if systemID == "" {
 		// use default system
-		systemID = s.opts.defaultSystemID
+		systemID = s.defaultSystemID()
 	}
 	if systemID == "" {
 		return nil, status.Error(codes.InvalidArgument,
@@ -1205,12 +1291,12 @@ func (s *service) ControllerPublishVolume(
 	if err := s.requireProbe(ctx, systemID); err != nil {
 		return nil, err
 	}
-	adminClient := s.adminClients[systemID]
+	adminClient := s.adminClientOf(systemID)
 
 	s.logStatistics()
 
 	// ensure no ambiguity if legacy vol
-	err := s.checkVolumesMap(csiVolID)
+	err := s.checkVolumesMap(ctx, csiVolID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal,
 			"checkVolumesMap for id: %s failed : %s", csiVolID, err.Error())
@@ -1230,7 +1316,7 @@ func (s *service) ControllerPublishVolume(
 	}
 	if isNFS {
 		fsID := getFilesystemIDFromCsiVolumeID(csiVolID)
-		fs, err := s.getFilesystemByID(fsID, systemID)
+		fs, err := s.getFilesystemByID(ctx, fsID, systemID)
 		if err != nil {
 			if strings.EqualFold(err.Error(), sioGatewayFileSystemNotFound) || strings.Contains(err.Error(), "must be a hexadecimal number") {
 				return nil, status.Error(codes.NotFound,
@@ -1241,14 +1327,14 @@ func (s *service) ControllerPublishVolume(
 				err.Error())
 		}
 
-		sdcIPs, err := s.getSDCIPs(nodeID, systemID)
+		sdcIPs, err := s.getSDCIPs(ctx, nodeID, systemID)
 		if err != nil {
 			return nil, status.Errorf(codes.NotFound, "%s", err.Error())
 		} else if len(sdcIPs) == 0 {
 			return nil, status.Errorf(codes.NotFound, "%s", "received empty sdcIPs")
 		}
 
-		externalAccess := s.opts.ExternalAccess
+		externalAccess := s.reloadable().ExternalAccess
 		publishContext["host"] = sdcIPs[0]
 
 		fsc := req.GetVolumeCapability()
@@ -1267,11 +1353,11 @@ func (s *service) ControllerPublishVolume(
 				errUnknownAccessMode)
 		}
 		// Export for NFS
-		resp, err := s.exportFilesystem(ctx, req, adminClient, fs, sdcIPs, externalAccess, nodeID, publishContext, am)
+		resp, err := s.exportFilesystem(ctx, req, systemID, adminClient, fs, sdcIPs, externalAccess, nodeID, publishContext, am)
 		return resp, err
 	}
 	volID := getVolumeIDFromCsiVolumeID(csiVolID)
-	vol, err := s.getVolByID(volID, systemID)
+	vol, err := s.getVolByID(ctx, volID, systemID)
 	if err != nil {
 		if strings.EqualFold(err.Error(), sioGatewayVolumeNotFound) || strings.Contains(err.Error(), "must be a hexadecimal number") {
 			return nil, status.Error(codes.NotFound,
@@ -1282,7 +1368,7 @@ func (s *service) ControllerPublishVolume(
 			err.Error())
 	}
 
-	sdcID, err := s.getSDCID(nodeID, systemID)
+	sdcID, err := s.getSDCID(ctx, nodeID, systemID)
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "%s", err.Error())
 	}
@@ -1378,7 +1464,9 @@ func (s *service) ControllerPublishVolume(
 	targetVolume := goscaleio.NewVolume(adminClient)
 	targetVolume.Volume = &siotypes.Volume{ID: vol.ID}
 
-	err = targetVolume.MapVolumeSdc(mapVolumeSdcParam)
+	err = s.gatewayCall(ctx, systemID, "MapVolumeSdc", func() error {
+		return targetVolume.MapVolumeSdc(mapVolumeSdcParam)
+	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal,
 			"error mapping volume to node: %s", err.Error())
@@ -1428,10 +1516,10 @@ func (s *service) setQoSParameters(
 	nodeID string,
 ) error {
 	Log.Infof("Setting QoS limits for volume %s, mapped to SDC %s", volumeName, sdcID)
-	adminClient := s.adminClients[systemID]
+	adminClient := s.adminClientOf(systemID)
 	tgtVol := goscaleio.NewVolume(adminClient)
 	volID := getVolumeIDFromCsiVolumeID(csiVolID)
-	vol, err := s.getVolByID(volID, systemID)
+	vol, err := s.getVolByID(ctx, volID, systemID)
 	if err != nil {
 		return status.Errorf(codes.NotFound, "volume %s was not found, error: %s", volID, err.Error())
 	}
@@ -1441,7 +1529,9 @@ func (s *service) setQoSParameters(
 		BandwidthLimitInKbps: bandwidthLimit,
 		IopsLimit:            iopsLimit,
 	}
-	err = tgtVol.SetMappedSdcLimits(&settings)
+	err = s.gatewayCall(ctx, systemID, "SetMappedSdcLimits", func() error {
+		return tgtVol.SetMappedSdcLimits(&settings)
+	})
 	if err != nil {
 		// unpublish the volume
 		Log.Errorf("unpublishing volume since error in setting QoS parameters for volume: %s, error: %s", volumeName, err.Error())
@@ -1516,7 +1606,7 @@ func (s *service) ControllerUnpublishVolume(
 	systemID := s.getSystemIDFromCsiVolumeID(req.GetVolumeId())
 	if systemID == "" {
 		// use default system
-		systemID = s.opts.defaultSystemID
+		systemID = s.defaultSystemID()
 	}
 
 	if systemID == "" {
@@ -1536,7 +1626,7 @@ func (s *service) ControllerUnpublishVolume(
 			"volume ID is required")
 	}
 	// ensure no ambiguity if legacy vol
-	err := s.checkVolumesMap(csiVolID)
+	err := s.checkVolumesMap(ctx, csiVolID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal,
 			"checkVolumesMap for id: %s failed : %s", csiVolID, err.Error())
@@ -1547,13 +1637,13 @@ func (s *service) ControllerUnpublishVolume(
 			"Node ID is required")
 	}
 
-	adminClient := s.adminClients[systemID]
+	adminClient := s.adminClientOf(systemID)
 
 	isNFS := strings.Contains(csiVolID, "/")
 
 	if isNFS {
 		fsID := getFilesystemIDFromCsiVolumeID(csiVolID)
-		fs, err := s.getFilesystemByID(fsID, systemID)
+		fs, err := s.getFilesystemByID(ctx, fsID, systemID)
 		if err != nil {
 			if strings.EqualFold(err.Error(), sioGatewayFileSystemNotFound) || strings.Contains(err.Error(), "must be a hexadecimal number") {
 				return nil, status.Error(codes.NotFound,
@@ -1564,7 +1654,7 @@ func (s *service) ControllerUnpublishVolume(
 				err.Error())
 		}
 
-		sdcIPs, err := s.getSDCIPs(nodeID, systemID)
+		sdcIPs, err := s.getSDCIPs(ctx, nodeID, systemID)
 		if err != nil {
 			return nil, status.Errorf(codes.NotFound, "%s", err.Error())
 		} else if len(sdcIPs) == 0 {
@@ -1572,7 +1662,7 @@ func (s *service) ControllerUnpublishVolume(
 		}
 
 		// unexport for NFS
-		err = s.unexportFilesystem(ctx, req, adminClient, fs, req.GetVolumeId(), sdcIPs, nodeID)
+		err = s.unexportFilesystem(ctx, req, systemID, adminClient, fs, req.GetVolumeId(), sdcIPs, nodeID)
 		if err != nil {
 			return nil, err
 		}
@@ -1581,7 +1671,7 @@ func (s *service) ControllerUnpublishVolume(
 	}
 
 	volID := getVolumeIDFromCsiVolumeID(csiVolID)
-	vol, err := s.getVolByID(volID, systemID)
+	vol, err := s.getVolByID(ctx, volID, systemID)
 	if err != nil {
 		if strings.EqualFold(err.Error(), sioGatewayVolumeNotFound) {
 			return nil, status.Error(codes.NotFound,
@@ -1592,7 +1682,7 @@ func (s *service) ControllerUnpublishVolume(
 			err.Error())
 	}
 
-	sdcID, err := s.getSDCID(nodeID, systemID)
+	sdcID, err := s.getSDCID(ctx, nodeID, systemID)
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "%s", err.Error())
 	}
@@ -1618,7 +1708,10 @@ func (s *service) ControllerUnpublishVolume(
 		AllSdcs: "",
 	}
 
-	if err = targetVolume.UnmapVolumeSdc(unmapVolumeSdcParam); err != nil {
+	err = s.gatewayCall(ctx, systemID, "UnmapVolumeSdc", func() error {
+		return targetVolume.UnmapVolumeSdc(unmapVolumeSdcParam)
+	})
+	if err != nil {
 		return nil, status.Errorf(codes.Internal,
 			"Error unmapping volume from node: %s", err.Error())
 	}
@@ -1637,7 +1730,7 @@ func (s *service) ValidateVolumeCapabilities(
 			"volume ID is required")
 	}
 	// ensure no ambiguity if legacy vol
-	err := s.checkVolumesMap(csiVolID)
+	err := s.checkVolumesMap(ctx, csiVolID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal,
 			"checkVolumesMap for id: %s failed : %s", csiVolID, err.Error())
@@ -1647,7 +1740,7 @@ func (s *service) ValidateVolumeCapabilities(
 	systemID := s.getSystemIDFromCsiVolumeID(csiVolID)
 	if systemID == "" {
 		// use default system
-		systemID = s.opts.defaultSystemID
+		systemID = s.defaultSystemID()
 	}
 
 	if systemID == "" {
@@ -1660,7 +1753,7 @@ func (s *service) ValidateVolumeCapabilities(
 	}
 
 	volID := getVolumeIDFromCsiVolumeID(csiVolID)
-	_, err = s.getVolByID(volID, systemID)
+	_, err = s.getVolByID(ctx, volID, systemID)
 	if err != nil {
 		if strings.EqualFold(err.Error(), sioGatewayVolumeNotFound) || strings.Contains(err.Error(), "must be a hexadecimal number") {
 			return nil, status.Error(codes.NotFound,
@@ -1767,7 +1860,7 @@ func (s *service) ListVolumes(
 	*csi.ListVolumesResponse, error,
 ) {
 	// TODO: Implement this method to get volumes from all systems. Currently we get volumes only from default system
-	systemID := s.opts.defaultSystemID
+	systemID := s.defaultSystemID()
 	if systemID != "" {
 		if err := s.requireProbe(ctx, systemID); err != nil {
 			Log.Printf("Could not probe system: %s", systemID)
@@ -1797,7 +1890,7 @@ func (s *service) ListVolumes(
 	}
 
 	// Call the common listVolumes code
-	source, nextToken, err := s.listVolumes(systemID, startToken, maxEntries, true, s.opts.EnableListVolumesSnapshots, "", "")
+	source, nextToken, err := s.listVolumes(ctx, systemID, startToken, maxEntries, true, s.opts.EnableListVolumesSnapshots, "", "")
 	if err != nil {
 		return nil, err
 	}
@@ -1807,7 +1900,7 @@ func (s *service) ListVolumes(
 	i := 0
 	for _, vol := range source {
 		entries[i] = &csi.ListVolumesResponse_Entry{
-			Volume: s.getCSIVolume(vol, systemID),
+			Volume: s.getCSIVolume(ctx, vol, systemID),
 		}
 		i = i + 1
 	}
@@ -1860,12 +1953,12 @@ func (s *service) ListSnapshots(
 	}
 
 	// Use systemID from csiSourceID if available, otherwise default systemID is used
-	systemID := s.opts.defaultSystemID
+	systemID := s.defaultSystemID()
 	if csiSourceID != "" {
 		systemID = s.getSystemIDFromCsiVolumeID(csiSourceID)
 		if systemID == "" {
 			// use default system
-			systemID = s.opts.defaultSystemID
+			systemID = s.defaultSystemID()
 		}
 
 		if systemID == "" {
@@ -1883,7 +1976,7 @@ func (s *service) ListSnapshots(
 
 	// Call the common listVolumes code to list snapshots only.
 	// If sourceVolumeID or snapshotID are provided, we list those use cases and do not use cache.
-	source, nextToken, err := s.listVolumes(systemID, startToken, maxEntries, false, true, volumeID, ancestorID)
+	source, nextToken, err := s.listVolumes(ctx, systemID, startToken, maxEntries, false, true, volumeID, ancestorID)
 
 	if err != nil && strings.Contains(err.Error(), "must be a hexadecimal number") {
 		return &csi.ListSnapshotsResponse{}, nil
@@ -1921,7 +2014,7 @@ func (s *service) ListSnapshots(
 // array of Volume pointers to be returned
 // next starting token (string)
 // error
-func (s *service) listVolumes(systemID string, startToken int, maxEntries int, doVols, doSnaps bool, volumeID, ancestorID string) (
+func (s *service) listVolumes(ctx context.Context, systemID string, startToken int, maxEntries int, doVols, doSnaps bool, volumeID, ancestorID string) (
 	[]*siotypes.Volume, string, error,
 ) {
 	var (
@@ -1931,11 +2024,14 @@ func (s *service) listVolumes(systemID string, startToken int, maxEntries int, d
 		err      error
 	)
 
-	adminClient := s.adminClients[systemID]
+	adminClient := s.adminClientOf(systemID)
 
 	// Handle exactly one volume or snapshot
 	if volumeID != "" || ancestorID != "" {
-		sioVols, err = adminClient.GetVolume("", volumeID, ancestorID, "", false)
+		err = s.gatewayCall(ctx, systemID, "GetVolume", func() (err error) {
+			sioVols, err = adminClient.GetVolume("", volumeID, ancestorID, "", false)
+			return err
+		})
 		if err != nil {
 			return nil, "", status.Errorf(codes.Internal,
 				"Unable to list volumes for volume ID %s ancestor ID %s: %s", volumeID, ancestorID, err.Error())
@@ -1947,6 +2043,7 @@ func (s *service) listVolumes(systemID string, startToken int, maxEntries int, d
 
 	// If neither ancestorID, nor volumeID provided, process volumes with volume cache
 	if doVols {
+		cacheHit := false
 		// Get the volumes from the cache if we can.
 		if startToken != 0 && len(s.volCache) > 0 {
 			Log.Printf("volume cache hit: %d volumes", len(s.volCache))
@@ -1957,12 +2054,17 @@ func (s *service) listVolumes(systemID string, startToken int, maxEntries int, d
 				// Check if cache has volumes for the required systemID
 				if s.volCacheSystemID == systemID {
 					copy(sioVols, s.volCache)
+					cacheHit = true
 				}
 			}()
 		}
+		recordCacheLookup(volumeCache, cacheHit)
 
 		if len(sioVols) == 0 {
-			sioVols, err = adminClient.GetVolume("", "", "", "", false)
+			err = s.gatewayCall(ctx, systemID, "GetVolume", func() (err error) {
+				sioVols, err = adminClient.GetVolume("", "", "", "", false)
+				return err
+			})
 			if err != nil {
 				return nil, "", status.Errorf(
 					codes.Internal,
@@ -1984,6 +2086,7 @@ func (s *service) listVolumes(systemID string, startToken int, maxEntries int, d
 
 	// Process snapshots.
 	if doSnaps {
+		cacheHit := false
 		if startToken != 0 && len(s.snapCache) > 0 {
 			Log.Printf("snap cache hit: %d snapshots", len(s.snapCache))
 			func() {
@@ -1993,11 +2096,16 @@ func (s *service) listVolumes(systemID string, startToken int, maxEntries int, d
 				// Check if cache has snapshots for the required systemID
 				if s.snapCacheSystemID == systemID {
 					copy(sioSnaps, s.snapCache)
+					cacheHit = true
 				}
 			}()
 		}
+		recordCacheLookup(snapshotCache, cacheHit)
 		if len(sioSnaps) == 0 {
-			sioSnaps, err = adminClient.GetVolume("", "", "", "", true)
+			err = s.gatewayCall(ctx, systemID, "GetVolume", func() (err error) {
+				sioSnaps, err = adminClient.GetVolume("", "", "", "", true)
+				return err
+			})
 			if err != nil {
 				return nil, "", status.Errorf(
 					codes.Internal,
@@ -2058,8 +2166,8 @@ func (s *service) getSystemCapacity(ctx context.Context, systemID, protectionDom
 		return 0, err
 	}
 
-	adminClient := s.adminClients[systemID]
-	system := s.systems[systemID]
+	adminClient := s.adminClientOf(systemID)
+	system := s.systemOf(systemID)
 
 	var statsFunc func() (*siotypes.Statistics, error)
 
@@ -2068,11 +2176,15 @@ func (s *service) getSystemCapacity(ctx context.Context, systemID, protectionDom
 
 	if len(spName) > 0 {
 		// if storage pool is given, get capacity of storage pool
-		pdID, err := s.getProtectionDomainIDFromName(systemID, protectionDomain)
+		pdID, err := s.getProtectionDomainIDFromName(ctx, systemID, protectionDomain)
 		if err != nil {
 			return 0, err
 		}
-		sp, err := adminClient.FindStoragePool("", spName[0], "", pdID)
+		var sp *siotypes.StoragePool
+		err = s.gatewayCall(ctx, systemID, "FindStoragePool", func() (err error) {
+			sp, err = adminClient.FindStoragePool("", spName[0], "", pdID)
+			return err
+		})
 		if err != nil {
 			return 0, status.Errorf(codes.Internal,
 				"unable to look up storage pool: %s on system: %s, err: %s",
@@ -2105,7 +2217,7 @@ func (s *service) getSystemCapacity(ctx context.Context, systemID, protectionDom
 func (s *service) getCapacityForAllSystems(ctx context.Context, protectionDomain string, spName ...string) (int64, error) {
 	var capacity int64
 
-	for _, array := range s.opts.arrays {
+	for _, array := range s.arrayConfigs() {
 		var systemCapacity int64
 		var err error
 
@@ -2172,8 +2284,8 @@ func (s *service) GetCapacity(
 			"Unable to get capacity: %s", err.Error())
 	}
 
-	if systemID == "" && s.opts.defaultSystemID != "" {
-		systemID = s.opts.defaultSystemID
+	if systemID == "" {
+		systemID = s.defaultSystemID()
 	}
 
 	if systemID == "" {
@@ -2182,7 +2294,7 @@ func (s *service) GetCapacity(
 		}, nil
 	}
 
-	maxVolSize, err := s.getMaximumVolumeSize(systemID)
+	maxVolSize, err := s.getMaximumVolumeSize(ctx, systemID)
 	if err != nil {
 		Log.Debug("GetMaxVolumeSize returning error ", err)
 	}
@@ -2201,15 +2313,19 @@ func (s *service) GetCapacity(
 	}, nil
 }
 
-func (s *service) getMaximumVolumeSize(systemID string) (int64, error) {
+func (s *service) getMaximumVolumeSize(ctx context.Context, systemID string) (int64, error) {
 	valueInCache, found := getCachedMaximumVolumeSize(systemID)
 	if !found || valueInCache < 0 {
-		adminClient := s.adminClients[systemID]
+		adminClient := s.adminClientOf(systemID)
 		if adminClient == nil {
 			return 0, status.Errorf(codes.InvalidArgument, "can't find adminClient by id %s", systemID)
 		}
 
-		vol1, err := adminClient.GetMaxVol()
+		var vol1 string
+		err := s.gatewayCall(ctx, systemID, "GetMaxVol", func() (err error) {
+			vol1, err = adminClient.GetMaxVol()
+			return err
+		})
 		if err != nil {
 			Log.Debug("GetMaxVolumeSize returning error ", err)
 			return 0, err
@@ -2338,13 +2454,15 @@ func (s *service) ControllerGetCapabilities(
 // the failed system name
 func (s *service) systemProbeAll(ctx context.Context) error {
 	// probe all arrays
-	Log.Infof("Probing all arrays. Number of arrays: %d", len(s.opts.arrays))
+	arrays := s.arrayConfigs()
+	Log.Infof("Probing all arrays. Number of arrays: %d", len(arrays))
 	allArrayFail := true
 	errMap := make(map[string]error)
 
-	for _, array := range s.opts.arrays {
+	for _, array := range arrays {
 		err := s.systemProbe(ctx, array)
 		systemID := array.SystemID
+		recordProbe(systemID, err)
 		if err == nil {
 			Log.Infof("array %s probed successfully", systemID)
 			allArrayFail = false
@@ -2363,7 +2481,7 @@ func (s *service) systemProbeAll(ctx context.Context) error {
 }
 
 // systemProbe will probe the given array
-func (s *service) systemProbe(_ context.Context, array *ArrayConnectionData) error {
+func (s *service) systemProbe(ctx context.Context, array *ArrayConnectionData) error {
 	// Check that we have the details needed to login to the Gateway
 	if array.Endpoint == "" {
 		return status.Error(codes.FailedPrecondition,
@@ -2389,40 +2507,51 @@ func (s *service) systemProbe(_ context.Context, array *ArrayConnectionData) err
 	systemID := array.SystemID
 
 	// Create ScaleIO API client if needed
-	if s.adminClients[systemID] == nil {
-		skipCertificateValidation := array.SkipCertificateValidation || array.Insecure
-		c, err := goscaleio.NewClientWithArgs(array.Endpoint, "", math.MaxInt64, skipCertificateValidation, !s.opts.DisableCerts)
+	if s.adminClientOf(systemID) == nil {
+		if array.needsGatewayProxy() && s.gatewayProxyOf(systemID) == nil {
+			proxy, err := newGatewayProxy(array, s.opts.GatewayHealthCheckInterval)
+			if err != nil {
+				return status.Errorf(codes.FailedPrecondition,
+					"unable to set up the connection to VxFlexOS Gateway: %s", err.Error())
+			}
+			s.connectionsRWL.Lock()
+			if s.gatewayProxies == nil {
+				s.gatewayProxies = make(map[string]*gatewayProxy)
+			}
+			s.gatewayProxies[systemID] = proxy
+			s.connectionsRWL.Unlock()
+		}
+		c, err := s.newAdminClient(array, s.gatewayProxyOf(systemID))
 		if err != nil {
-			return status.Errorf(codes.FailedPrecondition,
-				"unable to create ScaleIO client: %s", err.Error())
+			return err
 		}
+		s.connectionsRWL.Lock()
 		s.adminClients[systemID] = c
 		for _, name := range altSystemNames {
 			s.adminClients[name] = c
 		}
+		s.connectionsRWL.Unlock()
 	}
 
-	if s.adminClients[systemID].GetToken() == "" {
-		_, err := s.adminClients[systemID].Authenticate(&goscaleio.ConfigConnect{
-			Endpoint: array.Endpoint,
-			Username: array.Username,
-			Password: array.Password,
-		})
-		if err != nil {
-			return status.Errorf(codes.FailedPrecondition,
-				"unable to login to VxFlexOS Gateway: %s", err.Error())
+	if s.adminClientOf(systemID).GetToken() == "" {
+		if _, err := s.loginAdminClient(ctx, s.adminClientOf(systemID), array); err != nil {
+			return err
 		}
 	}
 
 	// initialize system if needed
-	if s.systems[systemID] == nil {
-		system, err := s.adminClients[systemID].FindSystem(
-			array.SystemID, array.SystemID, "")
+	if s.systemOf(systemID) == nil {
+		var system *goscaleio.System
+		err := s.gatewayCall(ctx, systemID, "FindSystem", func() (err error) {
+			system, err = s.adminClientOf(systemID).FindSystem(array.SystemID, array.SystemID, "")
+			return err
+		})
 		if err != nil {
 			return status.Errorf(codes.FailedPrecondition,
 				"unable to find matching VxFlexOS system name: %s",
 				err.Error())
 		}
+		s.connectionsRWL.Lock()
 		s.systems[systemID] = system
 		if system.System != nil && system.System.Name != "" {
 			Log.Printf("Found Name for system=%s with ID=%s", system.System.Name, system.System.ID)
@@ -2436,20 +2565,25 @@ func (s *service) systemProbe(_ context.Context, array *ArrayConnectionData) err
 			s.adminClients[name] = s.adminClients[systemID]
 			s.connectedSystemNameToID[name] = system.System.ID
 		}
+		s.connectionsRWL.Unlock()
 	}
 
 	sysID := systemID
-	if id, ok := s.connectedSystemNameToID[systemID]; ok {
+	if id, ok := s.systemIDByName(systemID); ok {
 		Log.Printf("System with name %s found id: %s", systemID, id)
 		sysID = id
+		s.optsRWL.Lock()
 		s.opts.arrays[sysID] = array
+		s.optsRWL.Unlock()
 	}
 	if array.IsDefault == true {
 		Log.Infof("default array is set to array ID: %s", sysID)
+		s.optsRWL.Lock()
 		s.opts.defaultSystemID = sysID
+		s.optsRWL.Unlock()
 		Log.Printf("%s is the default array, skipping VolumePrefixToSystems map update. \n", sysID)
 	} else {
-		err := s.UpdateVolumePrefixToSystemsMap(sysID)
+		err := s.UpdateVolumePrefixToSystemsMap(ctx, sysID)
 		if err != nil {
 			return err
 		}
@@ -2457,11 +2591,85 @@ func (s *service) systemProbe(_ context.Context, array *ArrayConnectionData) err
 	return nil
 }
 
+// newAdminClient creates a client of the gateway of array, connecting through proxy when it is not nil
+func (s *service) newAdminClient(array *ArrayConnectionData, proxy *gatewayProxy) (*goscaleio.Client, error) {
+	endpoint := array.Endpoint
+	if proxy != nil {
+		endpoint = proxy.url()
+	}
+	skipCertificateValidation := array.SkipCertificateValidation || array.Insecure
+	c, err := goscaleio.NewClientWithArgs(endpoint, "", math.MaxInt64, skipCertificateValidation, !s.opts.DisableCerts)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"unable to create ScaleIO client: %s", err.Error())
+	}
+	// the endpoint is kept for authenticateWithNewClient, authenticate carries it over to each login
+	c.GetConfigConnect().Endpoint = endpoint
+	return c, nil
+}
+
+// loginAdminClient logs in to the gateway of array with its credentials, reading them again from their provider
+// when the gateway rejects them, and returns the client that is logged in. A login with rotated credentials is
+// made on a new client that replaces c. When the session expires, goscaleio logs in again with the credentials
+// of the last login, the first request it rejects makes gatewayCall read the credentials again.
+func (s *service) loginAdminClient(ctx context.Context, c *goscaleio.Client, array *ArrayConnectionData) (*goscaleio.Client, error) {
+	err := s.authenticate(ctx, c, array)
+	if isUnauthorized(err) {
+		if fresh, rerr := s.reauthenticate(ctx, c, array); rerr == nil {
+			c, err = fresh, nil
+		} else {
+			Log.WithError(rerr).Debugf("unable to log in to array %s with rotated credentials", array.SystemID)
+		}
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"unable to login to VxFlexOS Gateway: %s", err.Error())
+	}
+	return c, nil
+}
+
+// authenticate logs in to the gateway of array with its credentials. The client logs in again with them when
+// its session expires.
+func (s *service) authenticate(ctx context.Context, c *goscaleio.Client, array *ArrayConnectionData) error {
+	return s.gatewayCall(ctx, array.SystemID, "Authenticate", func() error {
+		_, err := c.Authenticate(&goscaleio.ConfigConnect{
+			Endpoint: c.GetConfigConnect().Endpoint,
+			Username: array.Username,
+			Password: array.Password,
+		})
+		return err
+	})
+}
+
+// authenticateWithNewClient logs in to the gateway of array with a new client of the endpoint of c and, when the
+// login succeeds, replaces c with it in the admin clients and returns it. goscaleio drops the session of a client
+// whose login fails and keeps the rejected credentials for its next login, so c is not logged in with credentials
+// that may fail. The requests that are made with c meanwhile keep its session.
+func (s *service) authenticateWithNewClient(ctx context.Context, c *goscaleio.Client, array *ArrayConnectionData) (*goscaleio.Client, error) {
+	skipCertificateValidation := array.SkipCertificateValidation || array.Insecure
+	fresh, err := goscaleio.NewClientWithArgs(c.GetConfigConnect().Endpoint, c.GetConfigConnect().Version,
+		math.MaxInt64, skipCertificateValidation, !s.opts.DisableCerts)
+	if err != nil {
+		return nil, err
+	}
+	fresh.GetConfigConnect().Endpoint = c.GetConfigConnect().Endpoint
+	if err := s.authenticate(ctx, fresh, array); err != nil {
+		return nil, err
+	}
+	s.connectionsRWL.Lock()
+	for key, client := range s.adminClients {
+		if client == c {
+			s.adminClients[key] = fresh
+		}
+	}
+	s.connectionsRWL.Unlock()
+	return fresh, nil
+}
+
 func (s *service) requireProbe(ctx context.Context, systemID string) error {
-	if s.adminClients[systemID] == nil {
+	if s.adminClientOf(systemID) == nil {
 		Log.Debugf("probing system %s automatically", systemID)
-		array, ok := s.opts.arrays[systemID]
-		if ok {
+		if array := s.arrayOf(systemID); array != nil {
 			if err := s.systemProbe(ctx, array); err != nil {
 				return status.Errorf(codes.FailedPrecondition,
 					"failed to probe system: %s, error: %s", systemID, err.Error())
@@ -2489,7 +2697,7 @@ func (s *service) CreateSnapshot(
 		return nil, status.Errorf(codes.InvalidArgument, "CSI volume ID to be snapped is required")
 	}
 	// ensure no ambiguity if legacy vol
-	err := s.checkVolumesMap(csiVolID)
+	err := s.checkVolumesMap(ctx, csiVolID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal,
 			"checkVolumesMap for id: %s failed : %s", csiVolID, err.Error())
@@ -2501,7 +2709,7 @@ func (s *service) CreateSnapshot(
 
 	if systemID == "" {
 		// use default system
-		systemID = s.opts.defaultSystemID
+		systemID = s.defaultSystemID()
 	}
 
 	if systemID == "" {
@@ -2530,19 +2738,27 @@ func (s *service) CreateSnapshot(
 
 	if isNFS {
 		fileSystemID := getFilesystemIDFromCsiVolumeID(csiVolID)
-		_, err := s.getFilesystemByID(fileSystemID, systemID)
+		_, err := s.getFilesystemByID(ctx, fileSystemID, systemID)
 		if err != nil {
 			if strings.EqualFold(err.Error(), sioGatewayFileSystemNotFound) {
 				return nil, status.Errorf(codes.NotFound, "NFS volume %s not found", fileSystemID)
 			}
 		}
 
-		system, err := s.adminClients[systemID].FindSystem(systemID, "", "")
+		var system *goscaleio.System
+		err = s.gatewayCall(ctx, systemID, "FindSystem", func() (err error) {
+			system, err = s.adminClientOf(systemID).FindSystem(systemID, "", "")
+			return err
+		})
 		if err != nil {
 			return nil, err
 		}
 
-		existingSnap, err := system.GetFileSystemByIDName("", req.Name)
+		var existingSnap *siotypes.FileSystem
+		err = s.gatewayCall(ctx, systemID, "GetFileSystemByIDName", func() (err error) {
+			existingSnap, err = system.GetFileSystemByIDName("", req.Name)
+			return err
+		})
 
 		if err == nil {
 			if existingSnap.ParentID != fileSystemID {
@@ -2554,15 +2770,23 @@ func (s *service) CreateSnapshot(
 			return &csi.CreateSnapshotResponse{Snapshot: snapResponse}, nil
 		}
 
-		resp, err := system.CreateFileSystemSnapshot(&siotypes.CreateFileSystemSnapshotParam{
-			Name: req.Name,
-		}, fileSystemID)
+		var resp *siotypes.CreateFileSystemSnapshotResponse
+		err = s.gatewayCall(ctx, systemID, "CreateFileSystemSnapshot", func() (err error) {
+			resp, err = system.CreateFileSystemSnapshot(&siotypes.CreateFileSystemSnapshotParam{
+				Name: req.Name,
+			}, fileSystemID)
+			return err
+		})
 		if err != nil {
 			return nil, status.Errorf(codes.Internal,
 				"error creating snapshot with name %s for Volume ID %s", req.Name, fileSystemID)
 		}
 
-		newSnap, err := system.GetFileSystemByIDName(resp.ID, "")
+		var newSnap *siotypes.FileSystem
+		err = s.gatewayCall(ctx, systemID, "GetFileSystemByIDName", func() (err error) {
+			newSnap, err = system.GetFileSystemByIDName(resp.ID, "")
+			return err
+		})
 		if err != nil {
 			if strings.EqualFold(err.Error(), sioGatewayFileSystemNotFound) {
 				return nil, status.Errorf(codes.NotFound, "snapshot with ID %s was not found", resp.ID)
@@ -2593,7 +2817,11 @@ func (s *service) CreateSnapshot(
 	volID := getVolumeIDFromCsiVolumeID(csiVolID)
 
 	// Check for idempotent request, i.e. the snapshot has been already created, by looking up the name.
-	existingVols, err := s.adminClients[systemID].GetVolume("", "", "", req.Name, false)
+	var existingVols []*siotypes.Volume
+	err = s.gatewayCall(ctx, systemID, "GetVolume", func() (err error) {
+		existingVols, err = s.adminClientOf(systemID).GetVolume("", "", "", req.Name, false)
+		return err
+	})
 	noVolErrString1 := "Error: problem finding volume: Volume not found"
 	noVolErrString2 := "Error: problem finding volume: Could not find the volume"
 	if (err != nil) && !(strings.Contains(err.Error(), noVolErrString1) || strings.Contains(err.Error(), noVolErrString2)) {
@@ -2615,7 +2843,7 @@ func (s *service) CreateSnapshot(
 	}
 
 	// Validate volume
-	vol, err := s.getVolByID(volID, systemID)
+	vol, err := s.getVolByID(ctx, volID, systemID)
 	if err != nil {
 		if strings.EqualFold(err.Error(), sioGatewayVolumeNotFound) {
 			return nil, status.Errorf(codes.NotFound, "volume %s was not found", volID)
@@ -2655,7 +2883,7 @@ func (s *service) CreateSnapshot(
 				// Don't list the original volume again
 				continue
 			}
-			volx, err := s.getVolByID(vID, systemID)
+			volx, err := s.getVolByID(ctx, vID, systemID)
 			if err != nil {
 				return nil, status.Errorf(codes.NotFound, "volume %s was not found", vID)
 			}
@@ -2667,13 +2895,17 @@ func (s *service) CreateSnapshot(
 	snapParam := &siotypes.SnapshotVolumesParam{SnapshotDefs: snapshotDefs, AccessMode: "ReadOnly"}
 
 	// Create snapshot(s)
-	snapResponse, err := s.systems[systemID].CreateSnapshotConsistencyGroup(snapParam)
+	var snapResponse *siotypes.SnapshotVolumesResp
+	err = s.gatewayCall(ctx, systemID, "CreateSnapshotConsistencyGroup", func() (err error) {
+		snapResponse, err = s.systemOf(systemID).CreateSnapshotConsistencyGroup(snapParam)
+		return err
+	})
 	if err != nil {
 		return nil, status.Errorf(codes.AlreadyExists, "Failed to create snapshot: %s", err.Error())
 	}
 
 	// populate response structure
-	vol, err = s.getVolByID(volID, systemID)
+	vol, err = s.getVolByID(ctx, volID, systemID)
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "volume %s was not found, error: %s", volID, err.Error())
 	}
@@ -2716,10 +2948,10 @@ func (s *service) DeleteSnapshot(
 	req *csi.DeleteSnapshotRequest) (
 	*csi.DeleteSnapshotResponse, error,
 ) {
-	// Display any secrets passed in
+	// Display the keys of any secrets passed in
 	secrets := req.GetSecrets()
-	for k, v := range secrets {
-		Log.Printf("secret: %s = %s", k, v)
+	for k := range secrets {
+		Log.Printf("secret: %s = %s", k, redactedValue)
 	}
 
 	// Validate snapshot volume
@@ -2733,7 +2965,7 @@ func (s *service) DeleteSnapshot(
 	systemID := s.getSystemIDFromCsiVolumeID(csiSnapID)
 	if systemID == "" {
 		// use default system
-		systemID = s.opts.defaultSystemID
+		systemID = s.defaultSystemID()
 	}
 
 	if systemID == "" {
@@ -2748,13 +2980,19 @@ func (s *service) DeleteSnapshot(
 
 	if isNFS {
 		snapID := getFilesystemIDFromCsiVolumeID(csiSnapID)
-		system, err := s.adminClients[systemID].FindSystem(systemID, "", "")
+		var system *goscaleio.System
+		err := s.gatewayCall(ctx, systemID, "FindSystem", func() (err error) {
+			system, err = s.adminClientOf(systemID).FindSystem(systemID, "", "")
+			return err
+		})
 		if err != nil {
 			return nil, fmt.Errorf("can't find system by id %s, error: %s", systemID, err.Error())
 		}
-		snap, err := s.getFilesystemByID(snapID, systemID)
+		snap, err := s.getFilesystemByID(ctx, snapID, systemID)
 		if err == nil {
-			err = system.DeleteFileSystem(snap.Name)
+			err = s.gatewayCall(ctx, systemID, "DeleteFileSystem", func() error {
+				return system.DeleteFileSystem(snap.Name)
+			})
 
 			if err == nil {
 				return &csi.DeleteSnapshotResponse{}, nil
@@ -2777,7 +3015,7 @@ func (s *service) DeleteSnapshot(
 	}
 
 	snapID := getVolumeIDFromCsiVolumeID(csiSnapID)
-	vol, err := s.getVolByID(snapID, systemID)
+	vol, err := s.getVolByID(ctx, snapID, systemID)
 	if err != nil {
 		if strings.Contains(err.Error(), "Could not find the volume") || strings.Contains(err.Error(), "must be a hexadecimal number") {
 			Log.Printf("Snapshot %s already deleted on system %s \n", snapID, systemID)
@@ -2798,18 +3036,20 @@ func (s *service) DeleteSnapshot(
 		return nil, status.Errorf(codes.FailedPrecondition, "snapshot is in use by the following SDC IP addresses: %s", ips)
 	}
 
-	adminClient := s.adminClients[systemID]
+	adminClient := s.adminClientOf(systemID)
 
 	// Check for consistency group delete, and it must be globally enabled as startup option,
 	// otherwise only single snap is deleted
-	if vol.ConsistencyGroupID != "" && s.opts.EnableSnapshotCGDelete {
-		return s.DeleteSnapshotConsistencyGroup(ctx, vol, req, adminClient)
+	if vol.ConsistencyGroupID != "" && s.reloadable().EnableSnapshotCGDelete {
+		return s.DeleteSnapshotConsistencyGroup(ctx, vol, req, systemID, adminClient)
 	}
 
 	// Delete snapshot
 	tgtVol := goscaleio.NewVolume(adminClient)
 	tgtVol.Volume = vol
-	err = tgtVol.RemoveVolume(removeModeOnlyMe)
+	err = s.gatewayCall(ctx, systemID, "RemoveVolume", func() error {
+		return tgtVol.RemoveVolume(removeModeOnlyMe)
+	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "error removing snapshot: %s", err.Error())
 	}
@@ -2821,8 +3061,8 @@ func (s *service) DeleteSnapshot(
 // DeleteSnapshotConsistencyGroup is called when we wish to delete an entire CG
 // of snapshots. We retrieve all the volumes and determine if any are in use.
 func (s *service) DeleteSnapshotConsistencyGroup(
-	_ context.Context, snapVol *siotypes.Volume,
-	_ *csi.DeleteSnapshotRequest, adminClient *goscaleio.Client) (
+	ctx context.Context, snapVol *siotypes.Volume,
+	_ *csi.DeleteSnapshotRequest, systemID string, adminClient *goscaleio.Client) (
 	*csi.DeleteSnapshotResponse, error,
 ) {
 	cgVols := make([]*siotypes.Volume, 0)
@@ -2833,7 +3073,11 @@ func (s *service) DeleteSnapshotConsistencyGroup(
 	// make call to cluster to get all volumes
 	// Collect a list of the volumes in the same consistency group (cgVols)
 	// Collect the names of volumes that are exposed.
-	sioVols, err := adminClient.GetVolume("", "", "", "", true)
+	var sioVols []*siotypes.Volume
+	err := s.gatewayCall(ctx, systemID, "GetVolume", func() (err error) {
+		sioVols, err = adminClient.GetVolume("", "", "", "", true)
+		return err
+	})
 	for _, vol := range sioVols {
 		if vol.ConsistencyGroupID == cgID {
 			Log.Printf("Name %s CG %s ID %s", vol.Name, vol.ConsistencyGroupID, vol.ID)
@@ -2862,7 +3106,9 @@ func (s *service) DeleteSnapshotConsistencyGroup(
 		// Delete snapshot
 		tgtVol := goscaleio.NewVolume(adminClient)
 		tgtVol.Volume = vol
-		err = tgtVol.RemoveVolume(removeModeOnlyMe)
+		err = s.gatewayCall(ctx, systemID, "RemoveVolume", func() error {
+			return tgtVol.RemoveVolume(removeModeOnlyMe)
+		})
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "error removing snapshot: %s", err.Error())
 		}
@@ -2873,7 +3119,7 @@ func (s *service) DeleteSnapshotConsistencyGroup(
 }
 
 func (s *service) ControllerExpandVolume(ctx context.Context, req *csi.ControllerExpandVolumeRequest) (*csi.ControllerExpandVolumeResponse, error) {
-	Log.Printf("[ControllerExpandVolume] req: %+v", req)
+	Log.Printf("[ControllerExpandVolume] req: %+v", redactRequest(req))
 
 	var reqID string
 	var err error
@@ -2890,7 +3136,7 @@ func (s *service) ControllerExpandVolume(ctx context.Context, req *csi.Controlle
 			"volume ID is required")
 	}
 	// ensure no ambiguity if legacy vol
-	err = s.checkVolumesMap(csiVolID)
+	err = s.checkVolumesMap(ctx, csiVolID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal,
 			"checkVolumesMap for id: %s failed : %s", csiVolID, err.Error())
@@ -2903,7 +3149,7 @@ func (s *service) ControllerExpandVolume(ctx context.Context, req *csi.Controlle
 		systemID := s.getSystemIDFromCsiVolumeID(csiVolID)
 		if systemID == "" {
 			// use default system
-			systemID = s.opts.defaultSystemID
+			systemID = s.defaultSystemID()
 		}
 
 		if systemID == "" {
@@ -2914,7 +3160,7 @@ func (s *service) ControllerExpandVolume(ctx context.Context, req *csi.Controlle
 		if err := s.requireProbe(ctx, systemID); err != nil {
 			return nil, err
 		}
-		fs, err := s.getFilesystemByID(fsID, systemID)
+		fs, err := s.getFilesystemByID(ctx, fsID, systemID)
 		if err != nil {
 			if strings.EqualFold(err.Error(), sioGatewayFileSystemNotFound) || strings.Contains(err.Error(), "must be a hexadecimal number") {
 				return nil, status.Error(codes.NotFound,
@@ -2954,21 +3200,31 @@ func (s *service) ControllerExpandVolume(ctx context.Context, req *csi.Controlle
 			}, nil
 		}
 
-		system, err := s.adminClients[systemID].FindSystem(systemID, "", "")
+		var system *goscaleio.System
+		err = s.gatewayCall(ctx, systemID, "FindSystem", func() (err error) {
+			system, err = s.adminClientOf(systemID).FindSystem(systemID, "", "")
+			return err
+		})
 		if err != nil {
 			return nil, err
 		}
 
-		if err := system.ModifyFileSystem(&siotypes.FSModify{Size: requestedSize}, fsID); err != nil {
+		if err := s.gatewayCall(ctx, systemID, "ModifyFileSystem", func() error {
+			return system.ModifyFileSystem(&siotypes.FSModify{Size: requestedSize}, fsID)
+		}); err != nil {
 			Log.Errorf("NFS volume expansion failed with error: %s", err.Error())
 			return nil, status.Error(codes.Internal, err.Error())
 		}
 
 		// update tree quota hard limit and soft limit if pvc size has changed
 
-		isQuotaEnabled := s.opts.IsQuotaEnabled
+		isQuotaEnabled := s.reloadable().IsQuotaEnabled
 		if isQuotaEnabled && fs.IsQuotaEnabled {
-			treeQuota, err := system.GetTreeQuotaByFSID(fsID)
+			var treeQuota *siotypes.TreeQuota
+			err := s.gatewayCall(ctx, systemID, "GetTreeQuotaByFSID", func() (err error) {
+				treeQuota, err = system.GetTreeQuotaByFSID(fsID)
+				return err
+			})
 			if err != nil {
 				Log.Errorf("Fetching tree quota for NFS volume failed, error: %s", err.Error())
 				return nil, status.Error(codes.Internal, err.Error())
@@ -2983,7 +3239,9 @@ func (s *service) ControllerExpandVolume(ctx context.Context, req *csi.Controlle
 				SoftLimit: updatedSoftLimit,
 			}
 
-			err = system.ModifyTreeQuota(quotaModify, treeQuotaID)
+			err = s.gatewayCall(ctx, systemID, "ModifyTreeQuota", func() error {
+				return system.ModifyTreeQuota(quotaModify, treeQuotaID)
+			})
 			if err != nil {
 				Log.Errorf("Modifying tree quota for NFS volume failed, error: %s", err.Error())
 				return nil, status.Error(codes.Internal, err.Error())
@@ -3002,7 +3260,7 @@ func (s *service) ControllerExpandVolume(ctx context.Context, req *csi.Controlle
 	systemID := s.getSystemIDFromCsiVolumeID(csiVolID)
 	if systemID == "" {
 		// use default system
-		systemID = s.opts.defaultSystemID
+		systemID = s.defaultSystemID()
 	}
 
 	if systemID == "" {
@@ -3014,7 +3272,7 @@ func (s *service) ControllerExpandVolume(ctx context.Context, req *csi.Controlle
 		return nil, err
 	}
 
-	vol, err := s.getVolByID(volID, systemID)
+	vol, err := s.getVolByID(ctx, volID, systemID)
 	if err != nil {
 		if strings.EqualFold(err.Error(), sioGatewayVolumeNotFound) || strings.Contains(err.Error(), "must be a hexadecimal number") {
 			return nil, status.Error(codes.NotFound, "volume not found")
@@ -3053,9 +3311,11 @@ func (s *service) ControllerExpandVolume(ctx context.Context, req *csi.Controlle
 	}
 
 	reqSize := requestedSize / kiBytesInGiB
-	tgtVol := goscaleio.NewVolume(s.adminClients[systemID])
+	tgtVol := goscaleio.NewVolume(s.adminClientOf(systemID))
 	tgtVol.Volume = vol
-	err = tgtVol.SetVolumeSize(strconv.Itoa(int(reqSize)))
+	err = s.gatewayCall(ctx, systemID, "SetVolumeSize", func() error {
+		return tgtVol.SetVolumeSize(strconv.Itoa(int(reqSize)))
+	})
 	if err != nil {
 		Log.Errorf("Failed to execute ExpandVolume() with error (%s)", err.Error())
 		return nil, status.Error(codes.Internal, err.Error())
@@ -3096,14 +3356,14 @@ func mergeStringMaps(base map[string]string, additional map[string]string) map[s
 	return result
 }
 
-func (s *service) Clone(req *csi.CreateVolumeRequest,
+func (s *service) Clone(ctx context.Context, req *csi.CreateVolumeRequest,
 	volumeSource *csi.VolumeContentSource_VolumeSource, name string, sizeInKbytes int64, storagePool string,
 ) (*csi.CreateVolumeResponse, error) {
 	// get systemID from volume source CSI id
 	systemID := s.getSystemIDFromCsiVolumeID(volumeSource.VolumeId)
 	if systemID == "" {
 		// use default system
-		systemID = s.opts.defaultSystemID
+		systemID = s.defaultSystemID()
 	}
 	if systemID == "" {
 		return nil, status.Error(codes.InvalidArgument,
@@ -3112,7 +3372,7 @@ func (s *service) Clone(req *csi.CreateVolumeRequest,
 
 	// Look up the source volume
 	sourceVolID := getVolumeIDFromCsiVolumeID(volumeSource.VolumeId)
-	srcVol, err := s.getVolByID(sourceVolID, systemID)
+	srcVol, err := s.getVolByID(ctx, sourceVolID, systemID)
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "Volume not found: %s, error: %s", volumeSource.VolumeId, err.Error())
 	}
@@ -3124,16 +3384,20 @@ func (s *service) Clone(req *csi.CreateVolumeRequest,
 			volumeSource.VolumeId, srcVol.SizeInKb, sizeInKbytes)
 	}
 
-	adminClient := s.adminClients[systemID]
+	adminClient := s.adminClientOf(systemID)
 	// Validate the storage pool is the same
-	volStoragePool := s.getStoragePoolNameFromID(systemID, srcVol.StoragePoolID)
+	volStoragePool := s.getStoragePoolNameFromID(ctx, systemID, srcVol.StoragePoolID)
 	if volStoragePool != storagePool {
 		return nil, status.Errorf(codes.InvalidArgument,
 			"Volume storage pool %s is different from the requested storage pool %s", volStoragePool, storagePool)
 	}
 
 	// Check for idempotent request
-	existingVols, err := adminClient.GetVolume("", "", "", name, false)
+	var existingVols []*siotypes.Volume
+	err = s.gatewayCall(ctx, systemID, "GetVolume", func() (err error) {
+		existingVols, err = adminClient.GetVolume("", "", "", name, false)
+		return err
+	})
 	noVolErrString1 := "Error: problem finding volume: Volume not found"
 	noVolErrString2 := "Error: problem finding volume: Could not find the volume"
 	if (err != nil) && !(strings.Contains(err.Error(), noVolErrString1) || strings.Contains(err.Error(), noVolErrString2)) {
@@ -3144,7 +3408,7 @@ func (s *service) Clone(req *csi.CreateVolumeRequest,
 	for _, vol := range existingVols {
 		if vol.Name == name && vol.StoragePoolID == srcVol.StoragePoolID {
 			Log.Printf("Requested volume %s already exists", name)
-			csiVolume := s.getCSIVolume(vol, systemID)
+			csiVolume := s.getCSIVolume(ctx, vol, systemID)
 			csiVolume.ContentSource = req.GetVolumeContentSource()
 			copyInterestingParameters(req.GetParameters(), csiVolume.VolumeContext)
 			Log.Printf("Requested volume (from clone) already exists %s (%s) storage pool %s",
@@ -3161,8 +3425,12 @@ func (s *service) Clone(req *csi.CreateVolumeRequest,
 	snapParam := &siotypes.SnapshotVolumesParam{SnapshotDefs: snapshotDefs, AccessMode: "ReadWrite"}
 
 	// Create snapshot
-	system := s.systems[systemID]
-	snapResponse, err := system.CreateSnapshotConsistencyGroup(snapParam)
+	system := s.systemOf(systemID)
+	var snapResponse *siotypes.SnapshotVolumesResp
+	err = s.gatewayCall(ctx, systemID, "CreateSnapshotConsistencyGroup", func() (err error) {
+		snapResponse, err = system.CreateSnapshotConsistencyGroup(snapParam)
+		return err
+	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Failed to call CreateSnapshotConsistencyGroup to clone volume: %s", err.Error())
 	}
@@ -3173,14 +3441,14 @@ func (s *service) Clone(req *csi.CreateVolumeRequest,
 
 	// Retrieve created destination volume
 	destID := snapResponse.VolumeIDList[0]
-	destVol, err := s.getVolByID(destID, systemID)
+	destVol, err := s.getVolByID(ctx, destID, systemID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not retrieve created volume: %s, error: %s", destID, err.Error())
 	}
 
 	// Create a volume response and return it
 	s.clearCache()
-	csiVolume := s.getCSIVolume(destVol, systemID)
+	csiVolume := s.getCSIVolume(ctx, destVol, systemID)
 	csiVolume.ContentSource = req.GetVolumeContentSource()
 	copyInterestingParameters(req.GetParameters(), csiVolume.VolumeContext)
 
@@ -3192,7 +3460,7 @@ func (s *service) Clone(req *csi.CreateVolumeRequest,
 
 // ControllerGetVolume fetch current information about a volume
 // returns volume condition if found else returns not found
-func (s *service) ControllerGetVolume(_ context.Context, req *csi.ControllerGetVolumeRequest) (*csi.ControllerGetVolumeResponse, error) {
+func (s *service) ControllerGetVolume(ctx context.Context, req *csi.ControllerGetVolumeRequest) (*csi.ControllerGetVolumeResponse, error) {
 	abnormal := false
 	csiVolID := req.GetVolumeId()
 	if csiVolID == "" {
@@ -3203,14 +3471,14 @@ func (s *service) ControllerGetVolume(_ context.Context, req *csi.ControllerGetV
 	systemID := s.getSystemIDFromCsiVolumeID(csiVolID)
 	if systemID == "" {
 		// use default system
-		systemID = s.opts.defaultSystemID
+		systemID = s.defaultSystemID()
 	}
 	if systemID == "" {
 		return nil, status.Error(codes.InvalidArgument,
 			"systemID is not found in the request and there is no default system")
 	}
 
-	vol, err := s.getVolByID(volID, systemID)
+	vol, err := s.getVolByID(ctx, volID, systemID)
 	if err != nil {
 		if strings.EqualFold(err.Error(), sioGatewayVolumeNotFound) {
 			message := fmt.Sprintf("Volume is not found by controller at %s", time.Now().Format("2006-01-02 15:04:05"))
@@ -3230,7 +3498,7 @@ func (s *service) ControllerGetVolume(_ context.Context, req *csi.ControllerGetV
 	}
 
 	csiResp := &csi.ControllerGetVolumeResponse{
-		Volume: s.getCSIVolume(vol, systemID),
+		Volume: s.getCSIVolume(ctx, vol, systemID),
 		Status: &csi.ControllerGetVolumeResponse_VolumeStatus{
 			VolumeCondition: &csi.VolumeCondition{
 				Abnormal: abnormal,
@@ -3242,17 +3510,19 @@ func (s *service) ControllerGetVolume(_ context.Context, req *csi.ControllerGetV
 	return csiResp, nil
 }
 
-func (s *service) CreateReplicationConsistencyGroup(systemID string, name string,
+// CreateReplicationConsistencyGroup creates a replication consistency group, or finds the group of the same name and
+// protection domains when it already exists. It returns whether the group was created by this call.
+func (s *service) CreateReplicationConsistencyGroup(ctx context.Context, systemID string, name string,
 	rpo string, locatProtectionDomain string, remoteProtectionDomain string,
 	peerMdmID string, remoteSystemID string,
-) (*siotypes.ReplicationConsistencyGroupResp, error) {
-	adminClient := s.adminClients[systemID]
+) (*siotypes.ReplicationConsistencyGroupResp, bool, error) {
+	adminClient := s.adminClientOf(systemID)
 	if adminClient == nil {
-		return nil, fmt.Errorf("can't find adminClient by id %s", systemID)
+		return nil, false, fmt.Errorf("can't find adminClient by id %s", systemID)
 	}
 
 	if peerMdmID != "" && remoteSystemID != "" {
-		return nil, fmt.Errorf("peerMdmID and remoteSystemID cannot both be present")
+		return nil, false, fmt.Errorf("peerMdmID and remoteSystemID cannot both be present")
 	}
 
 	rcgPayload := &siotypes.ReplicationConsistencyGroupCreatePayload{
@@ -3264,20 +3534,28 @@ func (s *service) CreateReplicationConsistencyGroup(systemID string, name string
 		DestinationSystemID:      remoteSystemID,
 	}
 
-	rcgResp, err := adminClient.CreateReplicationConsistencyGroup(rcgPayload)
+	var rcgResp *siotypes.ReplicationConsistencyGroupResp
+	err := s.gatewayCall(ctx, systemID, "CreateReplicationConsistencyGroup", func() (err error) {
+		rcgResp, err = adminClient.CreateReplicationConsistencyGroup(rcgPayload)
+		return err
+	})
 	if err != nil {
 		// Handle the case where it already exists.
 		if !strings.EqualFold(err.Error(), sioReplicationGroupExists) {
 			Log.Printf("Replication Creation Error: %s", err.Error())
-			return nil, err
+			return nil, false, err
 		}
 	}
 
 	var id string
 	if rcgResp == nil {
-		rcgs, err := adminClient.GetReplicationConsistencyGroups()
+		var rcgs []*siotypes.ReplicationConsistencyGroup
+		err := s.gatewayCall(ctx, systemID, "GetReplicationConsistencyGroups", func() (err error) {
+			rcgs, err = adminClient.GetReplicationConsistencyGroups()
+			return err
+		})
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		// RCG already exists, find it on the array.
@@ -3290,7 +3568,7 @@ func (s *service) CreateReplicationConsistencyGroup(systemID string, name string
 		}
 
 		if id == "" {
-			return nil, status.Errorf(codes.Internal, "couldn't find replication consistency group")
+			return nil, false, status.Errorf(codes.Internal, "couldn't find replication consistency group")
 		}
 	} else {
 		id = rcgResp.ID
@@ -3298,13 +3576,13 @@ func (s *service) CreateReplicationConsistencyGroup(systemID string, name string
 
 	return &siotypes.ReplicationConsistencyGroupResp{
 		ID: id,
-	}, nil
+	}, rcgResp != nil, nil
 }
 
-func (s *service) CreateReplicationPair(systemID string, name string,
+func (s *service) CreateReplicationPair(ctx context.Context, systemID string, name string,
 	localVolumeID string, remoteVolumeID string, replicationGroupID string,
 ) (*siotypes.ReplicationPair, error) {
-	adminClient := s.adminClients[systemID]
+	adminClient := s.adminClientOf(systemID)
 	if adminClient == nil {
 		return nil, fmt.Errorf("can't find adminClient by id %s", systemID)
 	}
@@ -3317,7 +3595,11 @@ func (s *service) CreateReplicationPair(systemID string, name string,
 		CopyType:                      "OnlineCopy",
 	}
 
-	response, err := adminClient.CreateReplicationPair(payload)
+	var response *siotypes.ReplicationPair
+	err := s.gatewayCall(ctx, systemID, "CreateReplicationPair", func() (err error) {
+		response, err = adminClient.CreateReplicationPair(payload)
+		return err
+	})
 	if err != nil {
 		// Handle the case where it already exists.
 		if !strings.EqualFold(err.Error(), sioReplicationPairExists) {
@@ -3327,7 +3609,11 @@ func (s *service) CreateReplicationPair(systemID string, name string,
 	}
 
 	if response == nil {
-		pairs, err := adminClient.GetAllReplicationPairs()
+		var pairs []*siotypes.ReplicationPair
+		err := s.gatewayCall(ctx, systemID, "GetAllReplicationPairs", func() (err error) {
+			pairs, err = adminClient.GetAllReplicationPairs()
+			return err
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -3348,8 +3634,8 @@ func (s *service) CreateReplicationPair(systemID string, name string,
 	return response, nil
 }
 
-func (s *service) DeleteReplicationConsistencyGroup(systemID string, groupID string) error {
-	adminClient := s.adminClients[systemID]
+func (s *service) DeleteReplicationConsistencyGroup(ctx context.Context, systemID string, groupID string) error {
+	adminClient := s.adminClientOf(systemID)
 	if adminClient == nil {
 		return status.Errorf(codes.InvalidArgument, "can't find adminClient by id %s", systemID)
 	}
@@ -3358,7 +3644,11 @@ func (s *service) DeleteReplicationConsistencyGroup(systemID string, groupID str
 		return status.Errorf(codes.InvalidArgument, "group id wasn't provided")
 	}
 
-	group, err := adminClient.GetReplicationConsistencyGroupByID(groupID)
+	var group *siotypes.ReplicationConsistencyGroup
+	err := s.gatewayCall(ctx, systemID, "GetReplicationConsistencyGroupByID", func() (err error) {
+		group, err = adminClient.GetReplicationConsistencyGroupByID(groupID)
+		return err
+	})
 	if err != nil {
 		Log.Printf("Replication Deletion Error: %s", err.Error())
 		return err
@@ -3367,16 +3657,22 @@ func (s *service) DeleteReplicationConsistencyGroup(systemID string, groupID str
 	rcg := goscaleio.NewReplicationConsistencyGroup(adminClient)
 	rcg.ReplicationConsistencyGroup = group
 
-	err = rcg.RemoveReplicationConsistencyGroup(false)
+	err = s.gatewayCall(ctx, systemID, "RemoveReplicationConsistencyGroup", func() error {
+		return rcg.RemoveReplicationConsistencyGroup(false)
+	})
 
 	return err
 }
 
-func (s *service) CreateReplicationConsistencyGroupSnapshot(client *goscaleio.Client, group *siotypes.ReplicationConsistencyGroup) (*siotypes.CreateReplicationConsistencyGroupSnapshotResp, error) {
+func (s *service) CreateReplicationConsistencyGroupSnapshot(ctx context.Context, systemID string, client *goscaleio.Client, group *siotypes.ReplicationConsistencyGroup) (*siotypes.CreateReplicationConsistencyGroupSnapshotResp, error) {
 	rcg := goscaleio.NewReplicationConsistencyGroup(client)
 	rcg.ReplicationConsistencyGroup = group
 
-	response, err := rcg.CreateReplicationConsistencyGroupSnapshot(false)
+	var response *siotypes.CreateReplicationConsistencyGroupSnapshotResp
+	err := s.gatewayCall(ctx, systemID, "CreateReplicationConsistencyGroupSnapshot", func() (err error) {
+		response, err = rcg.CreateReplicationConsistencyGroupSnapshot(false)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -3384,34 +3680,40 @@ func (s *service) CreateReplicationConsistencyGroupSnapshot(client *goscaleio.Cl
 	return response, nil
 }
 
-func (s *service) ExecuteFailoverOnReplicationGroup(client *goscaleio.Client, group *siotypes.ReplicationConsistencyGroup) error {
+func (s *service) ExecuteFailoverOnReplicationGroup(ctx context.Context, systemID string, client *goscaleio.Client, group *siotypes.ReplicationConsistencyGroup) error {
 	rcg := goscaleio.NewReplicationConsistencyGroup(client)
 	rcg.ReplicationConsistencyGroup = group
 
 	Log.Printf("[ExecuteFailoverOnReplicationGroup]: Executing Failover command")
 
-	return rcg.ExecuteFailoverOnReplicationGroup()
+	return s.gatewayCall(ctx, systemID, "ExecuteFailoverOnReplicationGroup", func() error {
+		return rcg.ExecuteFailoverOnReplicationGroup()
+	})
 }
 
-func (s *service) ExecuteSwitchoverOnReplicationGroup(client *goscaleio.Client, group *siotypes.ReplicationConsistencyGroup) error {
+func (s *service) ExecuteSwitchoverOnReplicationGroup(ctx context.Context, systemID string, client *goscaleio.Client, group *siotypes.ReplicationConsistencyGroup) error {
 	rcg := goscaleio.NewReplicationConsistencyGroup(client)
 	rcg.ReplicationConsistencyGroup = group
 
 	Log.Printf("[ExecuteSwitchoverOnReplicationGroup]: Executing Switchover (Unplanned Failover)")
 
-	return rcg.ExecuteSwitchoverOnReplicationGroup(false)
+	return s.gatewayCall(ctx, systemID, "ExecuteSwitchoverOnReplicationGroup", func() error {
+		return rcg.ExecuteSwitchoverOnReplicationGroup(false)
+	})
 }
 
-func (s *service) ExecuteReverseOnReplicationGroup(client *goscaleio.Client, group *siotypes.ReplicationConsistencyGroup) error {
+func (s *service) ExecuteReverseOnReplicationGroup(ctx context.Context, systemID string, client *goscaleio.Client, group *siotypes.ReplicationConsistencyGroup) error {
 	rcg := goscaleio.NewReplicationConsistencyGroup(client)
 	rcg.ReplicationConsistencyGroup = group
 
 	Log.Printf("[ExecuteReverseOnReplicationGroup]: Executing Reverse (Reprotect Local)")
 
-	return rcg.ExecuteReverseOnReplicationGroup()
+	return s.gatewayCall(ctx, systemID, "ExecuteReverseOnReplicationGroup", func() error {
+		return rcg.ExecuteReverseOnReplicationGroup()
+	})
 }
 
-func (s *service) ExecuteResumeOnReplicationGroup(client *goscaleio.Client, group *siotypes.ReplicationConsistencyGroup, failover bool) error {
+func (s *service) ExecuteResumeOnReplicationGroup(ctx context.Context, systemID string, client *goscaleio.Client, group *siotypes.ReplicationConsistencyGroup, failover bool) error {
 	rcg := goscaleio.NewReplicationConsistencyGroup(client)
 	rcg.ReplicationConsistencyGroup = group
 
@@ -3419,32 +3721,67 @@ func (s *service) ExecuteResumeOnReplicationGroup(client *goscaleio.Client, grou
 
 	if failover {
 		Log.Printf("[ExecuteReverseOnReplicationGroup]: In Failover, Restoring...")
-		return rcg.ExecuteRestoreOnReplicationGroup()
+		return s.gatewayCall(ctx, systemID, "ExecuteRestoreOnReplicationGroup", func() error {
+			return rcg.ExecuteRestoreOnReplicationGroup()
+		})
 	}
 
-	return rcg.ExecuteResumeOnReplicationGroup()
+	return s.gatewayCall(ctx, systemID, "ExecuteResumeOnReplicationGroup", func() error {
+		return rcg.ExecuteResumeOnReplicationGroup()
+	})
 }
 
-func (s *service) ExecutePauseOnReplicationGroup(client *goscaleio.Client, group *siotypes.ReplicationConsistencyGroup) error {
+func (s *service) ExecutePauseOnReplicationGroup(ctx context.Context, systemID string, client *goscaleio.Client, group *siotypes.ReplicationConsistencyGroup) error {
 	rcg := goscaleio.NewReplicationConsistencyGroup(client)
 	rcg.ReplicationConsistencyGroup = group
 
 	Log.Printf("[ExecutePauseOnReplicationGroup]: Pause Replication Group")
 
-	return rcg.ExecutePauseOnReplicationGroup()
+	return s.gatewayCall(ctx, systemID, "ExecutePauseOnReplicationGroup", func() error {
+		return rcg.ExecutePauseOnReplicationGroup()
+	})
 }
 
-func (s *service) ExecuteSyncOnReplicationGroup(client *goscaleio.Client, group *siotypes.ReplicationConsistencyGroup) (*siotypes.SynchronizationResponse, error) {
+func (s *service) ExecuteSyncOnReplicationGroup(ctx context.Context, systemID string, client *goscaleio.Client, group *siotypes.ReplicationConsistencyGroup) (*siotypes.SynchronizationResponse, error) {
 	rcg := goscaleio.NewReplicationConsistencyGroup(client)
 	rcg.ReplicationConsistencyGroup = group
 
 	Log.Printf("[ExecuteSyncOnReplicationGroup]: Executing SyncNow")
 
-	return rcg.ExecuteSyncOnReplicationGroup()
+	var response *siotypes.SynchronizationResponse
+	err := s.gatewayCall(ctx, systemID, "ExecuteSyncOnReplicationGroup", func() (err error) {
+		response, err = rcg.ExecuteSyncOnReplicationGroup()
+		return err
+	})
+	return response, err
+}
+
+// ExecuteTestFailoverOnReplicationGroup starts a test failover of the replication consistency group, the goscaleio
+// SDK has no function for it
+func (s *service) ExecuteTestFailoverOnReplicationGroup(ctx context.Context, systemID string, group *siotypes.ReplicationConsistencyGroup) error {
+	Log.Printf("[ExecuteTestFailoverOnReplicationGroup]: Executing Test Failover")
+
+	return s.postReplicationConsistencyGroupAction(ctx, systemID, group.ID, "testFailoverReplicationConsistencyGroup", siotypes.EmptyPayload{})
+}
+
+// ExecuteTestFailoverStopOnReplicationGroup stops the test failover of the replication consistency group
+func (s *service) ExecuteTestFailoverStopOnReplicationGroup(ctx context.Context, systemID string, group *siotypes.ReplicationConsistencyGroup) error {
+	Log.Printf("[ExecuteTestFailoverStopOnReplicationGroup]: Stopping Test Failover")
+
+	return s.postReplicationConsistencyGroupAction(ctx, systemID, group.ID, "testFailoverStopReplicationConsistencyGroup", siotypes.EmptyPayload{})
+}
+
+// ModifyRPOOfReplicationGroup changes the RPO of the replication consistency group, the goscaleio SDK has no
+// function for it. The gateway takes the RPO as a string, as when the group is created.
+func (s *service) ModifyRPOOfReplicationGroup(ctx context.Context, systemID string, group *siotypes.ReplicationConsistencyGroup, rpo string) error {
+	Log.Printf("[ModifyRPOOfReplicationGroup]: Changing RPO of %s from %d to %s", group.Name, group.RpoInSeconds, rpo)
+
+	return s.postReplicationConsistencyGroupAction(ctx, systemID, group.ID, "modifyReplicationConsistencyGroupRpo",
+		map[string]string{"rpoInSeconds": rpo})
 }
 
 func (s *service) verifySystem(systemID string) (*goscaleio.Client, error) {
-	adminClient := s.adminClients[systemID]
+	adminClient := s.adminClientOf(systemID)
 	if adminClient == nil {
 		return nil, fmt.Errorf("can't find adminClient by id %s", systemID)
 	}
@@ -0,0 +1,308 @@
+// Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dell/goscaleio"
+	siotypes "github.com/dell/goscaleio/types/v1"
+)
+
+const (
+	// defaultCredentialRefreshInterval is how often the credentials of the arrays are read again from their
+	// provider when no interval is configured
+	defaultCredentialRefreshInterval = time.Minute
+
+	vaultTokenHeader         = "X-Vault-Token" // #nosec G101
+	defaultVaultUsernameKey  = "username"
+	defaultVaultPasswordKey  = "password" // #nosec G101
+	vaultRequestTimeout      = 10 * time.Second
+	credentialRefreshTimeout = 5 * time.Minute
+)
+
+// VaultCredentials locates the credentials of an array in a Vault compatible secret store
+type VaultCredentials struct {
+	Address     string `json:"address"`               // URL of the secret store
+	Path        string `json:"path"`                  // path of the secret, as secret/data/powerflex for a KV v2 engine
+	TokenFile   string `json:"tokenFile"`             // file holding the token the secret is read with
+	UsernameKey string `json:"usernameKey,omitempty"` // key of the username in the secret, username by default
+	PasswordKey string `json:"passwordKey,omitempty"` // key of the password in the secret, password by default
+}
+
+// credentialProvider supplies the current credentials of an array
+type credentialProvider interface {
+	credentials(ctx context.Context) (username, password string, err error)
+}
+
+// validateCredentialSources checks that the username and the password of array each come from a single source
+func validateCredentialSources(array *ArrayConnectionData) error {
+	if array.Vault != nil {
+		if array.Username != "" || array.UsernameFile != "" || array.Password != "" || array.PasswordFile != "" {
+			return fmt.Errorf("vault cannot be used with username, usernameFile, password or passwordFile")
+		}
+		if array.Vault.Address == "" || array.Vault.Path == "" || array.Vault.TokenFile == "" {
+			return fmt.Errorf("vault requires address, path and tokenFile")
+		}
+	}
+	if array.Username != "" && array.UsernameFile != "" {
+		return fmt.Errorf("username and usernameFile cannot both be set")
+	}
+	if array.Password != "" && array.PasswordFile != "" {
+		return fmt.Errorf("password and passwordFile cannot both be set")
+	}
+	return nil
+}
+
+// newCredentialProvider returns the provider of the credentials of array, or nil when they are set inline. The
+// inline username or password of an array reading the other one from a file is kept.
+func newCredentialProvider(array *ArrayConnectionData) credentialProvider {
+	switch {
+	case array.Vault != nil:
+		return &vaultCredentialProvider{source: *array.Vault, client: &http.Client{Timeout: vaultRequestTimeout}}
+	case array.UsernameFile != "" || array.PasswordFile != "":
+		return &fileCredentialProvider{
+			username: array.Username, usernameFile: array.UsernameFile,
+			password: array.Password, passwordFile: array.PasswordFile,
+		}
+	}
+	return nil
+}
+
+// resolveArrayCredentials sets the username and password of array from its credential provider, if it has one
+func resolveArrayCredentials(ctx context.Context, array *ArrayConnectionData) error {
+	if err := validateCredentialSources(array); err != nil {
+		return err
+	}
+	provider := newCredentialProvider(array)
+	if provider == nil {
+		return nil
+	}
+	var err error
+	array.Username, array.Password, err = provider.credentials(ctx)
+	return err
+}
+
+// fileCredentialProvider reads the username and the password from mounted files, each of which may be set inline
+// instead
+type fileCredentialProvider struct {
+	username     string
+	usernameFile string
+	password     string
+	passwordFile string
+}
+
+func (p *fileCredentialProvider) credentials(_ context.Context) (string, string, error) {
+	username, password := p.username, p.password
+	if p.usernameFile != "" {
+		value, err := os.ReadFile(filepath.Clean(p.usernameFile))
+		if err != nil {
+			return "", "", fmt.Errorf("unable to read usernameFile: %v", err)
+		}
+		username = strings.TrimSpace(string(value))
+	}
+	if p.passwordFile != "" {
+		value, err := os.ReadFile(filepath.Clean(p.passwordFile))
+		if err != nil {
+			return "", "", fmt.Errorf("unable to read passwordFile: %v", err)
+		}
+		password = strings.TrimRight(string(value), "\r\n")
+	}
+	return username, password, nil
+}
+
+// vaultCredentialProvider reads the username and the password from a secret of a Vault compatible HTTP API
+type vaultCredentialProvider struct {
+	source VaultCredentials
+	client *http.Client
+}
+
+func (p *vaultCredentialProvider) credentials(ctx context.Context) (string, string, error) {
+	token, err := os.ReadFile(filepath.Clean(p.source.TokenFile))
+	if err != nil {
+		return "", "", fmt.Errorf("unable to read vault tokenFile: %v", err)
+	}
+	url := strings.TrimRight(p.source.Address, "/") + "/v1/" + strings.TrimLeft(p.source.Path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set(vaultTokenHeader, strings.TrimSpace(string(token)))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to read secret %s from vault: %v", p.source.Path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("unable to read secret %s from vault: %s", p.source.Path, resp.Status)
+	}
+
+	// a KV v2 engine nests the secret under data.data, a KV v1 engine under data
+	secret := struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return "", "", fmt.Errorf("unable to parse secret %s from vault: %v", p.source.Path, err)
+	}
+	values := make(map[string]string)
+	if nested, ok := secret.Data["data"]; ok {
+		if err := json.Unmarshal(nested, &values); err != nil {
+			return "", "", fmt.Errorf("unable to parse secret %s from vault: %v", p.source.Path, err)
+		}
+	} else {
+		for key, value := range secret.Data {
+			var s string
+			if json.Unmarshal(value, &s) == nil {
+				values[key] = s
+			}
+		}
+	}
+
+	usernameKey, passwordKey := p.source.UsernameKey, p.source.PasswordKey
+	if usernameKey == "" {
+		usernameKey = defaultVaultUsernameKey
+	}
+	if passwordKey == "" {
+		passwordKey = defaultVaultPasswordKey
+	}
+	if values[usernameKey] == "" || values[passwordKey] == "" {
+		return "", "", fmt.Errorf("secret %s from vault has no %s or %s", p.source.Path, usernameKey, passwordKey)
+	}
+	return values[usernameKey], values[passwordKey], nil
+}
+
+// isUnauthorized returns true when err is the rejection of the credentials by the gateway
+func isUnauthorized(err error) bool {
+	var e *siotypes.Error
+	return errors.As(err, &e) && e.HTTPStatusCode == http.StatusUnauthorized
+}
+
+// isSessionLoginRejected returns true when err is the rejection of the credentials by the gateway, or goscaleio
+// failing to log in again after the session of a client expired. goscaleio does not wrap the error of that login,
+// it is matched by its message.
+func isSessionLoginRejected(err error) bool {
+	return isUnauthorized(err) || (err != nil && strings.Contains(err.Error(), "Error Authenticating: "))
+}
+
+// reauthenticate reads the credentials of array again after the gateway rejected them and, when they changed,
+// logs in with them on a new client that replaces c and returns it. The array keeps its credentials when the new
+// ones are rejected too.
+func (s *service) reauthenticate(ctx context.Context, c *goscaleio.Client, array *ArrayConnectionData) (*goscaleio.Client, error) {
+	provider := newCredentialProvider(array)
+	if provider == nil {
+		return nil, fmt.Errorf("credentials of array %s are set inline", array.SystemID)
+	}
+	username, password, err := provider.credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if username == array.Username && password == array.Password {
+		return nil, fmt.Errorf("credentials of array %s did not change", array.SystemID)
+	}
+	rotated := *array
+	rotated.Username, rotated.Password = username, password
+	fresh, err := s.authenticateWithNewClient(ctx, c, &rotated)
+	if err != nil {
+		return nil, err
+	}
+	Log.Infof("logged in to array %s with its rotated credentials", array.SystemID)
+	s.replaceArray(array, &rotated)
+	return fresh, nil
+}
+
+// reauthenticateArray reads the credentials of an array again after the gateway rejected a request of its
+// admin client and, when they changed, logs in with them on a new admin client. The concurrent requests that are
+// rejected read the credentials once.
+func (s *service) reauthenticateArray(ctx context.Context, systemID string) {
+	s.reauthenticateMutex.Lock()
+	defer s.reauthenticateMutex.Unlock()
+	c, array := s.adminClientOf(systemID), s.arrayOf(systemID)
+	if c == nil || array == nil {
+		return
+	}
+	if _, err := s.reauthenticate(ctx, c, array); err != nil {
+		Log.WithError(err).Debugf("unable to log in to array %s with rotated credentials", systemID)
+	}
+}
+
+// runCredentialRefresher periodically reads the credentials of the arrays again from their providers, so the
+// arrays are logged in with rotated credentials before their sessions expire, see loginAdminClient.
+func (s *service) runCredentialRefresher(ctx context.Context, interval time.Duration) {
+	Log.Infof("credential refresher started, interval: %s", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			Log.Info("credential refresher stopped")
+			return
+		case <-ticker.C:
+			refreshCtx, cancel := context.WithTimeout(ctx, credentialRefreshTimeout)
+			s.refreshCredentials(refreshCtx)
+			cancel()
+		}
+	}
+}
+
+// refreshCredentials logs in to the arrays whose credentials were rotated in their provider with the new
+// credentials. An array keeps its credentials when the new ones cannot be read or are rejected.
+func (s *service) refreshCredentials(ctx context.Context) {
+	px.Lock()
+	defer px.Unlock()
+
+	arrays := s.arrayConfigs()
+	systemIDs := make([]string, 0, len(arrays))
+	for key, array := range arrays {
+		if key == array.SystemID {
+			systemIDs = append(systemIDs, key)
+		}
+	}
+	sort.Strings(systemIDs)
+
+	for _, systemID := range systemIDs {
+		array := arrays[systemID]
+		provider := newCredentialProvider(array)
+		if provider == nil {
+			continue
+		}
+		username, password, err := provider.credentials(ctx)
+		if err != nil {
+			Log.WithError(err).Errorf("unable to read the credentials of array %s, keeping the current ones", systemID)
+			continue
+		}
+		if username == array.Username && password == array.Password {
+			continue
+		}
+		rotated := *array
+		rotated.Username, rotated.Password = username, password
+		if c := s.adminClientOf(systemID); c != nil {
+			if _, err := s.authenticateWithNewClient(ctx, c, &rotated); err != nil {
+				Log.WithError(err).Errorf("unable to log in to array %s with its rotated credentials, keeping the current ones", systemID)
+				continue
+			}
+		}
+		Log.Infof("credentials of array %s rotated", systemID)
+		s.replaceArray(array, &rotated)
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	csi "github.com/container-storage-interface/spec/lib/go/csi"
 	podmon "github.com/dell/dell-csi-extensions/podmon"
@@ -31,10 +33,21 @@ import (
 const (
 	// ExistingGroupID group id on powerflex array
 	ExistingGroupID = "existingSnapshotGroupID"
+
+	// nfsGroupSnapshotMaxSkew is the maximum difference between the creation times of the
+	// snapshots of a volume group snapshot with NFS members
+	nfsGroupSnapshotMaxSkew = 10 * time.Second
 )
 
+// filesystemSnapshotDef describes the snapshot to take of an NFS member of a volume group snapshot
+type filesystemSnapshotDef struct {
+	SourceID     string
+	FileSystemID string
+	SnapshotName string
+}
+
 func (s *service) ValidateVolumeHostConnectivity(ctx context.Context, req *podmon.ValidateVolumeHostConnectivityRequest) (*podmon.ValidateVolumeHostConnectivityResponse, error) {
-	Log.Infof("ValidateVolumeHostConnectivity called %+v", req)
+	Log.Infof("ValidateVolumeHostConnectivity called %+v", redactRequest(req))
 	rep := &podmon.ValidateVolumeHostConnectivityResponse{
 		Messages: make([]string, 0),
 	}
@@ -56,7 +69,7 @@ func (s *service) ValidateVolumeHostConnectivity(ctx context.Context, req *podmo
 			systemID = s.getSystemIDFromCsiVolumeID(req.GetVolumeIds()[0])
 		}
 		if systemID == "" {
-			systemID = s.opts.defaultSystemID
+			systemID = s.defaultSystemID()
 		}
 	}
 
@@ -67,7 +80,11 @@ func (s *service) ValidateVolumeHostConnectivity(ctx context.Context, req *podmo
 
 	// First- check to see if the SDC is Connected or Disconnected.
 	// Then retrieve the SDC and seet the connection state
-	sdc, err := s.systems[systemID].FindSdc("SdcGUID", req.GetNodeId())
+	var sdc *sio.Sdc
+	err := s.gatewayCall(ctx, systemID, "FindSdc", func() (err error) {
+		sdc, err = s.systemOf(systemID).FindSdc("SdcGUID", req.GetNodeId())
+		return err
+	})
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "NodeID is invalid: %s - there is no corresponding SDC, error: %s", req.GetNodeId(), err.Error())
 	}
@@ -81,7 +98,7 @@ func (s *service) ValidateVolumeHostConnectivity(ctx context.Context, req *podmo
 		prevSystemID := systemID
 		systemID = s.getSystemIDFromCsiVolumeID(volID)
 		if systemID == "" {
-			systemID = s.opts.defaultSystemID
+			systemID = s.defaultSystemID()
 		}
 		if prevSystemID != systemID {
 			if err := s.requireProbe(ctx, systemID); err != nil {
@@ -90,15 +107,19 @@ func (s *service) ValidateVolumeHostConnectivity(ctx context.Context, req *podmo
 			}
 		}
 		// Get the Volume
-		vol, err := s.getVolByID(getVolumeIDFromCsiVolumeID(volID), systemID)
+		vol, err := s.getVolByID(ctx, getVolumeIDFromCsiVolumeID(volID), systemID)
 		if err != nil {
 			rep.Messages = append(rep.Messages, fmt.Sprintf("Could not retrieve volume: %s, error: %s", volID, err.Error()))
 			continue
 		}
 		// Get the volume statistics
-		volume := sio.NewVolume(s.adminClients[systemID])
+		volume := sio.NewVolume(s.adminClientOf(systemID))
 		volume.Volume = vol
-		stats, err := volume.GetVolumeStatistics()
+		var stats *siotypes.VolumeStatistics
+		err = s.gatewayCall(ctx, systemID, "GetVolumeStatistics", func() (err error) {
+			stats, err = volume.GetVolumeStatistics()
+			return err
+		})
 		if err != nil {
 			rep.Messages = append(rep.Messages, fmt.Sprintf("Could not retrieve volume statistics: %s, error: %s", volID, err.Error()))
 			continue
@@ -118,7 +139,7 @@ func (s *service) ValidateVolumeHostConnectivity(ctx context.Context, req *podmo
 }
 
 func (s *service) CreateVolumeGroupSnapshot(ctx context.Context, req *volumeGroupSnapshot.CreateVolumeGroupSnapshotRequest) (*volumeGroupSnapshot.CreateVolumeGroupSnapshotResponse, error) {
-	Log.Infof("CreateVolumeGroupSnapshot called with req: %v", req)
+	Log.Infof("CreateVolumeGroupSnapshot called with req: %v", redactRequest(req))
 
 	err := validateCreateVGSreq(req)
 	if err != nil {
@@ -140,7 +161,13 @@ func (s *service) CreateVolumeGroupSnapshot(ctx context.Context, req *volumeGrou
 
 	Log.Infof("Creating Snapshot Consistency Group on system: %s", systemID)
 
-	snapshotDefs, err := s.buildSnapshotDefs(req, systemID)
+	snapshotDefs, err := s.buildSnapshotDefs(ctx, req, systemID)
+	if err != nil {
+		Log.Errorf("Error from CreateVolumeGroupSnapshot: %v ", err)
+		return nil, err
+	}
+
+	fsSnapshotDefs, err := s.buildFilesystemSnapshotDefs(ctx, req, systemID)
 	if err != nil {
 		Log.Errorf("Error from CreateVolumeGroupSnapshot: %v ", err)
 		return nil, err
@@ -149,40 +176,111 @@ func (s *service) CreateVolumeGroupSnapshot(ctx context.Context, req *volumeGrou
 	snapParam := &siotypes.SnapshotVolumesParam{SnapshotDefs: snapshotDefs}
 
 	// check if req is Idempotent, return group found if yes
-	existingGroup, err := s.checkIdempotency(ctx, snapParam, systemID)
+	var existingGroup *volumeGroupSnapshot.CreateVolumeGroupSnapshotResponse
+	if len(snapshotDefs) > 0 {
+		existingGroup, err = s.checkIdempotency(ctx, snapParam, systemID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	existingFsSnapshots, err := s.checkFilesystemSnapshotsIdempotency(ctx, systemID, fsSnapshotDefs)
 	if err != nil {
 		return nil, err
 	}
-	if existingGroup != nil {
-		return existingGroup, nil
+	blockDone := len(snapshotDefs) == 0 || existingGroup != nil
+	fsDone := len(fsSnapshotDefs) == 0 || existingFsSnapshots != nil
+	if blockDone && fsDone {
+		return buildGroupSnapshotResponse(existingGroup, existingFsSnapshots, systemID, req.Name), nil
+	}
+	if existingGroup != nil || existingFsSnapshots != nil {
+		err := status.Error(codes.Internal, "Some snapshots exist on array, while others need to be created. Cannot create VolumeGroupSnapshot")
+		Log.Errorf("Error from CreateVolumeGroupSnapshot: %v ", err)
+		return nil, err
 	}
 
-	// Create snapshot(s), Idempotent requests will already be returned before this is called
-	snapResponse, err := s.systems[systemID].CreateSnapshotConsistencyGroup(snapParam)
-	if err != nil {
-		var snapsThatFailed []string
+	// Create snapshot(s), Idempotent requests will already be returned before this is called.
+	// The block and filesystem snapshots are started together to keep their creation times close.
+	var snapResponse *siotypes.SnapshotVolumesResp
+	var snapErr error
+	fsSnapshots := make([]*siotypes.FileSystem, len(fsSnapshotDefs))
+	fsErrs := make([]error, len(fsSnapshotDefs))
+	var wg sync.WaitGroup
+	if len(snapshotDefs) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			snapErr = s.gatewayCall(ctx, systemID, "CreateSnapshotConsistencyGroup", func() (err error) {
+				snapResponse, err = s.systemOf(systemID).CreateSnapshotConsistencyGroup(snapParam)
+				return err
+			})
+		}()
+	}
+	for i, fsSnapshotDef := range fsSnapshotDefs {
+		wg.Add(1)
+		go func(i int, fsSnapshotDef *filesystemSnapshotDef) {
+			defer wg.Done()
+			fsSnapshots[i], fsErrs[i] = s.createFilesystemSnapshot(ctx, systemID, fsSnapshotDef)
+		}(i, fsSnapshotDef)
+	}
+	wg.Wait()
+
+	var snapsThatFailed []string
+	if snapErr != nil {
 		for _, snap := range snapshotDefs {
 			snapsThatFailed = append(snapsThatFailed, snap.SnapshotName)
 		}
-		err = status.Errorf(codes.Internal, "Failed to create group with snapshots %s : %s", snapsThatFailed, err.Error())
-		Log.Errorf("Error from CreateVolumeGroupSnapshot: %v ", err)
-		return nil, err
 	}
-	Log.Infof("snapResponse is: %s", snapResponse)
-	// populate response
-	groupSnapshots, err := s.buildCreateVGSResponse(ctx, snapResponse, systemID)
-	if err != nil {
+	for i, fsErr := range fsErrs {
+		if fsErr != nil {
+			snapsThatFailed = append(snapsThatFailed, fsSnapshotDefs[i].SnapshotName)
+			if snapErr == nil {
+				snapErr = fsErr
+			}
+		}
+	}
+	if snapErr != nil {
+		s.rollbackGroupSnapshots(ctx, systemID, snapResponse, fsSnapshots)
+		err = status.Errorf(codes.Internal, "Failed to create group with snapshots %s : %s", snapsThatFailed, snapErr.Error())
 		Log.Errorf("Error from CreateVolumeGroupSnapshot: %v ", err)
 		return nil, err
 	}
 
-	// Check  Creation time, should be the same across all volumes
-	err = checkCreationTime(groupSnapshots[0].CreationTime, groupSnapshots)
-	if err != nil {
-		return nil, err
+	var groupSnapshots []*volumeGroupSnapshot.Snapshot
+	groupID := systemID + "-" + req.Name
+	if snapResponse != nil {
+		Log.Infof("snapResponse is: %s", snapResponse)
+		// populate response
+		groupSnapshots, err = s.buildCreateVGSResponse(ctx, snapResponse, systemID)
+		if err != nil {
+			Log.Errorf("Error from CreateVolumeGroupSnapshot: %v ", err)
+			s.rollbackGroupSnapshots(ctx, systemID, snapResponse, fsSnapshots)
+			return nil, err
+		}
+
+		// Check  Creation time, should be the same across all volumes
+		err = checkCreationTime(groupSnapshots[0].CreationTime, groupSnapshots)
+		if err != nil {
+			s.rollbackGroupSnapshots(ctx, systemID, snapResponse, fsSnapshots)
+			return nil, err
+		}
+		groupID = systemID + "-" + snapResponse.SnapshotGroupID
+	}
+
+	for i, fsSnapshot := range fsSnapshots {
+		groupSnapshots = append(groupSnapshots, getVGSSnapshotFromFileSystem(fsSnapshot, fsSnapshotDefs[i], systemID))
+	}
+
+	// filesystem snapshots are not taken atomically with the block snapshots, so they are only
+	// required to be taken within nfsGroupSnapshotMaxSkew of each other
+	if len(fsSnapshots) > 0 {
+		err = checkCreationTimeSkew(groupSnapshots, nfsGroupSnapshotMaxSkew)
+		if err != nil {
+			s.rollbackGroupSnapshots(ctx, systemID, snapResponse, fsSnapshots)
+			return nil, err
+		}
 	}
 
-	resp := &volumeGroupSnapshot.CreateVolumeGroupSnapshotResponse{SnapshotGroupID: systemID + "-" + snapResponse.SnapshotGroupID, Snapshots: groupSnapshots, CreationTime: groupSnapshots[0].CreationTime}
+	resp := &volumeGroupSnapshot.CreateVolumeGroupSnapshotResponse{SnapshotGroupID: groupID, Snapshots: groupSnapshots, CreationTime: groupSnapshots[0].CreationTime}
 
 	Log.Infof("CreateVolumeGroupSnapshot Response:  %#v", resp)
 	return resp, nil
@@ -207,7 +305,7 @@ func (s *service) getSystemID(req *volumeGroupSnapshot.CreateVolumeGroupSnapshot
 	systemID := s.getSystemIDFromCsiVolumeID(req.SourceVolumeIDs[0])
 	if systemID == "" {
 		// use default system
-		systemID = s.opts.defaultSystemID
+		systemID = s.defaultSystemID()
 	}
 
 	if systemID == "" {
@@ -243,7 +341,7 @@ func validateCreateVGSreq(req *volumeGroupSnapshot.CreateVolumeGroupSnapshotRequ
 	return nil
 }
 
-func (s *service) buildSnapshotDefs(req *volumeGroupSnapshot.CreateVolumeGroupSnapshotRequest, systemID string) ([]*siotypes.SnapshotDef, error) {
+func (s *service) buildSnapshotDefs(ctx context.Context, req *volumeGroupSnapshot.CreateVolumeGroupSnapshotRequest, systemID string) ([]*siotypes.SnapshotDef, error) {
 	snapshotDefs := make([]*siotypes.SnapshotDef, 0)
 
 	for index, id := range req.SourceVolumeIDs {
@@ -254,8 +352,13 @@ func (s *service) buildSnapshotDefs(req *volumeGroupSnapshot.CreateVolumeGroupSn
 			return nil, err
 		}
 
+		// NFS volumes are snapshotted separately, see buildFilesystemSnapshotDefs
+		if strings.Contains(id, "/") {
+			continue
+		}
+
 		// legacy vol check
-		err := s.checkVolumesMap(id)
+		err := s.checkVolumesMap(ctx, id)
 		if err != nil {
 			err = status.Errorf(codes.Internal, "checkVolumesMap for id: %s failed : %s", id, err.Error())
 			Log.Errorf("Error from buildSnapshotDefs: %v ", err)
@@ -264,7 +367,7 @@ func (s *service) buildSnapshotDefs(req *volumeGroupSnapshot.CreateVolumeGroupSn
 
 		volID := getVolumeIDFromCsiVolumeID(id)
 
-		_, err = s.getVolByID(volID, systemID)
+		_, err = s.getVolByID(ctx, volID, systemID)
 		if err != nil {
 			err = status.Errorf(codes.Internal, "failure checking source volume status: %s", err.Error())
 			Log.Errorf("Error from buildSnapshotDefs: %v ", err)
@@ -309,7 +412,11 @@ func (s *service) checkIdempotency(ctx context.Context, snapshotsToMake *siotype
 	var idempotencyValue bool
 	for _, snap := range snapshotsToMake.SnapshotDefs {
 		// snapshots will always have a  consistency group ID, so setting it to "", means no snapshot was found
-		existingSnaps, _ := s.adminClients[systemID].GetVolume("", "", snap.VolumeID, snap.SnapshotName, true)
+		var existingSnaps []*siotypes.Volume
+		_ = s.gatewayCall(ctx, systemID, "GetVolume", func() (err error) {
+			existingSnaps, err = s.adminClientOf(systemID).GetVolume("", "", snap.VolumeID, snap.SnapshotName, true)
+			return err
+		})
 		idempotencyMap[snap.SnapshotName] = false
 		for _, existingSnap := range existingSnaps {
 			consistencyGroupMap[existingSnap.Name] = ""
@@ -347,7 +454,11 @@ func (s *service) checkIdempotency(ctx context.Context, snapshotsToMake *siotype
 	}
 
 	// now we need to check that the consistency group contains no extra snaps. This is done last.
-	existingVols, _ := s.adminClients[systemID].GetVolume("", "", "", "", true)
+	var existingVols []*siotypes.Volume
+	_ = s.gatewayCall(ctx, systemID, "GetVolume", func() (err error) {
+		existingVols, err = s.adminClientOf(systemID).GetVolume("", "", "", "", true)
+		return err
+	})
 	for _, vol := range existingVols {
 		grpID := systemID + "-" + vol.ConsistencyGroupID
 		if grpID == systemID+"-"+consistencyGroupValue {
@@ -408,15 +519,21 @@ func (s *service) buildCreateVGSResponse(ctx context.Context, snapResponse *siot
 		}
 		var arraySnapName string
 		// ancestorvolumeid
-		existingSnap, _ := s.adminClients[systemID].GetVolume("", id, lResponse.Entries[0].Snapshot.SourceVolumeId, "", true)
+		var existingSnap []*siotypes.Volume
+		_ = s.gatewayCall(ctx, systemID, "GetVolume", func() (err error) {
+			existingSnap, err = s.adminClientOf(systemID).GetVolume("", id, lResponse.Entries[0].Snapshot.SourceVolumeId, "", true)
+			return err
+		})
 		for _, e := range existingSnap {
 			if e.ID == id && e.ConsistencyGroupID == snapResponse.SnapshotGroupID {
 				if e.Name == "" {
 					Log.Infof("debug set snap name for [%s]", e.ID)
 					arraySnapName = e.ID + "-snap-" + strconv.Itoa(index)
-					tgtVol := sio.NewVolume(s.adminClients[systemID])
+					tgtVol := sio.NewVolume(s.adminClientOf(systemID))
 					tgtVol.Volume = e
-					err := tgtVol.SetVolumeName(arraySnapName)
+					err := s.gatewayCall(ctx, systemID, "SetVolumeName", func() error {
+						return tgtVol.SetVolumeName(arraySnapName)
+					})
 					if err != nil {
 						Log.Errorf("Error setting name of snapshot id=%s name=%s %s", e.ID, arraySnapName, err.Error())
 					}
@@ -444,3 +561,184 @@ func (s *service) buildCreateVGSResponse(ctx context.Context, snapResponse *siot
 
 	return groupSnapshots, nil
 }
+
+// buildFilesystemSnapshotDefs returns the snapshots to take of the NFS members of the request
+func (s *service) buildFilesystemSnapshotDefs(ctx context.Context, req *volumeGroupSnapshot.CreateVolumeGroupSnapshotRequest, systemID string) ([]*filesystemSnapshotDef, error) {
+	fsSnapshotDefs := make([]*filesystemSnapshotDef, 0)
+
+	for index, id := range req.SourceVolumeIDs {
+		if !strings.Contains(id, "/") {
+			continue
+		}
+		fsID := getFilesystemIDFromCsiVolumeID(id)
+		_, err := s.getFilesystemByID(ctx, fsID, systemID)
+		if err != nil {
+			err = status.Errorf(codes.Internal, "failure checking source filesystem status: %s", err.Error())
+			Log.Errorf("Error from buildFilesystemSnapshotDefs: %v ", err)
+			return nil, err
+		}
+
+		fsSnapshotDefs = append(fsSnapshotDefs, &filesystemSnapshotDef{
+			SourceID:     id,
+			FileSystemID: fsID,
+			SnapshotName: req.Name + "-" + strconv.Itoa(index),
+		})
+	}
+
+	return fsSnapshotDefs, nil
+}
+
+// checkFilesystemSnapshotsIdempotency returns the existing filesystem snapshots when all of them were
+// already taken, nil when none of them were, and an error for a mixture of both
+func (s *service) checkFilesystemSnapshotsIdempotency(ctx context.Context, systemID string, fsSnapshotDefs []*filesystemSnapshotDef) ([]*siotypes.FileSystem, error) {
+	if len(fsSnapshotDefs) == 0 {
+		return nil, nil
+	}
+
+	existingSnapshots := make([]*siotypes.FileSystem, 0)
+	for _, fsSnapshotDef := range fsSnapshotDefs {
+		var existingSnap *siotypes.FileSystem
+		err := s.gatewayCall(ctx, systemID, "GetFileSystemByIDName", func() (err error) {
+			existingSnap, err = s.systemOf(systemID).GetFileSystemByIDName("", fsSnapshotDef.SnapshotName)
+			return err
+		})
+		if err != nil {
+			continue
+		}
+		if existingSnap.ParentID != fsSnapshotDef.FileSystemID {
+			err := status.Errorf(codes.AlreadyExists,
+				"snapshot with name '%s' exists, but SourceVolumeId %s doesn't match", fsSnapshotDef.SnapshotName, fsSnapshotDef.FileSystemID)
+			Log.Errorf("Error from checkFilesystemSnapshotsIdempotency: %v ", err)
+			return nil, err
+		}
+		existingSnapshots = append(existingSnapshots, existingSnap)
+	}
+
+	if len(existingSnapshots) == 0 {
+		return nil, nil
+	}
+	if len(existingSnapshots) != len(fsSnapshotDefs) {
+		err := status.Error(codes.Internal, "Some snapshots exist on array, while others need to be created. Cannot create VolumeGroupSnapshot")
+		Log.Errorf("Error from checkFilesystemSnapshotsIdempotency: %v ", err)
+		return nil, err
+	}
+	Log.Infof("Filesystem snapshots already exist on array")
+	return existingSnapshots, nil
+}
+
+// createFilesystemSnapshot takes the snapshot of an NFS member of a volume group snapshot
+func (s *service) createFilesystemSnapshot(ctx context.Context, systemID string, fsSnapshotDef *filesystemSnapshotDef) (*siotypes.FileSystem, error) {
+	system := s.systemOf(systemID)
+	var resp *siotypes.CreateFileSystemSnapshotResponse
+	err := s.gatewayCall(ctx, systemID, "CreateFileSystemSnapshot", func() (err error) {
+		resp, err = system.CreateFileSystemSnapshot(&siotypes.CreateFileSystemSnapshotParam{
+			Name: fsSnapshotDef.SnapshotName,
+		}, fsSnapshotDef.FileSystemID)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating snapshot with name %s for filesystem %s: %s", fsSnapshotDef.SnapshotName, fsSnapshotDef.FileSystemID, err.Error())
+	}
+
+	var newSnap *siotypes.FileSystem
+	err = s.gatewayCall(ctx, systemID, "GetFileSystemByIDName", func() (err error) {
+		newSnap, err = system.GetFileSystemByIDName(resp.ID, "")
+		return err
+	})
+	if err != nil {
+		// the snapshot was created, it is returned so that it can be rolled back
+		return &siotypes.FileSystem{ID: resp.ID, Name: fsSnapshotDef.SnapshotName},
+			fmt.Errorf("error getting snapshot %s: %s", resp.ID, err.Error())
+	}
+	return newSnap, nil
+}
+
+// rollbackGroupSnapshots deletes the snapshots taken for a volume group snapshot that could not be completed
+func (s *service) rollbackGroupSnapshots(ctx context.Context, systemID string, snapResponse *siotypes.SnapshotVolumesResp, fsSnapshots []*siotypes.FileSystem) {
+	var snapIDs []string
+	if snapResponse != nil {
+		for _, id := range snapResponse.VolumeIDList {
+			snapIDs = append(snapIDs, systemID+"-"+id)
+		}
+	}
+	for _, fsSnapshot := range fsSnapshots {
+		if fsSnapshot != nil {
+			snapIDs = append(snapIDs, systemID+"/"+fsSnapshot.ID)
+		}
+	}
+
+	for _, snapID := range snapIDs {
+		Log.Infof("Rolling back volume group snapshot, deleting snapshot %s", snapID)
+		_, err := s.DeleteSnapshot(ctx, &csi.DeleteSnapshotRequest{SnapshotId: snapID})
+		if err != nil {
+			Log.Errorf("Error rolling back volume group snapshot, snapshot %s must be deleted manually: %s", snapID, err.Error())
+		}
+	}
+}
+
+// buildGroupSnapshotResponse builds the response for a volume group snapshot that already exists on the array
+func buildGroupSnapshotResponse(existingGroup *volumeGroupSnapshot.CreateVolumeGroupSnapshotResponse, fsSnapshots []*siotypes.FileSystem, systemID string, name string) *volumeGroupSnapshot.CreateVolumeGroupSnapshotResponse {
+	resp := &volumeGroupSnapshot.CreateVolumeGroupSnapshotResponse{SnapshotGroupID: systemID + "-" + name}
+	if existingGroup != nil {
+		resp = existingGroup
+	}
+	for _, fsSnapshot := range fsSnapshots {
+		resp.Snapshots = append(resp.Snapshots, getVGSSnapshotFromFileSystem(fsSnapshot, &filesystemSnapshotDef{
+			SourceID:     systemID + "/" + fsSnapshot.ParentID,
+			FileSystemID: fsSnapshot.ParentID,
+			SnapshotName: fsSnapshot.Name,
+		}, systemID))
+	}
+	if existingGroup == nil && len(resp.Snapshots) > 0 {
+		resp.CreationTime = resp.Snapshots[0].CreationTime
+	}
+	Log.Infof("Returning Idempotent response: %v", resp)
+	return resp
+}
+
+// getVGSSnapshotFromFileSystem converts a filesystem snapshot to a volume group snapshot member
+func getVGSSnapshotFromFileSystem(fsSnapshot *siotypes.FileSystem, fsSnapshotDef *filesystemSnapshotDef, systemID string) *volumeGroupSnapshot.Snapshot {
+	return &volumeGroupSnapshot.Snapshot{
+		Name:          fsSnapshot.Name,
+		CapacityBytes: int64(fsSnapshot.SizeTotal),
+		SnapId:        systemID + "/" + fsSnapshot.ID,
+		SourceId:      fsSnapshotDef.SourceID,
+		ReadyToUse:    true,
+		CreationTime:  getFileSystemCreationTime(fsSnapshot),
+	}
+}
+
+// getFileSystemCreationTime returns the creation time of a filesystem in nanoseconds, or 0 if it is not known
+func getFileSystemCreationTime(fs *siotypes.FileSystem) int64 {
+	if t, err := time.Parse(time.RFC3339Nano, fs.CreationTimestamp); err == nil {
+		return t.UnixNano()
+	}
+	if seconds, err := strconv.ParseInt(fs.CreationTimestamp, 10, 64); err == nil {
+		return seconds * int64(time.Second)
+	}
+	return 0
+}
+
+// checkCreationTimeSkew verifies that the creation times of the snapshots are known and at most maxSkew apart
+func checkCreationTimeSkew(snapshots []*volumeGroupSnapshot.Snapshot, maxSkew time.Duration) error {
+	var first, last *volumeGroupSnapshot.Snapshot
+	for _, snap := range snapshots {
+		if snap.CreationTime == 0 {
+			err := status.Errorf(codes.Internal, "Creation time of snapshot %s is not known, its skew from the other snapshots cannot be checked", snap.Name)
+			Log.Errorf("Error from checkCreationTimeSkew: %v ", err)
+			return err
+		}
+		if first == nil || snap.CreationTime < first.CreationTime {
+			first = snap
+		}
+		if last == nil || snap.CreationTime > last.CreationTime {
+			last = snap
+		}
+	}
+	if first != nil && time.Duration(last.CreationTime-first.CreationTime) > maxSkew {
+		err := status.Errorf(codes.Internal, "Creation time of snapshot %s, %d is more than %s after snapshot %s creation time %d", last.Name, last.CreationTime, maxSkew, first.Name, first.CreationTime)
+		Log.Errorf("Error from checkCreationTimeSkew: %v ", err)
+		return err
+	}
+	return nil
+}
@@ -58,6 +58,9 @@ const (
 	// EnvReplicationPrefix is used as a prefix to find out if replication is enabled.
 	EnvReplicationPrefix = "X_CSI_REPLICATION_PREFIX" // #nosec G101
 
+	// EnvEnableSnapshotCGDelete enables deleting all the snapshots of the consistency group of a deleted snapshot
+	EnvEnableSnapshotCGDelete = "X_CSI_VXFLEXOS_ENABLESNAPSHOTCGDELETE"
+
 	// EnvMaxVolumesPerNode specifies maximum number of volumes that controller can publish to the node.
 	EnvMaxVolumesPerNode = "X_CSI_MAX_VOLUMES_PER_NODE"
 
@@ -69,4 +72,75 @@ const (
 
 	// EnvKubeNodeName is the name of the environment variable which stores current kubernetes node name
 	EnvKubeNodeName = "X_CSI_POWERFLEX_KUBE_NODE_NAME"
+
+	// EnvNFSExportReconcileInterval is the name of the environment variable that specifies how often the
+	// controller reconciles the host lists of NFS exports against the current VolumeAttachments, e.g. "10m".
+	// The reconciler is disabled when it is unset or zero.
+	EnvNFSExportReconcileInterval = "X_CSI_NFS_EXPORT_RECONCILE_INTERVAL"
+
+	// EnvNFSExportReconcileDryRun is the name of the environment variable that specifies if the NFS export
+	// reconciler should only report stale hosts instead of removing them.
+	EnvNFSExportReconcileDryRun = "X_CSI_NFS_EXPORT_RECONCILE_DRY_RUN"
+
+	// EnvNFSMountOptions is the name of the environment variable that specifies the comma separated default
+	// mount options for NFS volumes, e.g. "vers=4.1,proto=tcp,hard,timeo=600". Mount options set in the
+	// StorageClass take precedence over these defaults.
+	EnvNFSMountOptions = "X_CSI_NFS_MOUNT_OPTIONS"
+
+	// EnvNFSAllowedMountOptions is the name of the environment variable that specifies the comma separated
+	// names of the NFS mount options that may be used. Any mount option may be used when it is unset.
+	EnvNFSAllowedMountOptions = "X_CSI_NFS_ALLOWED_MOUNT_OPTIONS"
+
+	// EnvReplicationAutoReprotectInterval is the name of the environment variable that specifies how often the
+	// controller checks for replication consistency groups left failed over by an unplanned failover, e.g. "1m".
+	// Those groups are reprotected and resumed automatically. The policy is disabled when it is unset or zero.
+	EnvReplicationAutoReprotectInterval = "X_CSI_REPLICATION_AUTO_REPROTECT_INTERVAL"
+
+	// EnvReplicationAutoReprotectGracePeriod is the name of the environment variable that specifies how long a
+	// replication consistency group must stay failed over, with both systems reachable, before it is
+	// reprotected automatically, e.g. "10m". Defaults to 5m.
+	EnvReplicationAutoReprotectGracePeriod = "X_CSI_REPLICATION_AUTO_REPROTECT_GRACE_PERIOD"
+
+	// EnvVolumeMetricsInterval is the name of the environment variable that specifies how often the controller
+	// collects the performance metrics of the volumes and the capacity of the storage pools, e.g. "1m".
+	// The collector is disabled when it is unset or zero.
+	EnvVolumeMetricsInterval = "X_CSI_POWERFLEX_VOLUME_METRICS_INTERVAL"
+
+	// EnvTracingEndpoint is the name of the environment variable that specifies the OTLP/HTTP endpoint the
+	// traces of the driver are exported to, e.g. "http://otel-collector:4318". Tracing is disabled when it is unset.
+	EnvTracingEndpoint = "X_CSI_POWERFLEX_TRACING_ENDPOINT"
+
+	// EnvTracingSampleRatio is the name of the environment variable that specifies the fraction of the requests
+	// that are traced, between 0 and 1. Requests that are part of a sampled trace are always traced. Defaults to 1.
+	EnvTracingSampleRatio = "X_CSI_POWERFLEX_TRACING_SAMPLE_RATIO"
+
+	// EnvAuditLogPath is the name of the environment variable that specifies the file the audit records of the
+	// operations that change the storage are appended to, as JSON lines. Auditing is disabled when it is unset.
+	EnvAuditLogPath = "X_CSI_POWERFLEX_AUDIT_LOG_PATH"
+
+	// EnvAuditLogMaxSizeMB is the name of the environment variable that specifies the size in megabytes at
+	// which the audit log is rotated. Defaults to 100.
+	EnvAuditLogMaxSizeMB = "X_CSI_POWERFLEX_AUDIT_LOG_MAX_SIZE_MB"
+
+	// EnvAuditLogMaxBackups is the name of the environment variable that specifies how many rotated audit logs
+	// are kept. Defaults to 10.
+	EnvAuditLogMaxBackups = "X_CSI_POWERFLEX_AUDIT_LOG_MAX_BACKUPS"
+
+	// EnvCredentialRefreshInterval is the name of the environment variable that specifies how often the
+	// credentials of the arrays set with usernameFile, passwordFile or vault are read again to pick up a
+	// rotation, e.g. "5m". Defaults to 1m, zero disables it. The credentials are also read again when the
+	// gateway rejects a request, only that request fails when the session expired after a rotation.
+	EnvCredentialRefreshInterval = "X_CSI_POWERFLEX_CREDENTIAL_REFRESH_INTERVAL"
+
+	// EnvGatewayHealthCheckInterval is the name of the environment variable that specifies how often a gateway
+	// of an array with several endpoints is checked after a failover, to return to it when it recovers, e.g.
+	// "1m". Defaults to 30s, zero disables it.
+	EnvGatewayHealthCheckInterval = "X_CSI_POWERFLEX_GATEWAY_HEALTH_CHECK_INTERVAL"
+
+	// EnvPodName is the name of the environment variable which stores the name of the driver pod,
+	// the driver emits its Kubernetes events on this pod
+	EnvPodName = "X_CSI_POWERFLEX_POD_NAME"
+
+	// EnvPodNamespace is the name of the environment variable which stores the namespace of the driver pod
+	EnvPodNamespace = "X_CSI_POWERFLEX_POD_NAMESPACE"
 )
@@ -54,6 +54,26 @@ func parseSize(size string) (int64, error) {
 	return 0, errors.New(message)
 }
 
+// getEphemeralNFSVolumeCapability returns a mount capability with fsType nfs, keeping the
+// access mode and mount flags of the inline volume
+func getEphemeralNFSVolumeCapability(volCap *csi.VolumeCapability) *csi.VolumeCapability {
+	nfsCap := &csi.VolumeCapability{
+		AccessMode: &csi.VolumeCapability_AccessMode{
+			Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER,
+		},
+		AccessType: &csi.VolumeCapability_Mount{
+			Mount: &csi.VolumeCapability_MountVolume{
+				FsType:     "nfs",
+				MountFlags: volCap.GetMount().GetMountFlags(),
+			},
+		},
+	}
+	if volCap.GetAccessMode() != nil {
+		nfsCap.AccessMode = volCap.GetAccessMode()
+	}
+	return nfsCap
+}
+
 // Call complete stack: systemProbe, CreateVolume, ControllerPublishVolume, and NodePublishVolume
 func (s *service) ephemeralNodePublish(
 	ctx context.Context,
@@ -97,17 +117,17 @@ func (s *service) ephemeralNodePublish(
 
 	if systemName == "" {
 		Log.Debug("systemName not specified, using default array")
-		systemName = s.opts.defaultSystemID
+		systemName = s.defaultSystemID()
 	}
 
-	array := s.opts.arrays[systemName]
+	array := s.arrayOf(systemName)
 
 	if array == nil {
 		// to get inside this if block, req has name, but secret has ID, need to convert from name -> ID
-		if id, ok := s.connectedSystemNameToID[systemName]; ok {
+		if id, ok := s.systemIDByName(systemName); ok {
 			// systemName was sent in req, but secret used ID. Change to ID.
 			Log.Debug("systemName set to id")
-			array = s.opts.arrays[id]
+			array = s.arrayOf(id)
 		} else {
 			err = status.Errorf(codes.Internal, "systemID: %s not recgonized", systemName)
 			Log.Errorf("Error from ephemeralNodePublish: %v ", err)
@@ -122,13 +142,19 @@ func (s *service) ephemeralNodePublish(
 		return nil, status.Error(codes.Internal, "inline ephemeral system prob failed: "+err.Error())
 	}
 
+	// fsType nfs in the volume attributes creates a filesystem on the NAS server instead of a block volume
+	volCap := req.VolumeCapability
+	if req.VolumeContext[KeyFsType] == "nfs" {
+		volCap = getEphemeralNFSVolumeCapability(req.VolumeCapability)
+	}
+
 	crvolresp, err := s.CreateVolume(ctx, &csi.CreateVolumeRequest{
 		Name: volName,
 		CapacityRange: &csi.CapacityRange{
 			RequiredBytes: volSize,
 			LimitBytes:    0,
 		},
-		VolumeCapabilities: []*csi.VolumeCapability{req.VolumeCapability},
+		VolumeCapabilities: []*csi.VolumeCapability{volCap},
 		Parameters:         req.VolumeContext,
 		Secrets:            req.Secrets,
 	})
@@ -173,7 +199,7 @@ func (s *service) ephemeralNodePublish(
 	cpubresp, err := s.ControllerPublishVolume(ctx, &csi.ControllerPublishVolumeRequest{
 		NodeId:           NodeID,
 		VolumeId:         volumeID,
-		VolumeCapability: req.VolumeCapability,
+		VolumeCapability: volCap,
 		Readonly:         req.Readonly,
 		Secrets:          req.Secrets,
 		VolumeContext:    crvolresp.Volume.VolumeContext,
@@ -192,7 +218,7 @@ func (s *service) ephemeralNodePublish(
 		PublishContext:    cpubresp.PublishContext,
 		StagingTargetPath: ephemeralStagingMountPath,
 		TargetPath:        req.TargetPath,
-		VolumeCapability:  req.VolumeCapability,
+		VolumeCapability:  volCap,
 		Readonly:          req.Readonly,
 		Secrets:           req.Secrets,
 		VolumeContext:     crvolresp.Volume.VolumeContext,
@@ -239,7 +265,11 @@ func (s *service) ephemeralNodeUnpublish(
 		NodeId:   NodeID,
 	})
 	if err != nil {
-		return errors.New("Inline ephemeral controller unpublish failed")
+		// the volume may already be gone when cleaning up after a node crash
+		if status.Code(err) != codes.NotFound {
+			return errors.New("Inline ephemeral controller unpublish failed")
+		}
+		Log.Infof("Inline ephemeral volume %s not found during controller unpublish: %s", goodVolid, err.Error())
 	}
 
 	_, err = s.DeleteVolume(ctx, &csi.DeleteVolumeRequest{
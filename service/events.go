@@ -0,0 +1,84 @@
+// Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dell/csi-vxflexos/v2/k8sutils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultPodNamespace is the namespace of the driver events when no other namespace applies
+const defaultPodNamespace = "default"
+
+// recordEvent emits a Kubernetes event on the driver pod. The pod is identified by the pod name and
+// namespace options; no event is emitted when the pod name is not configured.
+func (s *service) recordEvent(ctx context.Context, eventType, reason, messageFmt string, args ...interface{}) {
+	if s.opts.PodName == "" {
+		Log.Debugf("pod name not configured, not emitting event %s: %s", reason, fmt.Sprintf(messageFmt, args...))
+		return
+	}
+
+	namespace := s.opts.PodNamespace
+	if namespace == "" {
+		namespace = defaultPodNamespace
+	}
+	pod := corev1.ObjectReference{
+		Kind:       "Pod",
+		APIVersion: "v1",
+		Name:       s.opts.PodName,
+		Namespace:  namespace,
+	}
+	recordObjectEvent(ctx, pod, eventType, reason, messageFmt, args...)
+}
+
+// recordObjectEvent emits a Kubernetes event on object. Events of cluster scoped objects go to the default
+// namespace. Failures are only logged, an event never fails the operation it reports on.
+func recordObjectEvent(ctx context.Context, object corev1.ObjectReference, eventType, reason, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+	if K8sClientset == nil {
+		if err := k8sutils.CreateKubeClientSet(); err != nil {
+			Log.WithError(err).Errorf("unable to create k8s clientset, not emitting event %s: %s", reason, message)
+			return
+		}
+		K8sClientset = k8sutils.Clientset
+	}
+
+	namespace := object.Namespace
+	if namespace == "" {
+		namespace = defaultPodNamespace
+	}
+	now := metav1.NewTime(time.Now())
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", object.Name, now.UnixNano()),
+			Namespace: namespace,
+		},
+		InvolvedObject: object,
+		Reason:         reason,
+		Message:        message,
+		Type:           eventType,
+		Source:         corev1.EventSource{Component: Name},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	if _, err := K8sClientset.CoreV1().Events(namespace).Create(ctx, event, metav1.CreateOptions{}); err != nil {
+		Log.WithError(err).Errorf("unable to emit event %s: %s", reason, message)
+	}
+}
@@ -107,6 +107,61 @@ Feature: VxFlex OS CSI interface
     Then a valid PublishVolumeResponse is returned
     And I call NFS PublishVolume with "single-writer"
     Then a valid PublishVolumeResponse is returned
+
+    Scenario: Reconcile NFS exports reports stale host in dry run
+    Given a VxFlexOS service
+    And I enable the audit log
+    When I specify CreateVolumeMountRequest "nfs"
+    And I call CreateVolume "volume1"
+    Then a valid CreateVolumeResponse is returned
+    And I call NFS PublishVolume with "single-writer"
+    Then a valid PublishVolumeResponse is returned
+    And I call reconcileNFSExports with dry run "true"
+    Then the error contains "none"
+    And the NFS export reconcile reports 1 stale hosts
+    And I call reconcileNFSExports with dry run "true"
+    And the NFS export reconcile reports 1 stale hosts
+    And the audit records are "none"
+
+    Scenario: Reconcile NFS exports removes stale host
+    Given a VxFlexOS service
+    And I enable the audit log
+    When I specify CreateVolumeMountRequest "nfs"
+    And I call CreateVolume "volume1"
+    Then a valid CreateVolumeResponse is returned
+    And I call NFS PublishVolume with "single-writer"
+    Then a valid PublishVolumeResponse is returned
+    And I call reconcileNFSExports with dry run "false"
+    Then the error contains "none"
+    And the NFS export reconcile reports 1 stale hosts
+    And I call reconcileNFSExports with dry run "true"
+    And the NFS export reconcile reports 0 stale hosts
+    And the audit records are "NFSExportReconcile/RemoveHosts:success"
+
+    Scenario: Reconcile NFS exports keeps host of attached node
+    Given a VxFlexOS service
+    When I specify CreateVolumeMountRequest "nfs"
+    And I call CreateVolume "volume1"
+    Then a valid CreateVolumeResponse is returned
+    And I call NFS PublishVolume with "single-writer"
+    Then a valid PublishVolumeResponse is returned
+    And a VolumeAttachment for the volume on node "node1"
+    And I call reconcileNFSExports with dry run "false"
+    Then the error contains "none"
+    And the NFS export reconcile reports 0 stale hosts
+    And I delete the VolumeAttachment
+
+    Scenario: Reconcile NFS exports with get NFS exports error
+    Given a VxFlexOS service
+    When I specify CreateVolumeMountRequest "nfs"
+    And I call CreateVolume "volume1"
+    Then a valid CreateVolumeResponse is returned
+    And I call NFS PublishVolume with "single-writer"
+    Then a valid PublishVolumeResponse is returned
+    And I induce error "NFSExportsInstancesError"
+    And I call reconcileNFSExports with dry run "false"
+    Then the error contains "none"
+    And the NFS export reconcile reports 0 stale hosts
     
     Scenario: a Basic NFS controller Publish Idempotent no error
     Given a VxFlexOS service
@@ -587,6 +642,19 @@ Feature: VxFlex OS CSI interface
     When I call CreateVolumeSize nfs "vol-inttest-nfs" "8"
     Then the error contains "Modify filesystem failed"
 
+  Scenario: Create NFS volume with replication enabled, error
+    Given a VxFlexOS service
+    And I enable replication for the NFS volume
+    And I call CreateVolumeSize nfs "vol-inttest-nfs" "10"
+    Then the error contains "replication is not supported for NFS volumes"
+
+  Scenario: CreateRemoteVolume for NFS volume, error
+    Given a VxFlexOS service
+    And I call CreateVolumeSize nfs "vol-inttest-nfs" "10"
+    Then a valid CreateVolumeResponse is returned
+    And I call CreateRemoteVolume
+    Then the error contains "replication is not supported for NFS volumes"
+
   Scenario: Create NFS volume, empty path, error
     Given a VxFlexOS service
     And I enable quota for filesystem
@@ -187,3 +187,66 @@ Scenario: Call CreateVolumeGroupSnapshot idempotent; criteria 3 fails
   And remove a volume from VolumeGroupSnapshotRequest
   And I call CreateVolumeSnapshotGroup
   Then the error contains "contains more snapshots"
+
+@vg
+Scenario: Call CreateVolumeSnapshotGroup with block and NFS volumes
+  Given a VxFlexOS service
+  When I call Probe
+  And I call CreateVolume "vol1"
+  And a valid CreateVolumeResponse is returned
+  And I clear the CreateVolume request
+  And I call CreateVolumeSize nfs "nfsvol1" "8"
+  And a valid CreateVolumeResponse is returned
+  And I call CreateVolumeSize nfs "nfsvol2" "8"
+  And a valid CreateVolumeResponse is returned
+  And I call CreateVolumeSnapshotGroup
+  Then the error contains "none"
+  And a valid CreateVolumeSnapshotGroup response is returned
+  And the VolumeGroupSnapshot has 3 snapshots
+
+@vg
+Scenario: Call CreateVolumeSnapshotGroup with NFS volumes idempotent
+  Given a VxFlexOS service
+  When I call Probe
+  And I call CreateVolumeSize nfs "nfsvol1" "8"
+  And a valid CreateVolumeResponse is returned
+  And I call CreateVolumeSize nfs "nfsvol2" "8"
+  And a valid CreateVolumeResponse is returned
+  And I call CreateVolumeSnapshotGroup
+  And I call CreateVolumeSnapshotGroup
+  Then the error contains "none"
+  And the VolumeGroupSnapshot has 2 snapshots
+
+@vg
+Scenario Outline: Call CreateVolumeSnapshotGroup with block and NFS volumes and errors
+  Given a VxFlexOS service
+  When I call Probe
+  And I call CreateVolume "vol1"
+  And a valid CreateVolumeResponse is returned
+  And I clear the CreateVolume request
+  And I call CreateVolumeSize nfs "nfsvol1" "8"
+  And a valid CreateVolumeResponse is returned
+  And I induce error <error>
+  And I call CreateVolumeSnapshotGroup
+  Then the error contains <errorMsg>
+  And the filesystem "apple-1" does not exist
+
+Examples:
+      | error                     | errorMsg                                          |
+      | "CreateSnapshotError"     | "Failed to create group"                          |
+      | "CreateSnapshotsError"    | "Failed to create group with snapshots [apple-1]" |
+      | "GetFileSystemsByIdError" | "failure checking source filesystem status"       |
+      | "GetGroupSnapshotError"   | "Failed to get snapshot"                          |
+
+@vg
+Scenario Outline: Check creation time skew of volume group snapshot members
+  Given a VxFlexOS service
+  When I call checkCreationTimeSkew with times <times> and max skew <maxSkew>
+  Then the error contains <errorMsg>
+
+Examples:
+      | times         | maxSkew | errorMsg                                        |
+      | "100,101,105" | "10s"   | "none"                                          |
+      | "100,0,105"   | "5s"    | "Creation time of snapshot snap-1 is not known" |
+      | "100,101,115" | "10s"   | "is more than 10s after snapshot"               |
+
@@ -65,6 +65,51 @@ Examples:
  | "csi-d0f055a700000000"  | "30Gi"         | "viki_pool_HDD_20181031" | "does-not-exist"   | "not recgonized"                        |
  | "csi-d0f055a700012345"  | "30Gi"         | "viki_pool_HDD_20181031" | "15dbbf5617523655" | "not published"             |
 
+Scenario: Node publish and unpublish NFS ephemeral volume
+    Given a VxFlexOS service
+    And a capability with voltype "mount" access "single-writer" fstype "none"
+    And get Node Publish Ephemeral Volume Request with name "csi-nfs-ephemeral" size "8Gi" storagepool "viki_pool_HDD_20181031" and systemName "14dbbf5617523654"
+    And I set the ephemeral volume fsType "nfs" and nasName "dummy-nas-server"
+    And I call Probe
+    And I call NodePublishVolume "SDC_GUID"
+    Then the error contains "none"
+    And I call NodeUnpublishVolume "SDC_GUID"
+    Then the error contains "none"
+    And the filesystem "csi-nfs-ephemeral" does not exist
+
+Scenario: Reconcile NFS exports keeps host of NFS ephemeral volume
+    Given a VxFlexOS service
+    And a capability with voltype "mount" access "single-writer" fstype "none"
+    And get Node Publish Ephemeral Volume Request with name "csi-nfs-ephemeral" size "8Gi" storagepool "viki_pool_HDD_20181031" and systemName "14dbbf5617523654"
+    And I set the ephemeral volume fsType "nfs" and nasName "dummy-nas-server"
+    And I call Probe
+    And I call NodePublishVolume "SDC_GUID"
+    Then the error contains "none"
+    And a pod using the ephemeral volume on node "node1"
+    And I call reconcileNFSExports with dry run "false"
+    Then the error contains "none"
+    And the NFS export reconcile reports 0 stale hosts
+    And I delete the pod using the ephemeral volume
+    And I call reconcileNFSExports with dry run "true"
+    Then the error contains "none"
+    And the NFS export reconcile reports 1 stale hosts
+    And I call NodeUnpublishVolume "SDC_GUID"
+    Then the error contains "none"
+    And the filesystem "csi-nfs-ephemeral" does not exist
+
+Scenario: Node unpublish NFS ephemeral volume after the filesystem is gone
+    Given a VxFlexOS service
+    And a capability with voltype "mount" access "single-writer" fstype "none"
+    And get Node Publish Ephemeral Volume Request with name "csi-nfs-ephemeral" size "8Gi" storagepool "viki_pool_HDD_20181031" and systemName "14dbbf5617523654"
+    And I set the ephemeral volume fsType "nfs" and nasName "dummy-nas-server"
+    And I call Probe
+    And I call NodePublishVolume "SDC_GUID"
+    Then the error contains "none"
+    And I delete the ephemeral volume on the array
+    And I call NodeUnpublishVolume "SDC_GUID"
+    Then the error contains "none"
+    And the filesystem "csi-nfs-ephemeral" does not exist
+
 Scenario Outline: Ephemeral Node Unpublish with errors
 	Given a VxFlexOS service
 	And I induce error <error>
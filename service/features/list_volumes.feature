@@ -27,6 +27,7 @@ Feature: VxFlex OS CSI interface
     And I call ListVolumes again with max_entries "3" and starting_token "next"
     Then a valid ListVolumesResponse is returned
     And 3 volumes are listed
+    And the "volume" cache lookups with result "hit" are "1"
 
   Scenario: Test list volumes with an invalid starting token
     Given a VxFlex OS service
@@ -80,6 +80,40 @@ Feature: VxFlex OS CSI interface
     Then the error contains "none"
     
    
+  Scenario: NFS Node Publish with default and StorageClass mount options
+    Given a VxFlexOS service
+    When I specify CreateVolumeMountRequest "nfs"
+    And I call CreateVolume "volume1"
+    Then a valid CreateVolumeResponse is returned
+    And I call NFS PublishVolume with "single-writer"
+    Then a valid PublishVolumeResponse is returned
+    And a capability with voltype "mount" access "single-writer" fstype "nfs"
+    And I set the NFS default mount options <defaults>
+    And I set the mount flags <mountFlags>
+    Then I call NodePublishVolume NFS ""
+    Then the error contains <errormsg>
+
+    Examples:
+      | defaults                | mountFlags                | errormsg                                       |
+      | "vers=3,proto=tcp,hard" | ""                        | "none"                                         |
+      | "vers=3,proto=tcp,hard" | "vers=4.1"                | "NFS version 4.1 is not enabled on NAS server" |
+      | "vers=4.1"              | "nfsvers=3"               | "none"                                         |
+      | "nconnect=4"            | "fsc"                     | "none"                                         |
+      | ""                      | "_netdev,sync,noresvport" | "none"                                         |
+
+  Scenario: NFS Node Publish with mount option not in configured allow-list
+    Given a VxFlexOS service
+    When I specify CreateVolumeMountRequest "nfs"
+    And I call CreateVolume "volume1"
+    Then a valid CreateVolumeResponse is returned
+    And I call NFS PublishVolume with "single-writer"
+    Then a valid PublishVolumeResponse is returned
+    And a capability with voltype "mount" access "single-writer" fstype "nfs"
+    And I set the NFS allowed mount options "vers,proto"
+    And I set the mount flags "nconnect=8"
+    Then I call NodePublishVolume NFS ""
+    Then the error contains "NFS mount options [nconnect=8] are not allowed"
+
    Scenario: a Basic NFS Node Publish filesystem not found error
     Given a VxFlexOS service
     When I specify CreateVolumeMountRequest "nfs"
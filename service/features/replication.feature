@@ -34,6 +34,11 @@ Scenario Outline: Test CreateRemoteVolume
   | "sourcevol" | "BadRemoteSystemIDError"     | "System 15dbbf5617523655 not found" |
   | "sourcevol" | "ProbePrimaryError"          | "PodmonControllerProbeError"        |
   | "sourcevol" | "ProbeSecondaryError"        | "PodmonControllerProbeError"        |
+  | "sourcevol" | "PeerMdmNotJoined"           | "is not connected"                  |
+  | "sourcevol" | "PeerMdmNotPeered"           | "is not peered with remote system"  |
+  | "sourcevol" | "NoRemoteCredentials"        | "no credentials for remote system"  |
+  | "sourcevol" | "BadRemoteStoragePool"       | "remote storage pool no-such-pool"  |
+  | "sourcevol" | "BadRemoteProtectionDomain"  | "protection domain no-such-pd"      |
 
 @replication
 Scenario Outline: Test DeleteLocalVolume
@@ -95,7 +100,7 @@ Scenario Outline: Test CreateStorageProtectionGroup
   | "sourcevol" | "NoProtectionDomainError"                   | "NoProtectionDomainError"                           |
   | "sourcevol" | "ReplicationPairError"                      | "POST ReplicationPair induced error"                |
   | "sourcevol" | "PeerMdmError"                              | "PeerMdmError"                                      |
-  | "sourcevol" | "BadRemoteSystem"                           | "couldn't getSystem (remote)"                       |
+  | "sourcevol" | "BadRemoteSystem"                           | "no credentials for remote system xxx"              |
   | "sourcevol" | "FindVolumeIDError"                         | "can't find volume replicated-sourcevol by name"    |
   | "sourcevol" | "StorageGroupAlreadyExists"                 | "none"                                              |
   | "sourcevol" | "StorageGroupAlreadyExistsUnretriavable"    | "couldn't find replication consistency group"       |
@@ -105,6 +110,42 @@ Scenario Outline: Test CreateStorageProtectionGroup
   | "sourcevol" | "NoRPOSpecified"                            | "no RPO specified"                                  |
   | "sourcevol" | "NoRemoteClusterID"                         | "no remote cluster ID specified"                    |
 
+@replication
+Scenario Outline: Test CreateStorageProtectionGroup rolls back the group
+  Given a VxFlexOS service
+  And I use config "replication-config"
+  When I call CreateVolume "sourcevol"
+  And I call CreateRemoteVolume
+  And I enable the audit log
+  And I induce error <error>
+  And I call CreateStorageProtectionGroup
+  Then the error contains <errormsg>
+  And the number of replication consistency groups on system "14dbbf5617523654" is 0
+  And the audit records are <audit>
+
+  Examples:
+  | error                  | errormsg                                         | audit                                                                    |
+  | "ReplicationPairError" | "POST ReplicationPair induced error"             | "CreateStorageProtectionGroup/DeleteReplicationConsistencyGroup:success" |
+  | "FindVolumeIDError"    | "can't find volume replicated-sourcevol by name" | "CreateStorageProtectionGroup/DeleteReplicationConsistencyGroup:success" |
+  | "PeerMdmNotJoined"     | "is not connected"                               | "none"                                                                   |
+
+@replication
+Scenario: Test CreateStorageProtectionGroup keeps an existing group without pairs
+  Given a VxFlexOS service
+  And I use config "replication-config"
+  When I call CreateVolume "sourcevol"
+  And I call CreateRemoteVolume
+  And I call CreateStorageProtectionGroup with "rcg-1", "cluster-k211", "60"
+  And the replication pairs on system "14dbbf5617523654" are deleted
+  And I call CreateVolume "othervol"
+  And I call CreateRemoteVolume
+  And I enable the audit log
+  And I induce error "ReplicationPairError"
+  And I call CreateStorageProtectionGroup with "rcg-1", "cluster-k211", "60"
+  Then the error contains "POST ReplicationPair induced error"
+  And the number of replication consistency groups on system "14dbbf5617523654" is 1
+  And the audit records are "none"
+
 @replication
 Scenario Outline: Test CreateStorageProtectionGroup with arguments
   Given a VxFlexOS service
@@ -133,12 +174,24 @@ Scenario Outline: Test multiple CreateStorageProtectionGroup calls
   And I call CreateRemoteVolume
   And I call CreateStorageProtectionGroup with <group name>, <remote cluster id>, <rpo2>
   Then the error contains <errormsg>
+  And the same storage protection group is returned
 
   Examples:
   | name1     | name2     | group name | remote cluster id | rpo  | rpo2   | errormsg |
   | "1srcVol" | "2srcVol" | ""         | "cluster-k211"    | "60" | "60"   | "none"   |
   | "1srcVol" | "2srcVol" | ""         | "cluster-k211"    | "60" | "120"  | "none"   |
 
+@replication
+Scenario: Test CreateStorageProtectionGroup for a volume that is already replicated
+  Given a VxFlexOS service
+  And I use config "replication-config"
+  When I call CreateVolume "sourcevol"
+  And I call CreateRemoteVolume
+  And I call CreateStorageProtectionGroup with "", "cluster-k211", "60"
+  And I call CreateStorageProtectionGroup with "", "cluster-k211", "120"
+  Then the error contains "none"
+  And the same storage protection group is returned
+
 @replication
 Scenario Outline: Test GetStorageProtectionGroupStatus 
   Given a VxFlexOS service
@@ -244,6 +297,339 @@ Scenario Outline: Test ExecuteAction
   | "sourcevol" | "none"                    | "not match with supported actions"  | "Unknown"           | "Normal"    | "Consistent"  |
   | "sourcevol" | "none"                    | "none"                              | "Sync"              | "Normal"    | "Consistent"  |
   | "sourcevol" | "ExecuteActionError"      | "could not execute RCG action"      | "Sync"              | "Normal"    | "Consistent"  |
+  | "sourcevol" | "none"                    | "none"                              | "TestFailover"      | "Normal"    | "Consistent"  |
+  | "sourcevol" | "ExecuteActionError"      | "could not execute RCG action"      | "TestFailover"      | "Normal"    | "Consistent"  |
+  | "sourcevol" | "none"                    | "none"                              | "TestFailoverStop"  | "Normal"    | "Consistent"  |
+
+@replication
+Scenario Outline: Test ExecuteAction test failover
+  Given a VxFlexOS service
+  And I use config "replication-config"
+  When I call CreateVolume "sourcevol"
+  And I call CreateRemoteVolume
+  And I call CreateStorageProtectionGroup
+  And I call GetStorageProtectionGroupStatus with state <state> and mode "Consistent"
+  And I induce error <error>
+  And I call ExecuteAction <action>
+  Then the error contains <errormsg>
+  And the replication group actions are <actions>
+  And I call DescribeStorageProtectionGroup
+  And the protection group is "SYNCHRONIZED" with test failover <testFailover>
+
+  Examples:
+  | state          | error                | action             | errormsg                       | actions            | testFailover |
+  | "Normal"       | "none"               | "TestFailover"     | "none"                         | "testFailover"     | "true"       |
+  | "TestFailover" | "none"               | "TestFailover"     | "none"                         | "none"             | "true"       |
+  | "TestFailover" | "none"               | "TestFailoverStop" | "none"                         | "testFailoverStop" | "false"      |
+  | "Normal"       | "none"               | "TestFailoverStop" | "none"                         | "none"             | "false"      |
+  | "Normal"       | "ExecuteActionError" | "TestFailover"     | "could not execute RCG action" | "none"             | "false"      |
+
+@replication
+Scenario: Test traced ExecuteAction test failover carries the trace context to the gateway
+  Given a VxFlexOS service
+  And I use config "replication-config"
+  When I call CreateVolume "sourcevol"
+  And I call CreateRemoteVolume
+  And I call CreateStorageProtectionGroup
+  And I call GetStorageProtectionGroupStatus with state "Normal" and mode "Consistent"
+  And I call ExecuteAction "TestFailover" with a traced request
+  Then the error contains "none"
+  And the replication group action gateway request carries the trace context
+
+@replication
+Scenario Outline: Test AddVolumeToProtectionGroup
+  Given a VxFlexOS service
+  And I use config "replication-config"
+  When I call CreateVolume "sourcevol"
+  And I induce error <error>
+  And I call AddVolumeToProtectionGroup
+  Then the error contains <errormsg>
+  And volume "replicated-sourcevol" on system "15dbbf5617523655" exists <remote>
+
+  Examples:
+  | error                  | errormsg                                   | remote  |
+  | "none"                 | "none"                                     | "true"  |
+  | "ReplicationPairError" | "POST ReplicationPair induced error"       | "false" |
+  | "BadRemoteStoragePool" | "no-such-pool"                             | "false" |
+  | "NoVolIDError"         | "failed to provide system ID or volume ID" | "false" |
+
+@replication
+Scenario: Test AddVolumeToProtectionGroup returns the remote volume and group
+  Given a VxFlexOS service
+  And I use config "replication-config"
+  When I call CreateVolume "sourcevol"
+  And I call AddVolumeToProtectionGroup
+  Then the error contains "none"
+  And the added volume is replicated to "15dbbf5617523655-7265706c6963617465642d736f75726365766f6c"
+  And I call DescribeStorageProtectionGroup
+  And the protection group is "SYNCHRONIZED" with test failover "false"
+
+@replication
+Scenario: Test AddVolumeToProtectionGroup keeps an existing remote volume when it fails
+  Given a VxFlexOS service
+  And I use config "replication-config"
+  When I call CreateVolume "sourcevol"
+  And I call CreateRemoteVolume
+  And I induce error "ReplicationPairError"
+  And I call AddVolumeToProtectionGroup
+  Then the error contains "POST ReplicationPair induced error"
+  And volume "replicated-sourcevol" on system "15dbbf5617523655" exists "true"
+
+@replication
+Scenario Outline: Test ModifyStorageProtectionGroup
+  Given a VxFlexOS service
+  And I use config "replication-config"
+  When I call CreateVolume "sourcevol"
+  And I call CreateRemoteVolume
+  And I call CreateStorageProtectionGroup
+  And I call GetStorageProtectionGroupStatus with state "Normal" and mode "Consistent"
+  And I induce error <error>
+  And I call ModifyStorageProtectionGroup with RPO <rpo>
+  Then the error contains <errormsg>
+  And the replication group actions are <actions>
+  And I call DescribeStorageProtectionGroup
+  And the described status attribute "rpoSeconds" is <after>
+
+  Examples:
+  | rpo | error                | errormsg                       | actions                                                                               | after |
+  | 120 | "none"               | "none"                         | "modifyReplicationConsistencyGroupRpo(120),modifyReplicationConsistencyGroupRpo(120)" | "120" |
+  | 60  | "none"               | "none"                         | "none"                                                                                | "60"  |
+  | 0   | "none"               | "RPO must be a positive"       | "none"                                                                                | "60"  |
+  | 120 | "ExecuteActionError" | "could not execute RCG action" | "none"                                                                                | "60"  |
+
+@replication
+Scenario Outline: Test ExecuteAction returns the protection group status attributes
+  Given a VxFlexOS service
+  And I use config "replication-config"
+  When I call CreateVolume "sourcevol"
+  And I call CreateRemoteVolume
+  And I call CreateStorageProtectionGroup
+  And I call GetStorageProtectionGroupStatus with state "Normal" and mode <mode>
+  And I call ExecuteAction "Sync"
+  Then the error contains "none"
+  And the ExecuteAction status attribute "rpoSeconds" is "60"
+  And the ExecuteAction status attribute "pairCount" is "1"
+  And the ExecuteAction status attribute "pairsInInitialCopy" is <initialCopy>
+  And the ExecuteAction status attribute "rpoCompliant" is <compliant>
+  And the replication metric "powerflex_replication_pairs" is "1"
+  And the replication metric "powerflex_replication_pairs_in_initial_copy" is <initialCopy>
+  And I call DescribeStorageProtectionGroup
+  And the described status attribute "rpoSeconds" is "60"
+  And the described status attribute "rpoCompliant" is <compliant>
+
+  Examples:
+  | mode                  | initialCopy | compliant |
+  | "Consistent"          | "0"         | "true"    |
+  | "PartiallyConsistent" | "0"         | ""        |
+
+@replication
+Scenario: Test protection group status of pairs in initial copy
+  Given a VxFlexOS service
+  And I use config "replication-config"
+  When I call CreateVolume "sourcevol"
+  And I call CreateRemoteVolume
+  And I call CreateStorageProtectionGroup
+  And the replication pairs are in initial copy
+  And I call GetStorageProtectionGroupStatus with state "Normal" and mode "Consistent"
+  Then the error contains "none"
+  And the replication metric "powerflex_replication_pairs_in_initial_copy" is "1"
+  And the replication metric "powerflex_replication_rpo_compliant" is not published
+
+@replication
+Scenario Outline: Test replication auto reprotect after unplanned failover
+  Given a VxFlexOS service
+  And I use config "replication-config"
+  When I call CreateVolume "sourcevol"
+  And I call CreateRemoteVolume
+  And I call CreateStorageProtectionGroup
+  And I call GetStorageProtectionGroupStatus with state <state> and mode "Consistent"
+  And I enable replication auto reprotect with grace period "5m"
+  And I enable the audit log
+  And I induce error <error>
+  And I call autoReprotectReplicationGroups after "0s"
+  Then the auto reprotect decision is <first>
+  And I call autoReprotectReplicationGroups after "10m"
+  And the auto reprotect decision is <second>
+  And the replication group actions are <actions>
+  And an event with reason <reason> is emitted
+  And the audit records are <audit>
+
+  Examples:
+  | state      | error                | first             | second            | actions           | reason                                    | audit                                                        |
+  | "Normal"   | "none"               | "none"            | "none"            | "none"            | "none"                                    | "none"                                                       |
+  | "Paused"   | "none"               | "none"            | "none"            | "none"            | "none"                                    | "none"                                                       |
+  | "Failover" | "none"               | "Wait"            | "Reprotected"     | "reverse,restore" | "ReplicationAutoReprotectPending"         | "AutoReprotect/Reverse:success,AutoReprotect/Resume:success" |
+  | "Failover" | "none"               | "Wait"            | "Reprotected"     | "reverse,restore" | "ReplicationAutoReprotected"              | "AutoReprotect/Reverse:success,AutoReprotect/Resume:success" |
+  | "Failover" | "PeerMdmNotJoined"   | "PeerUnreachable" | "PeerUnreachable" | "none"            | "ReplicationAutoReprotectPeerUnreachable" | "none"                                                       |
+  | "Failover" | "ExecuteActionError" | "Wait"            | "Failed"          | "none"            | "ReplicationAutoReprotectFailed"          | "AutoReprotect/Reverse:failure"                              |
+
+@replication
+Scenario Outline: Test ExecuteMultiGroupAction
+  Given a VxFlexOS service
+  And I use config "replication-config"
+  When I call CreateVolume "sourcevol"
+  And I call CreateRemoteVolume
+  And I call CreateStorageProtectionGroup
+  And a second storage protection group
+  And I call GetStorageProtectionGroupStatus with state "Normal" and mode <mode>
+  And I induce error <error>
+  And I call ExecuteMultiGroupAction <action>
+  Then the error contains <errormsg>
+  And the replication group actions are <actions>
+
+  Examples:
+  | action              | mode                  | error                      | errormsg                                       | actions                                           |
+  | "UnplannedFailover" | "Consistent"          | "none"                     | "none"                                         | "pause,pause,failover,failover"                   |
+  | "FailoverRemote"    | "Consistent"          | "none"                     | "none"                                         | "pause,pause,switchover,switchover,resume,resume" |
+  | "Sync"              | "Consistent"          | "none"                     | "is not supported on multiple"                 | "none"                                            |
+  | "UnplannedFailover" | "PartiallyConsistent" | "none"                     | "is not synchronized"                          | "none"                                            |
+  | "UnplannedFailover" | "Consistent"          | "GetRCGByIdError"          | "No replication consistency groups"            | "none"                                            |
+  | "UnplannedFailover" | "Consistent"          | "SecondGroupPauseError"    | "pausing group second-rcg failed"              | "pause,resume"                                    |
+  | "UnplannedFailover" | "Consistent"          | "SecondGroupFailoverError" | "all groups were rolled back"                  | "pause,pause,failover,restore,resume"             |
+  | "FailoverRemote"    | "Consistent"          | "SecondGroupFailoverError" | "all groups were rolled back"                  | "pause,pause,switchover,switchover,resume,resume" |
+  | "FailoverRemote"    | "Consistent"          | "SecondGroupResumeError"   | "resuming groups failed, they are left paused" | "pause,pause,switchover,switchover,resume"        |
+
+@replication
+Scenario: Test ExecuteMultiGroupAction and ExecuteAction are audited with the action
+  Given a VxFlexOS service
+  And I use config "replication-config"
+  When I call CreateVolume "sourcevol"
+  And I call CreateRemoteVolume
+  And I call CreateStorageProtectionGroup
+  And a second storage protection group
+  And I call GetStorageProtectionGroupStatus with state "Normal" and mode "Consistent"
+  And I enable the audit log
+  And I call ExecuteMultiGroupAction "UnplannedFailover" through the interceptors
+  Then the error contains "none"
+  And the last audit record has objects "action=UNPLANNED_FAILOVER_LOCAL"
+  And I call ExecuteAction "Sync" through the interceptors
+  And the error contains "none"
+  And the last audit record has objects "action=SYNC"
+
+@replication
+Scenario Outline: Test replication state transitions emit events and metrics
+  Given a VxFlexOS service
+  And I use config "replication-config"
+  When I call CreateVolume "sourcevol"
+  And I call CreateRemoteVolume
+  And I call CreateStorageProtectionGroup
+  And a PersistentVolume for the replicated volume with claim "app/pvc-replicated"
+  And I call GetStorageProtectionGroupStatus with state "Normal" and mode "Consistent"
+  Then 0 events with reason "ReplicationStateChanged" are emitted on PersistentVolume "pv-replicated"
+  And I call GetStorageProtectionGroupStatus with state <state> and mode <mode>
+  And <events> events with reason "ReplicationStateChanged" are emitted on PersistentVolume "pv-replicated"
+  And <events> events with reason "ReplicationStateChanged" are emitted on PersistentVolumeClaim "pvc-replicated"
+  And the replication transitions from "SYNCHRONIZED" to <to> are <count>
+
+  Examples:
+  | state      | mode                  | to                 | events | count |
+  | "Normal"   | "Consistent"          | "SYNCHRONIZED"     | 0      | "0"   |
+  | "Normal"   | "PartiallyConsistent" | "SYNC_IN_PROGRESS" | 1      | "1"   |
+  | "Paused"   | "Consistent"          | "SUSPENDED"        | 1      | "1"   |
+  | "Failover" | "Consistent"          | "FAILEDOVER"       | 1      | "1"   |
+
+@replication
+Scenario Outline: Test replication lag and RPO compliance
+  Given a VxFlexOS service
+  When I call getReplicationGroupStatus with mode <mode> last synced <lastSync> seconds ago and RPO <rpo>
+  Then the replication group status attribute "secondsSinceSeenConsistent" is <since>
+  And the replication group status attribute "rpoCompliant" is <compliant>
+
+  Examples:
+  | mode                  | lastSync | rpo | since | compliant |
+  | "Consistent"          | 30       | 60  | "0"   | "true"    |
+  | "PartiallyConsistent" | 30       | 60  | "30"  | "true"    |
+  | "PartiallyConsistent" | 90       | 60  | "90"  | "false"   |
+  | "PartiallyConsistent" | -1       | 60  | ""    | ""        |
+
+@replication
+Scenario Outline: Test CreateVolumesFromProtectionGroupSnapshot
+  Given a VxFlexOS service
+  And I use config "replication-config"
+  When I call CreateVolume "sourcevol"
+  And I call CreateRemoteVolume
+  And I call CreateStorageProtectionGroup
+  And I call GetStorageProtectionGroupStatus with state "Normal" and mode "Consistent"
+  And I call ExecuteAction "CreateSnapshot"
+  And I induce error <error>
+  And I call CreateVolumesFromProtectionGroupSnapshot with prefix <prefix>
+  Then the error contains <errormsg>
+  And <count> volumes are restored on system "15dbbf5617523655"
+
+  Examples:
+  | prefix  | error                 | errormsg                         | count |
+  | "drill" | "none"                | "none"                           | 1     |
+  | ""      | "none"                | "volume name prefix is required" | 0     |
+  | "drill" | "InvalidSnapshotID"   | "invalid snapshot ID"            | 0     |
+  | "drill" | "CreateSnapshotError" | "Failed to create snapshot"      | 0     |
+
+@replication
+Scenario Outline: Test RemoveVolumeFromProtectionGroup
+  Given a VxFlexOS service
+  And I use config "replication-config"
+  When I call CreateVolume "sourcevol"
+  And I call CreateRemoteVolume
+  And I call CreateStorageProtectionGroup
+  And I induce error <error>
+  And I call RemoveVolumeFromProtectionGroup with deleteRemote <deleteRemote>
+  Then the error contains <errormsg>
+  And the volume is not replicated
+  And the remote volume exists <remoteExists>
+  And the storage protection group exists "false"
+
+  Examples:
+  | deleteRemote | error  | errormsg | remoteExists |
+  | "false"      | "none" | "none"   | "true"       |
+  | "true"       | "none" | "none"   | "false"      |
+
+@replication
+Scenario: Test RemoveVolumeFromProtectionGroup keeps a group that still has pairs
+  Given a VxFlexOS service
+  And I use config "replication-config"
+  When I call CreateVolume "1srcVol"
+  And I call CreateRemoteVolume
+  And I call CreateStorageProtectionGroup with "", "cluster-k211", "60"
+  And I call CreateVolume "2srcVol"
+  And I call CreateRemoteVolume
+  And I call CreateStorageProtectionGroup with "", "cluster-k211", "60"
+  And I call RemoveVolumeFromProtectionGroup with deleteRemote "true"
+  Then the error contains "none"
+  And the volume is not replicated
+  And the storage protection group exists "true"
+
+@replication
+Scenario: Test RemoveVolumeFromProtectionGroup finishes on a retry after the remote volume could not be deleted
+  Given a VxFlexOS service
+  And I use config "replication-config"
+  When I call CreateVolume "sourcevol"
+  And I call CreateRemoteVolume
+  And I call CreateStorageProtectionGroup
+  And I induce error "RemoveVolumeError"
+  And I call RemoveVolumeFromProtectionGroup with deleteRemote "true"
+  Then the error contains "error deleting remote volume"
+  And the storage protection group exists "true"
+  When I clear the induced errors
+  And I call RemoveVolumeFromProtectionGroup with deleteRemote "true"
+  Then the error contains "none"
+  And the volume is not replicated
+  And the remote volume exists "false"
+  And the storage protection group exists "false"
+
+@replication
+Scenario Outline: Test RemoveVolumeFromProtectionGroup errors
+  Given a VxFlexOS service
+  And I use config "replication-config"
+  When I call CreateVolume "sourcevol"
+  And I call CreateRemoteVolume
+  And I call CreateStorageProtectionGroup
+  And I induce error <error>
+  And I call RemoveVolumeFromProtectionGroup with deleteRemote "false"
+  Then the error contains <errormsg>
+
+  Examples:
+  | error                     | errormsg                            |
+  | "GetReplicationPairError" | "GET ReplicationPair induced error" |
+  | "NoDeleteReplicationPair" | "error removing replication pair"   |
 
 @replication
 Scenario Outline: Test ControllerExpandVolume on replication pair
@@ -38,11 +38,280 @@ Feature: VxFlex OS CSI interface
       | "logConfig2.yaml"     | "trace" |
       | "logConfigWrong.yaml" | "debug" |
 
+  Scenario: Dynamic driver config params change
+    Given a VxFlexOS service
+    When I call BeforeServe
+    And I call DynamicLogChange "driverParams.yaml"
+    Then a valid DynamicLogChange occurs "driverParams.yaml" "info"
+    And the driver setting "X_CSI_MAX_VOLUMES_PER_NODE" is "10"
+    And the driver setting "X_CSI_QUOTA_ENABLED" is "true"
+    And the driver setting "X_CSI_POWERFLEX_EXTERNAL_ACCESS" is "10.0.0.0/255.255.255.0"
+    And the driver setting "X_CSI_RENAME_SDC_PREFIX" is "reloaded"
+    And the driver setting "X_CSI_APPROVE_SDC_ENABLED" is "true"
+
+  Scenario Outline: Reload driver settings from the driver config params
+    Given a VxFlexOS service
+    When I reload the driver config params with <key> set to <value>
+    Then the driver setting <key> is <setting>
+    Examples:
+      | key                                     | value         | setting                  |
+      | "X_CSI_MAX_VOLUMES_PER_NODE"            | "20"          | "20"                     |
+      | "X_CSI_MAX_VOLUMES_PER_NODE"            | "-1"          | "0"                      |
+      | "X_CSI_MAX_VOLUMES_PER_NODE"            | "many"        | "0"                      |
+      | "X_CSI_QUOTA_ENABLED"                   | "true"        | "true"                   |
+      | "X_CSI_QUOTA_ENABLED"                   | "maybe"       | "false"                  |
+      | "X_CSI_POWERFLEX_EXTERNAL_ACCESS"       | "10.0.0.1/24" | "10.0.0.0/255.255.255.0" |
+      | "X_CSI_POWERFLEX_EXTERNAL_ACCESS"       | "10.0.0.300"  | ""                       |
+      | "X_CSI_VXFLEXOS_ENABLESNAPSHOTCGDELETE" | "false"       | "false"                  |
+      | "X_CSI_RENAME_SDC_ENABLED"              | "true"        | "true"                   |
+      | "X_CSI_RENAME_SDC_PREFIX"               | " pfx "       | "pfx"                    |
+      | "X_CSI_APPROVE_SDC_ENABLED"             | "true"        | "true"                   |
+
+  Scenario Outline: Reloaded driver settings are read from the driver config params file only
+    Given a VxFlexOS service
+    When I reload the driver config params file with <key> set to <value> while the environment sets it to <env>
+    Then the driver setting <key> is <setting>
+    Examples:
+      | key                          | value  | env     | setting |
+      | "X_CSI_QUOTA_ENABLED"        | "true" | "false" | "true"  |
+      | "X_CSI_MAX_VOLUMES_PER_NODE" | "5"    | "30"    | "5"     |
+
+  Scenario Outline: Sensitive values are redacted from the logs
+    Given a VxFlexOS service
+    And the log redaction keys are <keys>
+    When I log <message>
+    Then the log contains <logged>
+    And the log does not contain <hidden>
+    Examples:
+      | keys        | message                                                 | logged                      | hidden       |
+      | ""          | "login password=abc123 user=admin"                      | "password=********"         | "abc123"     |
+      | ""          | "VolumeContext=map[fsType:ext4 apiToken:t0k3n]"         | "fsType:ext4"               | "t0k3n"      |
+      | ""          | "[csi.storage.k8s.io/node-stage-secret-name]=mysecret"  | "secret-name]=********"     | "mysecret"   |
+      | "sdc.*guid" | "sdcGUID: 1234-abcd token=t0k3n"                        | "token=********"            | "1234-abcd"  |
+      | "sdc.*guid" | "sdcGUID: 1234-abcd token=t0k3n"                        | "sdcGUID: ********"         | "t0k3n"      |
+      | "[invalid"  | "token=t0k3n"                                           | "token=********"            | "t0k3n"      |
+
+  Scenario: Sensitive fields are redacted from the logs
+    Given a VxFlexOS service
+    When I log "connecting to gateway" with field "password" of "abc123"
+    Then the log contains "connecting to gateway"
+    And the log does not contain "abc123"
+
+  Scenario: Secrets and sensitive parameters are redacted from the logged requests
+    Given a VxFlexOS service
+    When I log a CreateVolume request with secret "s3cr3t" and parameter "apiKey" of "k3y"
+    Then the log contains "viki_pool_HDD_20181031"
+    And the log does not contain "s3cr3t"
+    And the log does not contain "k3y"
+
   Scenario: Dynamic array config change
     Given a VxFlexOS service
     When I call DynamicArrayChange
     Then a valid DynamicArrayChange occurs
 
+  Scenario Outline: Incremental array config reload
+    Given a VxFlexOS service
+    And I call Probe
+    When I reload the array config with <change>
+    Then the array <array> <result>
+    And the array config has <count> arrays with default <default>
+    Examples:
+      | change      | array                            | result               | count | default            |
+      | "none"      | "14dbbf5617523654"               | keeps its connection | 2     | "14dbbf5617523654" |
+      | "password"  | "14dbbf5617523654"               | is reconnected       | 2     | "14dbbf5617523654" |
+      | "password"  | "15dbbf5617523655"               | keeps its connection | 2     | "14dbbf5617523654" |
+      | "endpoint"  | "15dbbf5617523655"               | is reconnected       | 2     | "14dbbf5617523654" |
+      | "endpoints" | "14dbbf5617523654"               | is reconnected       | 2     | "14dbbf5617523654" |
+      | "removed"   | "15dbbf5617523655"               | is disconnected      | 1     | "14dbbf5617523654" |
+      | "removed"   | "15dbbf5617523655-previous-name" | is disconnected      | 1     | "14dbbf5617523654" |
+      | "added"     | "1235e15806d1ec0f"               | is added             | 3     | "14dbbf5617523654" |
+      | "default"   | "15dbbf5617523655"               | keeps its connection | 2     | "15dbbf5617523655" |
+      | "invalid"   | "14dbbf5617523654"               | keeps its connection | 2     | "14dbbf5617523654" |
+
+  Scenario: Array config reload keeps the connection of an array that cannot log in with its new config
+    Given a VxFlexOS service
+    And I call Probe
+    And the Controller has no connection
+    When I reload the array config with "password"
+    Then the array "14dbbf5617523654" keeps its connection
+    And the array config has 2 arrays with default "14dbbf5617523654"
+
+  Scenario Outline: Connect to a gateway with a custom CA and a client certificate
+    Given a VxFlexOS service
+    And a gateway that requires a TLS client certificate
+    When I connect to the gateway with TLS settings <settings>
+    Then the error contains <errormsg>
+    Examples:
+      | settings                       | errormsg                                |
+      | "all"                          | "none"                                  |
+      | "skipped validation"           | "none"                                  |
+      | "no client certificate"        | "unable to login to VxFlexOS Gateway"   |
+      | "no server name"               | "unable to login to VxFlexOS Gateway"   |
+      | "untrusted client certificate" | "unable to login to VxFlexOS Gateway"   |
+      | "untrusted CA"                 | "unable to login to VxFlexOS Gateway"   |
+      | "missing CA file"              | "unable to read caCertificate"          |
+
+  Scenario: Only the client of the gateway proxy can send requests through it
+    Given a VxFlexOS service
+    And a gateway that requires a TLS client certificate
+    When I connect to the gateway with TLS settings "all"
+    Then the error contains "none"
+    And the gateway proxy rejects the requests without its secret
+
+  Scenario Outline: Gateway client certificates are reloaded when their files change
+    Given a VxFlexOS service
+    And a gateway that requires a TLS client certificate
+    And I connect to the gateway with TLS settings "all"
+    When the client certificate files are replaced with <replacement>
+    And I log in to the gateway again
+    Then the error contains <errormsg>
+    Examples:
+      | replacement               | errormsg                              |
+      | an untrusted certificate  | "unable to login to VxFlexOS Gateway" |
+      | garbage                   | "none"                                |
+
+  Scenario Outline: Gateway TLS settings are validated when the array config loads
+    Given a VxFlexOS service
+    And a gateway that requires a TLS client certificate
+    When I load an array config with TLS settings <settings>
+    Then the error contains <errormsg>
+    Examples:
+      | settings                    | errormsg                                                   |
+      | "all"                       | "none"                                                     |
+      | "http endpoint"             | "TLS settings require an https endpoint"                   |
+      | "missing CA file"           | "unable to read caCertificate"                             |
+      | "certificate without key"   | "clientCertificate and clientKey must be set together"     |
+      | "CA and skipped validation" | "caCertificate cannot be used when certificate validation" |
+      | "key as CA"                 | "no PEM certificate found in caCertificate"                |
+
+  Scenario Outline: Array credentials are read from files or a secret store
+    Given a VxFlexOS service
+    And credential files with password "Secret123"
+    When I load an array config with credentials from <source>
+    Then the error contains <errormsg>
+    And the loaded array has username <username> and password <password>
+    Examples:
+      | source                      | errormsg                                              | username | password      |
+      | "none"                      | "none"                                                | "admin"  | "Password123" |
+      | "files"                     | "none"                                                | "admin"  | "Secret123"   |
+      | "password file"             | "none"                                                | "admin"  | "Secret123"   |
+      | "vault"                     | "none"                                                | "admin"  | "Secret123"   |
+      | "vault kv1"                 | "none"                                                | "admin"  | "Secret123"   |
+      | "missing file"              | "unable to read passwordFile"                         | "none"   | "none"        |
+      | "password and passwordFile" | "password and passwordFile cannot both be set"        | "none"   | "none"        |
+      | "vault and username"        | "vault cannot be used with username"                  | "none"   | "none"        |
+      | "vault without path"        | "vault requires address, path and tokenFile"          | "none"   | "none"        |
+      | "vault with bad token"      | "403 Forbidden"                                       | "none"   | "none"        |
+      | "vault with missing key"    | "secret secret/data/powerflex from vault has no user" | "none"   | "none"        |
+
+  Scenario Outline: Rotated credentials are used without a restart
+    Given a VxFlexOS service
+    And I call Probe
+    And credential files with password "Password123"
+    And the array "14dbbf5617523654" reads its credentials from <source>
+    And the password file is rotated to "Rotated456"
+    And the gateway requires the password <required>
+    When I refresh the credentials
+    Then the array "14dbbf5617523654" password is <password>
+    And the client of array "14dbbf5617523654" is logged in with password <password>
+    And the array "14dbbf5617523654" read before the refresh has password "Password123"
+    And the client of array "14dbbf5617523654" before the refresh is logged in with password "Password123"
+    Examples:
+      | source          | required      | password      |
+      | "password file" | "none"        | "Rotated456"  |
+      | "vault"         | "Rotated456"  | "Rotated456"  |
+      | "password file" | "Password123" | "Password123" |
+
+  Scenario: Credentials are read again when the gateway rejects a request after the session expired
+    Given a VxFlexOS service
+    And I call Probe
+    And credential files with password "Password123"
+    And the array "14dbbf5617523654" reads its credentials from "password file"
+    And the password file is rotated to "Rotated456"
+    And the gateway requires the password "Rotated456"
+    And the gateway session expires
+    When I call CreateVolume "volume1"
+    Then the error contains "Error Authenticating"
+    And the array "14dbbf5617523654" password is "Rotated456"
+    And the client of array "14dbbf5617523654" is logged in with password "Rotated456"
+    When I call CreateVolume "volume2"
+    Then a valid CreateVolumeResponse is returned
+
+  Scenario Outline: Credentials are read again when the gateway rejects them
+    Given a VxFlexOS service
+    And I call Probe
+    And credential files with password "Password123"
+    And the array "14dbbf5617523654" reads its credentials from "password file"
+    And the password file is rotated to <rotated>
+    And the gateway requires the password "Rotated456"
+    When I log in to array "14dbbf5617523654" again
+    Then the error contains <errormsg>
+    And the array "14dbbf5617523654" password is <password>
+    Examples:
+      | rotated       | errormsg                              | password      |
+      | "Rotated456"  | "none"                                | "Rotated456"  |
+      | "Password123" | "unable to login to VxFlexOS Gateway" | "Password123" |
+      | "Wrong789"    | "unable to login to VxFlexOS Gateway" | "Password123" |
+
+  Scenario Outline: Probe logs in to the first healthy gateway of an array
+    Given a VxFlexOS service
+    And the array "14dbbf5617523654" has gateways "primary,secondary"
+    And the gateway "primary" is <primary>
+    And the gateway "secondary" is <secondary>
+    When I call Probe
+    Then the array probe success is <success>
+    And the array "14dbbf5617523654" uses gateway <gateway>
+    Examples:
+      | primary     | secondary   | success | gateway     |
+      | up          | up          | "1"     | "primary"   |
+      | down        | up          | "1"     | "secondary" |
+      | unavailable | up          | "1"     | "secondary" |
+      | failing     | up          | "0"     | "primary"   |
+      | down        | unavailable | "0"     | "primary"   |
+
+  Scenario Outline: Requests fail over to the next gateway and return to the primary when it recovers
+    Given a VxFlexOS service
+    And the array "14dbbf5617523654" has gateways "primary,secondary"
+    And I call Probe
+    And the gateway "primary" is <failure>
+    When I call the gateway of array "14dbbf5617523654"
+    Then the error contains "none"
+    And the array "14dbbf5617523654" uses gateway "secondary"
+    When the gateway "primary" is <later>
+    And I check the health of the gateways of array "14dbbf5617523654"
+    Then the array "14dbbf5617523654" uses gateway <gateway>
+    Examples:
+      | failure     | later       | gateway     |
+      | down        | up          | "primary"   |
+      | unavailable | up          | "primary"   |
+      | down        | down        | "secondary" |
+      | unavailable | unavailable | "secondary" |
+
+  Scenario Outline: Requests that may change the system fail over only when the gateway could not be reached
+    Given a VxFlexOS service
+    And the array "14dbbf5617523654" has gateways "primary,secondary"
+    And I call Probe
+    And the gateway "primary" is <failure>
+    When I send a POST request to the gateway of array "14dbbf5617523654"
+    Then the error contains <errormsg>
+    And the array "14dbbf5617523654" uses gateway <gateway>
+    Examples:
+      | failure     | errormsg                  | gateway     |
+      | stopped     | "none"                    | "secondary" |
+      | down        | "502 Bad Gateway"         | "primary"   |
+      | unavailable | "503 Service Unavailable" | "primary"   |
+
+  Scenario Outline: Gateway endpoints are validated when the array config loads
+    Given a VxFlexOS service
+    When I load an array config with endpoints <endpoints>
+    Then the error contains <errormsg>
+    And the loaded array has endpoint <endpoint>
+    Examples:
+      | endpoints                                         | errormsg                                 | endpoint                  |
+      | "https://gw1.example.com"                         | "none"                                   | "https://gw1.example.com" |
+      | "https://gw1.example.com,https://gw2.example.com" | "none"                                   | "https://gw1.example.com" |
+      | "https://gw1.example.com,gw2"                     | "invalid value for Endpoints at index 0" | "none"                    |
+
   Scenario Outline: multi array getSystemIDFromParameters good and with errors
     Given setup Get SystemID to fail
     Given a VxFlexOS service
@@ -189,6 +458,124 @@ Feature: VxFlex OS CSI interface
     And I call CreateVolume "volume1"
     Then the error contains "No system ID is found in parameters or as default"
 
+  Scenario Outline: Gateway calls and probes are counted in the metrics
+    Given a VxFlexOS service
+    When I call Probe
+    And I induce error <error>
+    And I call CreateVolume "volume1"
+    Then the gateway calls of "CreateVolume" are <calls> with <errors> errors
+    And the array probe success is "1"
+    And the metrics endpoint on port "19090" contains <metric>
+
+    Examples:
+      | error               | calls | errors | metric                             |
+      | "none"              | "1"   | "0"    | "powerflex_gateway_requests_total" |
+      | "CreateVolumeError" | "1"   | "1"    | "powerflex_gateway_errors_total"   |
+
+  Scenario: NFS export gateway calls are counted in the metrics
+    Given a VxFlexOS service
+    When I call Probe
+    And I specify CreateVolumeMountRequest "nfs"
+    And I call CreateVolume "volume1"
+    Then a valid CreateVolumeResponse is returned
+    And I call NFS PublishVolume with "single-writer"
+    Then a valid PublishVolumeResponse is returned
+    And the gateway calls of "CreateFileSystem" are "1" with "0" errors
+    And the gateway calls of "CreateNFSExport" are "1" with "0" errors
+
+  Scenario Outline: RPCs are counted in the metrics by status code
+    Given a VxFlexOS service
+    When I call the RPC metrics interceptor with code <code>
+    And I call the RPC metrics interceptor with code <code>
+    Then the RPC requests with code <label> are "2"
+    And the metrics endpoint on port "19090" contains "powerflex_rpc_duration_seconds_count"
+
+    Examples:
+      | code        | label      |
+      | "OK"        | "OK"       |
+      | "NOT_FOUND" | "NotFound" |
+
+  Scenario Outline: Volume and storage pool metrics are collected
+    Given a VxFlexOS service
+    And a valid volume
+    When I call Probe
+    And a PersistentVolume "pv-perf" for volume "1234" with claim "apps/data"
+    And I induce error <error>
+    And I call collectVolumeMetrics
+    Then the metric <metric> of <label> is <value>
+
+    Examples:
+      | error                  | metric                                    | label                                 | value          |
+      | "none"                 | "powerflex_volume_read_iops"              | persistentvolume="pv-perf"            | "1"            |
+      | "none"                 | "powerflex_volume_write_iops"             | persistentvolumeclaim="data"          | "1"            |
+      | "none"                 | "powerflex_volume_read_bytes_per_second"  | namespace="apps"                      | "1024"         |
+      | "none"                 | "powerflex_volume_write_bytes_per_second" | persistentvolume="pv-perf"            | "1024"         |
+      | "none"                 | "powerflex_volume_read_latency_seconds"   | persistentvolume="pv-perf"            | "0.0005"       |
+      | "none"                 | "powerflex_volume_write_latency_seconds"  | volume_id="1234"                      | "0.0015"       |
+      | "none"                 | "powerflex_storage_pool_capacity_bytes"   | storage_pool="viki_pool_HDD_20181031" | "318898176000" |
+      | "none"                 | "powerflex_storage_pool_used_bytes"       | storage_pool="other_storage_pool"     | "17211326464"  |
+      | "none"                 | "powerflex_storage_pool_available_bytes"  | storage_pool="other_storage_pool"     | "120259084288" |
+      | "GetStatisticsError"   | "powerflex_volume_read_iops"              | persistentvolume="pv-perf"            | "none"         |
+      | "GetStatisticsError"   | "powerflex_storage_pool_used_bytes"       | storage_pool="other_storage_pool"     | "none"         |
+      | "GetStoragePoolsError" | "powerflex_storage_pool_used_bytes"       | storage_pool="other_storage_pool"     | "none"         |
+      | "GetStoragePoolsError" | "powerflex_volume_read_iops"              | persistentvolume="pv-perf"            | "1"            |
+
+  Scenario: Metrics label values are escaped and invalid series are left out
+    Given a VxFlexOS service
+    When I record metrics with special characters in their labels
+    Then the metrics endpoint on port "19090" is valid with the escaped label values
+
+  Scenario: Traced create volume records the gateway calls as spans of the request
+    Given a VxFlexOS service
+    When I call Probe
+    And I call CreateVolume "volume1" with a traced request
+    Then a valid CreateVolumeResponse is returned
+    And the trace contains spans "PowerFlex FindStoragePool, PowerFlex CreateVolume, PowerFlex GetVolume"
+    And the create volume gateway request carries the trace context
+
+  Scenario: Traced NFS publish records the NFS export gateway calls as spans of the request
+    Given a VxFlexOS service
+    When I call Probe
+    And I specify CreateVolumeMountRequest "nfs"
+    And I call CreateVolume "volume1"
+    Then a valid CreateVolumeResponse is returned
+    And I call NFS PublishVolume with "single-writer" with a traced request
+    Then a valid PublishVolumeResponse is returned
+    And the trace contains spans "PowerFlex GetFileSystemByIDName, PowerFlex CreateNFSExport, PowerFlex ModifyNFSExport"
+
+  Scenario Outline: Audit log records the operations that change the storage
+    Given a VxFlexOS service
+    And I enable the audit log
+    When I call Probe
+    And I induce error <error>
+    And I call CreateVolume "volume1" through the interceptors
+    And I call ListVolumes through the interceptors
+    Then the audit log has 1 record
+    And the last audit record is "/csi.v1.Controller/CreateVolume" with outcome <outcome> and code <code>
+
+    Examples:
+      | error               | outcome   | code       |
+      | "none"              | "success" | "OK"       |
+      | "CreateVolumeError" | "failure" | "Internal" |
+
+  Scenario: Audit log records the new size of an expanded volume
+    Given a VxFlexOS service
+    When I call Probe
+    And I call CreateVolumeSize "volume10" "32"
+    And I enable the audit log
+    And I call ControllerExpandVolume set to 64 through the interceptors
+    Then the error contains "none"
+    And the last audit record has objects "requiredBytes=68719476736"
+
+  Scenario: Audit log records the QoS limits of a published volume
+    Given a VxFlexOS service
+    And a valid volume
+    When I call Probe
+    And I enable the audit log
+    And I call PublishVolume with QoS limits "10240" and "100" through the interceptors
+    Then the error contains "none"
+    And the last audit record has objects "bandwidthLimitInKbps=10240, iopsLimit=100"
+
   Scenario: Idempotent create volume with duplicate volume name
     Given a VxFlexOS service
     When I call Probe
@@ -1496,4 +1883,38 @@ Feature: VxFlex OS CSI interface
     Examples:
       |  nfsexporthost                  | externalAccess                | errorMsg                              |
       |  "127.0.0.1/255.255.255.255"    | "127.0.0.1/255.255.255.255"   | "external access exists"              |
-      |  "127.1.1.0/255.255.255.255"    | "127.0.0.1/255.255.255.255"   | "external access does not exist"      |
\ No newline at end of file
+      |  "127.1.1.0/255.255.255.255"    | "127.0.0.1/255.255.255.255"   | "external access does not exist"      |
+
+  Scenario: Get stale NFS export hosts
+    Given an NFSExport instance with nfsexporthost <nfsexporthost>
+    When I call getStaleNFSExportHosts with valid host <validHost> and externalAccess <externalAccess>
+    Then the error contains <errorMsg>
+    Examples:
+      |  nfsexporthost                  | validHost                     | externalAccess                | errorMsg                                    |
+      |  "127.0.0.1/255.255.255.255"    | "127.0.0.1/255.255.255.255"   | ""                            | "no stale hosts"                            |
+      |  "127.0.0.2/255.255.255.255"    | "127.0.0.1/255.255.255.255"   | ""                            | "stale hosts: 127.0.0.2/255.255.255.255"    |
+      |  "127.0.0.2/255.255.255.255"    | "127.0.0.1/255.255.255.255"   | "127.0.0.2/255.255.255.255"   | "no stale hosts"                            |
+      |  "10.0.0.0/255.255.255.0"       | "127.0.0.1/255.255.255.255"   | ""                            | "no stale hosts"                            |
+
+  Scenario: Merge NFS mount options
+    Given a VxFlexOS service
+    When I call mergeNFSMountOptions with defaults <defaults> and mount flags <mountFlags>
+    Then the error contains <errorMsg>
+    Examples:
+      | defaults                         | mountFlags            | errorMsg                                           |
+      | "vers=4.1,proto=tcp,hard"        | ""                    | "mount options: vers=4.1,proto=tcp,hard"           |
+      | "vers=4.1,proto=tcp,hard"        | "nfsvers=3,soft"      | "mount options: nfsvers=3,soft,proto=tcp"          |
+      | "vers=4.1,timeo=600,nconnect=4"  | "timeo=100,udp"       | "mount options: timeo=100,udp,vers=4.1,nconnect=4" |
+      | "intr"                           | "nointr"              | "mount options: nointr"                            |
+
+  Scenario: Check NFS version against NAS server
+    Given a VxFlexOS service
+    When I call checkNASNFSVersion with version <version> nfsv3 <nfsv3> nfsv4 <nfsv4>
+    Then the error contains <errorMsg>
+    Examples:
+      | version | nfsv3   | nfsv4   | errorMsg                                                   |
+      | ""      | "false" | "false" | "none"                                                     |
+      | "3"     | "true"  | "false" | "none"                                                     |
+      | "4.1"   | "true"  | "false" | "NFS version 4.1 is not enabled on NAS server dummy-nas-server" |
+      | "4"     | "false" | "true"  | "none"                                                     |
+      | "2"     | "true"  | "true"  | "unsupported NFS version 2"                                |
@@ -0,0 +1,76 @@
+// Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dell/goscaleio/api"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// callGatewayAPI sends a request the goscaleio SDK has no function for to the gateway of a system, through its
+// proxy when it has one, with the token of its admin client. The admin client logs in again when the token
+// expired, as goscaleio does for its own requests. The call is observed as operation and carries the trace
+// context of ctx.
+func (s *service) callGatewayAPI(ctx context.Context, systemID, operation, method, uri string, body, resp interface{}) error {
+	adminClient := s.adminClientOf(systemID)
+	array := s.arrayOf(systemID)
+	if adminClient == nil || array == nil {
+		return fmt.Errorf("can't find adminClient by id %s", systemID)
+	}
+
+	endpoint := array.Endpoint
+	if proxy := s.gatewayProxyOf(systemID); proxy != nil {
+		endpoint = proxy.url()
+	}
+	c, err := api.New(ctx, endpoint, api.ClientOptions{
+		Insecure: array.SkipCertificateValidation || array.Insecure,
+		UseCerts: !s.opts.DisableCerts,
+	}, false)
+	if err != nil {
+		return err
+	}
+
+	version := adminClient.GetConfigConnect().Version
+	contentType := api.HeaderValContentTypeJSON
+	if version != "" {
+		contentType += ";version=" + version
+	}
+	headers := map[string]string{api.HeaderKeyAccept: contentType, api.HeaderKeyContentType: contentType}
+	injectTraceContext(ctx, propagation.MapCarrier(headers))
+	call := func() error {
+		c.SetToken(adminClient.GetToken())
+		return c.DoWithHeaders(ctx, method, uri, headers, body, resp, version)
+	}
+
+	start := time.Now()
+	err = call()
+	if isUnauthorized(err) {
+		if adminClient, err = s.loginAdminClient(ctx, adminClient, array); err == nil {
+			err = call()
+		}
+	}
+	observeGatewayCall(ctx, systemID, operation, start, err)
+	return err
+}
+
+// postReplicationConsistencyGroupAction runs an action of a replication consistency group through the gateway API
+func (s *service) postReplicationConsistencyGroupAction(ctx context.Context, systemID, groupID, action string, body interface{}) error {
+	return s.callGatewayAPI(ctx, systemID, action, http.MethodPost,
+		"/api/instances/ReplicationConsistencyGroup::"+groupID+"/action/"+action, body, nil)
+}
@@ -0,0 +1,384 @@
+// Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/dell/goscaleio"
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	// defaultGatewayHealthCheckInterval is how often a failed gateway is checked when no interval is configured
+	defaultGatewayHealthCheckInterval = 30 * time.Second
+
+	gatewayHealthCheckTimeout = 5 * time.Second
+)
+
+// gatewayEndpoints returns the endpoints of the gateways of array in the order they are tried, endpoint first
+func (array *ArrayConnectionData) gatewayEndpoints() []string {
+	endpoints := make([]string, 0, len(array.Endpoints)+1)
+	for _, endpoint := range append([]string{array.Endpoint}, array.Endpoints...) {
+		if endpoint != "" && !slices.Contains(endpoints, endpoint) {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+// needsGatewayProxy returns true when the client of array cannot connect to its gateway directly, because the
+// array has custom TLS settings or more than one gateway
+func (array *ArrayConnectionData) needsGatewayProxy() bool {
+	return array.hasCustomTLS() || len(array.gatewayEndpoints()) > 1
+}
+
+// gatewayProxy forwards the requests of a goscaleio client to the gateways of an array. goscaleio connects to a
+// single endpoint, only validates the gateway certificate against the system trust store and does not take a
+// client certificate, so the client talks plain HTTP to the proxy on the loopback interface. The URL of the proxy
+// starts with a random secret only the client knows, and the proxy rejects the requests without it, so other
+// processes on the node cannot use the client certificate of the driver. The credentials the client logs in with
+// cross the loopback interface in clear text, where only a process with the privileges of the node can read them.
+//
+// The proxy sends the requests to the first gateway that answers, in the order of the endpoints, and moves on to
+// the next one when a gateway cannot be reached or, for an idempotent request, when the connection fails or the
+// gateway answers 502, 503 or 504. A request that may have changed the system is not sent again, as the gateway
+// may have received it before failing. The gateways preceding the one in use
+// are checked in the background, so the proxy returns to the primary gateway when it recovers. The certificate
+// files are reloaded when they change.
+type gatewayProxy struct {
+	array     ArrayConnectionData
+	targets   []*url.URL
+	secret    string       // first segment of the path of the requests of the client
+	active    atomic.Int32 // index of the target in use
+	listener  net.Listener
+	server    *http.Server
+	watcher   *fsnotify.Watcher
+	transport atomic.Pointer[http.Transport]
+	done      chan struct{}
+}
+
+// newGatewayProxy starts a proxy to the gateways of array, checking a failed gateway every healthCheckInterval
+func newGatewayProxy(array *ArrayConnectionData, healthCheckInterval time.Duration) (*gatewayProxy, error) {
+	var targets []*url.URL
+	for _, endpoint := range array.gatewayEndpoints() {
+		target, err := url.Parse(endpoint)
+		if err != nil || target.Host == "" {
+			return nil, fmt.Errorf("invalid endpoint %s: %v", endpoint, err)
+		}
+		targets = append(targets, target)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no endpoint")
+	}
+	config, err := loadGatewayTLSConfig(array)
+	if err != nil {
+		return nil, err
+	}
+	var watcher *fsnotify.Watcher
+	if array.hasCustomTLS() {
+		if watcher, err = fsnotify.NewWatcher(); err != nil {
+			return nil, fmt.Errorf("unable to watch the certificate files: %v", err)
+		}
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		if watcher != nil {
+			_ = watcher.Close()
+		}
+		return nil, fmt.Errorf("unable to generate the proxy secret: %v", err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		if watcher != nil {
+			_ = watcher.Close()
+		}
+		return nil, fmt.Errorf("unable to listen on the loopback interface: %v", err)
+	}
+
+	p := &gatewayProxy{
+		array: *array, targets: targets, secret: hex.EncodeToString(secret), listener: lis, watcher: watcher,
+		done: make(chan struct{}),
+	}
+	p.transport.Store(newGatewayTransport(config))
+
+	proxy := &httputil.ReverseProxy{
+		// the target is chosen for each attempt by RoundTrip
+		Director:  func(*http.Request) {},
+		Transport: p,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			Log.WithError(err).Errorf("request %s to the gateway of array %s failed", r.URL.Path, array.SystemID)
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}
+	p.server = &http.Server{Handler: p.authorize(proxy), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := p.server.Serve(lis); err != nil && err != http.ErrServerClosed {
+			Log.WithError(err).Errorf("gateway proxy of array %s failed", array.SystemID)
+		}
+	}()
+
+	if watcher != nil {
+		// certificates mounted from secrets are replaced by swapping a symlink, so their directories are watched
+		dirs := make(map[string]bool)
+		for _, file := range []string{array.CACertificate, array.ClientCertificate, array.ClientKey} {
+			if file != "" && !dirs[filepath.Dir(file)] {
+				dirs[filepath.Dir(file)] = true
+				if err := watcher.Add(filepath.Dir(file)); err != nil {
+					Log.WithError(err).Warnf("unable to watch %s for certificate changes of array %s", filepath.Dir(file), array.SystemID)
+				}
+			}
+		}
+		go p.watch()
+	}
+	if len(targets) > 1 && healthCheckInterval > 0 {
+		go p.checkHealth(healthCheckInterval)
+	}
+
+	Log.Infof("gateway proxy of array %s listening on %s", array.SystemID, lis.Addr())
+	return p, nil
+}
+
+// newGatewayTransport returns a transport to the gateway using config
+func newGatewayTransport(config *tls.Config) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = config
+	return transport
+}
+
+// url returns the endpoint the goscaleio client of the array connects to
+func (p *gatewayProxy) url() string {
+	return "http://" + p.listener.Addr().String() + "/" + p.secret
+}
+
+// authorize passes the requests whose path starts with the secret of the proxy on to next without it, and answers
+// 403 to the others
+func (p *gatewayProxy) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secret, path, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		if subtle.ConstantTimeCompare([]byte(secret), []byte(p.secret)) != 1 {
+			Log.Warnf("gateway proxy of array %s rejected a request without its secret from %s", p.array.SystemID, r.RemoteAddr)
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		r.URL.Path, r.URL.RawPath = "/"+path, ""
+		next.ServeHTTP(w, r)
+	})
+}
+
+// endpoint returns the endpoint of the gateway in use
+func (p *gatewayProxy) endpoint() string {
+	return p.targets[p.active.Load()].String()
+}
+
+// RoundTrip sends a request to the gateway in use with the current TLS config, failing over to the next gateways
+// when it is unavailable and the request may be sent again
+func (p *gatewayProxy) RoundTrip(r *http.Request) (*http.Response, error) {
+	// the body is sent again to each gateway tried
+	var body []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(r.Body); err != nil {
+			return nil, err
+		}
+		_ = r.Body.Close()
+	}
+
+	first := int(p.active.Load())
+	for i := 0; ; i++ {
+		index := (first + i) % len(p.targets)
+		resp, err := p.transport.Load().RoundTrip(p.targetRequest(r, index, body))
+		if !retryable(r, resp, err) || r.Context().Err() != nil || i == len(p.targets)-1 {
+			if index != first && !gatewayUnavailable(resp, err) && p.active.CompareAndSwap(int32(first), int32(index)) {
+				Log.Warnf("array %s failed over from gateway %s to %s", p.array.SystemID, p.targets[first], p.targets[index])
+			}
+			return resp, err
+		}
+		if err != nil {
+			Log.WithError(err).Warnf("gateway %s of array %s is unavailable", p.targets[index], p.array.SystemID)
+		} else {
+			Log.Warnf("gateway %s of array %s is unavailable: %s", p.targets[index], p.array.SystemID, resp.Status)
+			_ = resp.Body.Close()
+		}
+	}
+}
+
+// targetRequest returns a copy of r sent to the gateway at index
+func (p *gatewayProxy) targetRequest(r *http.Request, index int, body []byte) *http.Request {
+	target := p.targets[index]
+	out := r.Clone(r.Context())
+	out.URL.Scheme, out.URL.Host = target.Scheme, target.Host
+	out.URL.Path, out.URL.RawPath = strings.TrimRight(target.Path, "/")+r.URL.Path, ""
+	out.Host = target.Host
+	if body != nil {
+		out.Body, out.ContentLength = io.NopCloser(bytes.NewReader(body)), int64(len(body))
+	}
+	return out
+}
+
+// gatewayUnavailable returns true when a gateway could not be reached or answers that it cannot serve requests.
+// The PowerFlex API answers 500 to requests that fail on the system, which another gateway would fail as well.
+func gatewayUnavailable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryable returns true when a request that failed on a gateway may be sent to the next one: the gateway could
+// not be reached, so it did not receive the request, or the gateway is unavailable and the request is idempotent
+func retryable(r *http.Request, resp *http.Response, err error) bool {
+	if !gatewayUnavailable(resp, err) {
+		return false
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// checkHealth periodically returns to a gateway preceding the one in use when it recovers
+func (p *gatewayProxy) checkHealth(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.returnToPreferredGateway()
+		}
+	}
+}
+
+// returnToPreferredGateway switches to the first healthy gateway preceding the one in use
+func (p *gatewayProxy) returnToPreferredGateway() {
+	active := int(p.active.Load())
+	for index := 0; index < active; index++ {
+		if !p.healthy(index) {
+			continue
+		}
+		if p.active.CompareAndSwap(int32(active), int32(index)) {
+			Log.Infof("gateway %s of array %s recovered, switching back from %s", p.targets[index], p.array.SystemID, p.targets[active])
+		}
+		return
+	}
+}
+
+// healthy returns true when the gateway at index answers. Any answer but a 5xx is fine, the request is not
+// authenticated.
+func (p *gatewayProxy) healthy(index int) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), gatewayHealthCheckTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(p.targets[index].String(), "/")+"/api/version", nil)
+	if err != nil {
+		return false
+	}
+	resp, err := p.transport.Load().RoundTrip(req)
+	if err != nil {
+		return false
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode < http.StatusInternalServerError
+}
+
+// watch reloads the TLS config when the certificate files change
+func (p *gatewayProxy) watch() {
+	for {
+		select {
+		case _, ok := <-p.watcher.Events:
+			if !ok {
+				return
+			}
+			p.reload()
+		case err, ok := <-p.watcher.Errors:
+			if !ok {
+				return
+			}
+			Log.WithError(err).Warnf("error watching the certificate files of array %s", p.array.SystemID)
+		}
+	}
+}
+
+// reload replaces the TLS config by the one in the certificate files, keeping the current one when they do not load
+func (p *gatewayProxy) reload() {
+	config, err := loadGatewayTLSConfig(&p.array)
+	if err != nil {
+		Log.WithError(err).Errorf("unable to reload the certificates of array %s, keeping the current ones", p.array.SystemID)
+		return
+	}
+	previous := p.transport.Swap(newGatewayTransport(config))
+	previous.CloseIdleConnections()
+	Log.Infof("certificates of array %s reloaded", p.array.SystemID)
+}
+
+// close stops the proxy
+func (p *gatewayProxy) close() {
+	close(p.done)
+	if p.watcher != nil {
+		if err := p.watcher.Close(); err != nil {
+			Log.WithError(err).Warnf("error stopping the certificate watch of array %s", p.array.SystemID)
+		}
+	}
+	if err := p.server.Close(); err != nil {
+		Log.WithError(err).Warnf("error stopping the gateway proxy of array %s", p.array.SystemID)
+	}
+	p.transport.Load().CloseIdleConnections()
+}
+
+// connectArray creates a client of the gateway of array, through a proxy when the array has custom TLS settings or
+// more than one gateway, and logs in to it. Nothing is left running when it fails.
+func (s *service) connectArray(ctx context.Context, array *ArrayConnectionData) (*goscaleio.Client, *gatewayProxy, error) {
+	var proxy *gatewayProxy
+	if array.needsGatewayProxy() {
+		var err error
+		if proxy, err = newGatewayProxy(array, s.opts.GatewayHealthCheckInterval); err != nil {
+			return nil, nil, err
+		}
+	}
+	c, err := s.newAdminClient(array, proxy)
+	if err == nil {
+		c, err = s.loginAdminClient(ctx, c, array)
+	}
+	if err != nil {
+		if proxy != nil {
+			proxy.close()
+		}
+		return nil, nil, err
+	}
+	return c, proxy, nil
+}
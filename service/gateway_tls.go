@@ -0,0 +1,83 @@
+// Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dell/goscaleio/api"
+)
+
+// hasCustomTLS returns true when the array sets a CA bundle, a client certificate or a server name for the TLS
+// connection to its gateway
+func (array *ArrayConnectionData) hasCustomTLS() bool {
+	return array.CACertificate != "" || array.ClientCertificate != "" || array.ClientKey != "" || array.TLSServerName != ""
+}
+
+// validateGatewayTLS checks that the TLS settings of array are consistent and that its certificate files load
+func validateGatewayTLS(array *ArrayConnectionData) error {
+	if !array.hasCustomTLS() {
+		return nil
+	}
+	for _, endpoint := range array.gatewayEndpoints() {
+		if !strings.HasPrefix(strings.ToLower(endpoint), "https://") {
+			return fmt.Errorf("TLS settings require an https endpoint, endpoint is %s", endpoint)
+		}
+	}
+	if array.CACertificate != "" && (array.SkipCertificateValidation || array.Insecure) {
+		return fmt.Errorf("caCertificate cannot be used when certificate validation is skipped")
+	}
+	_, err := loadGatewayTLSConfig(array)
+	return err
+}
+
+// loadGatewayTLSConfig builds the TLS config of the connection to the gateway of array from its certificate files
+func loadGatewayTLSConfig(array *ArrayConnectionData) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		CipherSuites: api.GetSecuredCipherSuites(),
+		ServerName:   array.TLSServerName,
+		// #nosec G402
+		InsecureSkipVerify: array.SkipCertificateValidation || array.Insecure,
+	}
+
+	if array.CACertificate != "" {
+		bundle, err := os.ReadFile(filepath.Clean(array.CACertificate))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read caCertificate: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("no PEM certificate found in caCertificate %s", array.CACertificate)
+		}
+		config.RootCAs = pool
+	}
+
+	if (array.ClientCertificate == "") != (array.ClientKey == "") {
+		return nil, fmt.Errorf("clientCertificate and clientKey must be set together")
+	}
+	if array.ClientCertificate != "" {
+		cert, err := tls.LoadX509KeyPair(filepath.Clean(array.ClientCertificate), filepath.Clean(array.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("unable to load clientCertificate and clientKey: %v", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
@@ -0,0 +1,242 @@
+// Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metricLabels are the labels identifying one series of a metric
+type metricLabels map[string]string
+
+const (
+	metricTypeGauge     = "gauge"
+	metricTypeCounter   = "counter"
+	metricTypeHistogram = "histogram"
+)
+
+// latencyBuckets are the upper bounds in seconds of the latency histograms. CSI operations on PowerFlex can take
+// tens of seconds, so the buckets go up to a minute.
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// histogram holds the observations of one histogram series, counts are per bucket and not cumulative
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// metricFamily is a gauge, counter or histogram metric and the values of its series, keyed by the encoded labels
+type metricFamily struct {
+	name       string
+	help       string
+	metricType string
+	series     map[string]float64
+	histograms map[string]*histogram
+	labels     map[string]metricLabels
+	buckets    []float64
+}
+
+// metricsRegistry holds the metrics published by the driver. It is a prometheus.Collector, client_golang
+// validates and encodes the metrics when they are served.
+type metricsRegistry struct {
+	mu       sync.RWMutex
+	families map[string]*metricFamily
+}
+
+// driverMetrics is the registry of the driver metrics
+var driverMetrics = newMetricsRegistry()
+
+func newMetricsRegistry() *metricsRegistry {
+	return &metricsRegistry{
+		families: make(map[string]*metricFamily),
+	}
+}
+
+// encode returns the key of the series with the labels, sorted by label name, e.g. `a="1",b="2"`
+func (l metricLabels) encode() string {
+	names := make([]string, 0, len(l))
+	for name := range l {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(l[name])
+		pairs = append(pairs, name+`="`+value+`"`)
+	}
+	return strings.Join(pairs, ",")
+}
+
+// family returns the metric family name, creating it when it does not exist yet. The caller holds the lock.
+func (m *metricsRegistry) family(name, help, metricType string) *metricFamily {
+	family, ok := m.families[name]
+	if !ok {
+		family = &metricFamily{
+			name:       name,
+			help:       help,
+			metricType: metricType,
+			series:     make(map[string]float64),
+			histograms: make(map[string]*histogram),
+			labels:     make(map[string]metricLabels),
+		}
+		m.families[name] = family
+	}
+	return family
+}
+
+// setGauge sets the value of the series of metric name identified by labels
+func (m *metricsRegistry) setGauge(name, help string, labels metricLabels, value float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	family := m.family(name, help, metricTypeGauge)
+	key := labels.encode()
+	family.series[key] = value
+	family.labels[key] = labels
+}
+
+// incCounter increments the series of counter name identified by labels
+func (m *metricsRegistry) incCounter(name, help string, labels metricLabels) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	family := m.family(name, help, metricTypeCounter)
+	key := labels.encode()
+	family.series[key]++
+	family.labels[key] = labels
+}
+
+// observeHistogram adds value to the series of histogram name identified by labels
+func (m *metricsRegistry) observeHistogram(name, help string, buckets []float64, labels metricLabels, value float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	family := m.family(name, help, metricTypeHistogram)
+	family.buckets = buckets
+	key := labels.encode()
+	h, ok := family.histograms[key]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(buckets))}
+		family.histograms[key] = h
+		family.labels[key] = labels
+	}
+	for i, bound := range buckets {
+		if value <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += value
+	h.count++
+}
+
+// getValue returns the value of the series of metric name identified by labels, the number of observations
+// for a histogram
+func (m *metricsRegistry) getValue(name string, labels metricLabels) (float64, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	family, ok := m.families[name]
+	if !ok {
+		return 0, false
+	}
+	if h, ok := family.histograms[labels.encode()]; ok {
+		return float64(h.count), true
+	}
+	value, ok := family.series[labels.encode()]
+	return value, ok
+}
+
+// deleteSeries removes the series of metric name identified by labels
+func (m *metricsRegistry) deleteSeries(name string, labels metricLabels) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if family, ok := m.families[name]; ok {
+		delete(family.series, labels.encode())
+		delete(family.labels, labels.encode())
+	}
+}
+
+// replaceFamilies replaces the metrics names with their series in collected, a metric without series in
+// collected is removed. Readers see either all previous or all collected series.
+func (m *metricsRegistry) replaceFamilies(collected *metricsRegistry, names ...string) {
+	collected.mu.RLock()
+	defer collected.mu.RUnlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, name := range names {
+		if family, ok := collected.families[name]; ok {
+			m.families[name] = family
+		} else {
+			delete(m.families, name)
+		}
+	}
+}
+
+// Describe sends no descriptors, the metrics are created at runtime, so the registry is an unchecked collector
+func (m *metricsRegistry) Describe(chan<- *prometheus.Desc) {}
+
+// Collect sends the series of all metrics. A series client_golang rejects, e.g. for a label value that is not
+// valid UTF-8, is sent as an invalid metric, which fails only that series when the metrics are served.
+func (m *metricsRegistry) Collect(ch chan<- prometheus.Metric) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, family := range m.families {
+		valueType := prometheus.GaugeValue
+		if family.metricType == metricTypeCounter {
+			valueType = prometheus.CounterValue
+		}
+		for key, value := range family.series {
+			desc, values := family.desc(key)
+			metric, err := prometheus.NewConstMetric(desc, valueType, value, values...)
+			ch <- validMetric(desc, metric, err)
+		}
+		for key, h := range family.histograms {
+			desc, values := family.desc(key)
+			buckets := make(map[float64]uint64, len(family.buckets))
+			cumulative := uint64(0)
+			for i, bound := range family.buckets {
+				cumulative += h.counts[i]
+				buckets[bound] = cumulative
+			}
+			metric, err := prometheus.NewConstHistogram(desc, h.count, h.sum, buckets, values...)
+			ch <- validMetric(desc, metric, err)
+		}
+	}
+}
+
+// desc returns the descriptor of the series of the family with the encoded labels key, and its label values
+func (f *metricFamily) desc(key string) (*prometheus.Desc, []string) {
+	labels := f.labels[key]
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	values := make([]string, 0, len(names))
+	for _, name := range names {
+		values = append(values, labels[name])
+	}
+	return prometheus.NewDesc(f.name, f.help, names, nil), values
+}
+
+// validMetric returns metric, or an invalid metric of desc when it could not be created
+func validMetric(desc *prometheus.Desc, metric prometheus.Metric, err error) prometheus.Metric {
+	if err != nil {
+		return prometheus.NewInvalidMetric(desc, err)
+	}
+	return metric
+}
@@ -0,0 +1,189 @@
+// Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// ParamMetricsEnabled enables the HTTP metrics listener
+	ParamMetricsEnabled = "CSI_METRICS_ENABLED"
+	// ParamMetricsControllerPort is the port of the metrics listener of the controller
+	ParamMetricsControllerPort = "CSI_METRICS_CONTROLLER_PORT"
+	// ParamMetricsNodePort is the port of the metrics listener of the node
+	ParamMetricsNodePort = "CSI_METRICS_NODE_PORT"
+
+	defaultMetricsControllerPort = "9090"
+	defaultMetricsNodePort       = "9091"
+
+	// metricsPath is the path the metrics are served on
+	metricsPath = "/metrics"
+
+	metricRPCRequests       = "powerflex_rpc_requests_total"
+	metricRPCDuration       = "powerflex_rpc_duration_seconds"
+	metricGatewayRequests   = "powerflex_gateway_requests_total"
+	metricGatewayErrors     = "powerflex_gateway_errors_total"
+	metricGatewayDuration   = "powerflex_gateway_request_duration_seconds"
+	metricArrayProbeSuccess = "powerflex_array_probe_success"
+	metricCacheRequests     = "powerflex_cache_requests_total"
+
+	// volumeCache and snapshotCache are the names of the volCache and snapCache in the cache metrics
+	volumeCache   = "volume"
+	snapshotCache = "snapshot"
+)
+
+// metricsListener serves the driver metrics over HTTP
+type metricsListener struct {
+	mu      sync.Mutex
+	address string
+	server  *http.Server
+}
+
+// configureMetricsListener starts, moves or stops the metrics listener according to the driver config params.
+// The controller and the node listen on different ports as the node runs on the host network.
+func (s *service) configureMetricsListener(v *viper.Viper) {
+	portParam, port := ParamMetricsControllerPort, defaultMetricsControllerPort
+	if strings.EqualFold(s.mode, "node") {
+		portParam, port = ParamMetricsNodePort, defaultMetricsNodePort
+	}
+	if v.GetString(portParam) != "" {
+		port = v.GetString(portParam)
+	}
+
+	address := ""
+	if v.GetBool(ParamMetricsEnabled) {
+		address = ":" + port
+	}
+	s.metrics.listen(address)
+}
+
+// listen serves the metrics on address, an empty address stops the listener
+func (l *metricsListener) listen(address string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if address == l.address {
+		return
+	}
+
+	if l.server != nil {
+		if err := l.server.Close(); err != nil {
+			Log.WithError(err).Warnf("error stopping metrics listener on %s", l.address)
+		}
+		Log.Infof("metrics listener on %s stopped", l.address)
+		l.server = nil
+		l.address = ""
+	}
+	if address == "" {
+		return
+	}
+
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		Log.WithError(err).Errorf("unable to start metrics listener on %s", address)
+		return
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(metricsPath, serveMetrics)
+	l.server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	l.address = address
+	go func(server *http.Server) {
+		if err := server.Serve(lis); err != nil && err != http.ErrServerClosed {
+			Log.WithError(err).Error("metrics listener failed")
+		}
+	}(l.server)
+	Log.Infof("metrics listener started on %s%s", address, metricsPath)
+}
+
+// serveMetrics serves the driver metrics with client_golang, in the exposition format the scraper negotiates.
+// A series that fails validation is logged and left out, the other series are still served.
+func serveMetrics(w http.ResponseWriter, r *http.Request) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(driverMetrics)
+	promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+		ErrorLog:      Log,
+		ErrorHandling: promhttp.ContinueOnError,
+	}).ServeHTTP(w, r)
+}
+
+// RPCMetricsInterceptor records the count, latency and status code of every CSI and extension RPC
+func RPCMetricsInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	driverMetrics.observeHistogram(metricRPCDuration, "Latency of the driver RPCs in seconds", latencyBuckets,
+		metricLabels{"method": info.FullMethod}, time.Since(start).Seconds())
+	driverMetrics.incCounter(metricRPCRequests, "Number of driver RPCs by gRPC status code",
+		metricLabels{"method": info.FullMethod, "code": status.Code(err).String()})
+	return resp, err
+}
+
+// gatewayCall makes a PowerFlex gateway call through goscaleio and records it with observeGatewayCall. Every SDK
+// call that reaches the gateway is made through it, the results are returned by assigning them in call. When the
+// gateway rejects the credentials of the call, which goscaleio logged in with again after the session expired,
+// the credentials of the system are read again so the next calls log in with rotated credentials.
+func (s *service) gatewayCall(ctx context.Context, systemID, operation string, call func() error) error {
+	start := time.Now()
+	err := call()
+	observeGatewayCall(ctx, systemID, operation, start, err)
+	// the logins read the credentials again themselves, see loginAdminClient
+	if isSessionLoginRejected(err) && operation != "Authenticate" {
+		s.reauthenticateArray(ctx, systemID)
+	}
+	return err
+}
+
+// observeGatewayCall records a PowerFlex gateway call made through goscaleio in the metrics and as a span of
+// the request in ctx. goscaleio does not expose its HTTP client, so the calls are recorded where the driver
+// makes them, under the name of the SDK operation.
+func observeGatewayCall(ctx context.Context, systemID, operation string, start time.Time, err error) {
+	end := time.Now()
+	traceGatewayCall(ctx, systemID, operation, start, end, err)
+	labels := metricLabels{"system_id": systemID, "operation": operation}
+	driverMetrics.observeHistogram(metricGatewayDuration, "Latency of the PowerFlex gateway calls in seconds", latencyBuckets,
+		labels, end.Sub(start).Seconds())
+	driverMetrics.incCounter(metricGatewayRequests, "Number of PowerFlex gateway calls", labels)
+	if err != nil {
+		driverMetrics.incCounter(metricGatewayErrors, "Number of failed PowerFlex gateway calls", labels)
+	}
+}
+
+// recordProbe records the result of the last probe of an array
+func recordProbe(systemID string, err error) {
+	success := 1.0
+	if err != nil {
+		success = 0
+	}
+	driverMetrics.setGauge(metricArrayProbeSuccess, "1 if the last probe of the array succeeded", metricLabels{"system_id": systemID}, success)
+}
+
+// recordCacheLookup records a lookup in the volume or snapshot cache
+func recordCacheLookup(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	driverMetrics.incCounter(metricCacheRequests, "Number of volume and snapshot cache lookups by result",
+		metricLabels{"cache": cache, "result": result})
+}
@@ -329,8 +329,9 @@ func publishVolume(
 	return nil
 }
 
-// publishNFS mounts the NFS Volume to the targetpath
-func publishNFS(ctx context.Context, req *csi.NodePublishVolumeRequest, nfsExportURL string) error {
+// publishNFS mounts the NFS Volume to the targetpath using mntOptions, which are the
+// volume mount flags merged with the driver default NFS mount options
+func publishNFS(ctx context.Context, req *csi.NodePublishVolumeRequest, nfsExportURL string, mntOptions []string) error {
 	volCap := req.GetVolumeCapability()
 
 	if volCap == nil {
@@ -351,8 +352,7 @@ func publishNFS(ctx context.Context, req *csi.NodePublishVolumeRequest, nfsExpor
 		return status.Error(codes.InvalidArgument, "Invalid access type")
 	}
 
-	var mntOptions []string
-	mntOptions = mountVol.GetMountFlags()
+	mntOptions = append([]string{}, mntOptions...)
 	Log.Infof("The mountOptions received are: %s", mntOptions)
 
 	target := req.GetTargetPath()
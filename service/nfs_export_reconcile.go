@@ -0,0 +1,300 @@
+// Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dell/csi-vxflexos/v2/k8sutils"
+	siotypes "github.com/dell/goscaleio/types/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// hostNetmask is the netmask the driver appends to node IPs when granting NFS export access
+const hostNetmask = "/255.255.255.255"
+
+// nfsExportReconcileResult describes the stale hosts found on one NFS export
+type nfsExportReconcileResult struct {
+	SystemID     string
+	ExportID     string
+	ExportName   string
+	StaleHosts   []string
+	DryRun       bool
+	ModifyParams *siotypes.NFSExportModify
+}
+
+// runNFSExportReconciler periodically prunes stale hosts from NFS exports created by the driver
+func (s *service) runNFSExportReconciler(ctx context.Context, interval time.Duration) {
+	Log.Infof("NFS export reconciler started, interval: %s, dry run: %t", interval, s.opts.NFSExportReconcileDryRun)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			Log.Info("NFS export reconciler stopped")
+			return
+		case <-ticker.C:
+			if _, err := s.reconcileNFSExports(ctx, s.opts.NFSExportReconcileDryRun); err != nil {
+				Log.WithError(err).Error("NFS export reconciliation failed")
+			}
+		}
+	}
+}
+
+// reconcileNFSExports compares the host lists of every csishare- NFS export with the SDC IPs of the
+// nodes the volume is currently attached to or used by as an inline ephemeral volume, and removes the host entries that are no longer valid.
+// When dryRun is set, the stale hosts are only reported.
+func (s *service) reconcileNFSExports(ctx context.Context, dryRun bool) ([]*nfsExportReconcileResult, error) {
+	if K8sClientset == nil {
+		err := k8sutils.CreateKubeClientSet()
+		if err != nil {
+			return nil, fmt.Errorf("unable to create k8s clientset for NFS export reconciliation: %v", err)
+		}
+		K8sClientset = k8sutils.Clientset
+	}
+
+	// The exports are read before the VolumeAttachments, so any host added by a ControllerPublishVolume
+	// that runs concurrently belongs to a VolumeAttachment that is already listed below.
+	exports := make(map[string][]siotypes.NFSExport)
+	for systemID := range s.arrayConfigs() {
+		if err := s.requireProbe(ctx, systemID); err != nil {
+			Log.WithError(err).Warnf("NFS export reconciliation skipping system %s", systemID)
+			continue
+		}
+		var nfsExports []siotypes.NFSExport
+		err := s.gatewayCall(ctx, systemID, "GetNFSExport", func() (err error) {
+			nfsExports, err = s.adminClientOf(systemID).GetNFSExport()
+			return err
+		})
+		if err != nil {
+			Log.WithError(err).Warnf("NFS export reconciliation could not list NFS exports on system %s", systemID)
+			continue
+		}
+		exports[systemID] = nfsExports
+	}
+
+	validHosts, unresolved, err := s.getAttachedNFSHosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	externalAccess := s.reloadable().ExternalAccess
+	results := make([]*nfsExportReconcileResult, 0)
+	for systemID, nfsExports := range exports {
+		for i := range nfsExports {
+			export := &nfsExports[i]
+			if !strings.HasPrefix(export.Name, NFSExportNamePrefix) {
+				continue
+			}
+			key := systemID + "/" + export.FileSystemID
+			if unresolved[key] {
+				Log.Warnf("NFS export reconciliation skipping export %s, not all attached nodes could be resolved", export.Name)
+				continue
+			}
+
+			modifyParams, staleHosts := getStaleNFSExportHosts(export, validHosts[key], externalAccess)
+			if len(staleHosts) == 0 {
+				continue
+			}
+
+			result := &nfsExportReconcileResult{
+				SystemID:     systemID,
+				ExportID:     export.ID,
+				ExportName:   export.Name,
+				StaleHosts:   staleHosts,
+				DryRun:       dryRun,
+				ModifyParams: modifyParams,
+			}
+			results = append(results, result)
+
+			fields := map[string]interface{}{
+				"systemID":   systemID,
+				"exportID":   export.ID,
+				"exportName": export.Name,
+				"staleHosts": staleHosts,
+			}
+			if dryRun {
+				Log.WithFields(fields).Info("NFS export reconciliation dry run, stale hosts would be removed")
+				continue
+			}
+			err := s.gatewayCall(ctx, systemID, "ModifyNFSExport", func() error {
+				return s.adminClientOf(systemID).ModifyNFSExport(modifyParams, export.ID)
+			})
+			hosts, _ := json.Marshal(staleHosts)
+			s.auditOperation(ctx, auditNFSExportHostsRemoved, systemID,
+				map[string]string{"nfsExportId": export.ID, "name": export.Name, "hosts": string(hosts)}, err)
+			if err != nil {
+				Log.WithFields(fields).WithError(err).Error("NFS export reconciliation failed to remove stale hosts")
+				continue
+			}
+			Log.WithFields(fields).Info("NFS export reconciliation removed stale hosts")
+		}
+	}
+	return results, nil
+}
+
+// getAttachedNFSHosts returns, keyed by systemID/filesystemID, the set of host entries that are backed by a
+// VolumeAttachment of this driver or by a pod using an inline ephemeral NFS volume of this driver, which is
+// published by the node without a VolumeAttachment. Filesystems with an attachment whose node could not be
+// resolved are returned in the second map so their exports are left untouched.
+func (s *service) getAttachedNFSHosts(ctx context.Context) (map[string]map[string]bool, map[string]bool, error) {
+	validHosts := make(map[string]map[string]bool)
+	unresolved := make(map[string]bool)
+
+	nodeIDs := make(map[string]string)
+	sdcIPs := make(map[string][]string)
+	// addNodeHosts adds the SDC IPs of the node nodeName to the valid hosts of the filesystem key
+	addNodeHosts := func(systemID, key, nodeName string) {
+		nodeID, ok := nodeIDs[nodeName]
+		if !ok {
+			csiNode, err := K8sClientset.StorageV1().CSINodes().Get(ctx, nodeName, metav1.GetOptions{})
+			if err != nil && !k8serrors.IsNotFound(err) {
+				Log.WithError(err).Warnf("NFS export reconciliation could not get CSINode %s", nodeName)
+				unresolved[key] = true
+				return
+			}
+			// a deleted node no longer contributes any valid host
+			if csiNode != nil {
+				for _, driver := range csiNode.Spec.Drivers {
+					if driver.Name == Name {
+						nodeID = driver.NodeID
+					}
+				}
+			}
+			nodeIDs[nodeName] = nodeID
+		}
+		if nodeID == "" {
+			return
+		}
+
+		ips, ok := sdcIPs[systemID+"/"+nodeID]
+		if !ok {
+			var err error
+			ips, err = s.getSDCIPs(ctx, nodeID, systemID)
+			if err != nil {
+				Log.WithError(err).Warnf("NFS export reconciliation could not get SDC IPs of node %s", nodeName)
+				unresolved[key] = true
+				return
+			}
+			sdcIPs[systemID+"/"+nodeID] = ips
+		}
+		if validHosts[key] == nil {
+			validHosts[key] = make(map[string]bool)
+		}
+		for _, ip := range ips {
+			validHosts[key][ip+hostNetmask] = true
+		}
+	}
+
+	attachments, err := K8sClientset.StorageV1().VolumeAttachments().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to list VolumeAttachments: %v", err)
+	}
+	for _, va := range attachments.Items {
+		if va.Spec.Attacher != Name || va.Spec.Source.PersistentVolumeName == nil {
+			continue
+		}
+		pv, err := K8sClientset.CoreV1().PersistentVolumes().Get(ctx, *va.Spec.Source.PersistentVolumeName, metav1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return nil, nil, fmt.Errorf("unable to get PersistentVolume %s: %v", *va.Spec.Source.PersistentVolumeName, err)
+		}
+		if pv.Spec.CSI == nil || !strings.Contains(pv.Spec.CSI.VolumeHandle, "/") {
+			continue
+		}
+		csiVolID := pv.Spec.CSI.VolumeHandle
+		systemID := s.getSystemIDFromCsiVolumeID(csiVolID)
+		addNodeHosts(systemID, systemID+"/"+getFilesystemIDFromCsiVolumeID(csiVolID), va.Spec.NodeName)
+	}
+
+	pods, err := K8sClientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to list pods: %v", err)
+	}
+	// the filesystem of an inline ephemeral volume is named after its volumeName attribute
+	filesystemIDs := make(map[string]map[string]string)
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.CSI == nil || volume.CSI.Driver != Name || volume.CSI.VolumeAttributes[KeyFsType] != "nfs" {
+				continue
+			}
+			systemID := volume.CSI.VolumeAttributes["systemID"]
+			if systemID == "" {
+				systemID = s.defaultSystemID()
+			} else if id, ok := s.systemIDByName(systemID); ok {
+				systemID = id
+			}
+			if filesystemIDs[systemID] == nil {
+				if s.systemOf(systemID) == nil {
+					continue
+				}
+				var filesystems []siotypes.FileSystem
+				err := s.gatewayCall(ctx, systemID, "GetAllFileSystems", func() (err error) {
+					filesystems, err = s.systemOf(systemID).GetAllFileSystems()
+					return err
+				})
+				if err != nil {
+					return nil, nil, fmt.Errorf("unable to list the filesystems of system %s: %v", systemID, err)
+				}
+				filesystemIDs[systemID] = make(map[string]string)
+				for _, fs := range filesystems {
+					filesystemIDs[systemID][fs.Name] = fs.ID
+				}
+			}
+			fsID, ok := filesystemIDs[systemID][volume.CSI.VolumeAttributes["volumeName"]]
+			if !ok {
+				continue
+			}
+			addNodeHosts(systemID, systemID+"/"+fsID, pod.Spec.NodeName)
+		}
+	}
+	return validHosts, unresolved, nil
+}
+
+// getStaleNFSExportHosts returns the modify parameters that remove every single host entry of the export
+// that is neither in validHosts nor the configured externalAccess, along with the list of those hosts.
+// Entries with a netmask other than 255.255.255.255 were not added for a node and are never removed.
+func getStaleNFSExportHosts(export *siotypes.NFSExport, validHosts map[string]bool, externalAccess string) (*siotypes.NFSExportModify, []string) {
+	staleHosts := make([]string, 0)
+	stale := func(hosts []string) []string {
+		var remove []string
+		for _, host := range hosts {
+			if !strings.HasSuffix(host, hostNetmask) || validHosts[host] || host == externalAccess {
+				continue
+			}
+			remove = append(remove, host)
+			staleHosts = append(staleHosts, host)
+		}
+		return remove
+	}
+
+	modifyParams := &siotypes.NFSExportModify{
+		RemoveReadOnlyHosts:      stale(export.ReadOnlyHosts),
+		RemoveReadWriteHosts:     stale(export.ReadWriteHosts),
+		RemoveReadOnlyRootHosts:  stale(export.ReadOnlyRootHosts),
+		RemoveReadWriteRootHosts: stale(export.ReadWriteRootHosts),
+	}
+	return modifyParams, staleHosts
+}
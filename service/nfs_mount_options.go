@@ -0,0 +1,170 @@
+// Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dell/goscaleio"
+	siotypes "github.com/dell/goscaleio/types/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// splitNFSMountOptions splits a comma separated list of mount options, dropping empty entries
+func splitNFSMountOptions(options string) []string {
+	var result []string
+	for _, option := range strings.Split(options, ",") {
+		option = strings.TrimSpace(option)
+		if option != "" {
+			result = append(result, option)
+		}
+	}
+	return result
+}
+
+// nfsMountOptionName returns the name of a mount option, e.g. "timeo" for "timeo=600"
+func nfsMountOptionName(option string) string {
+	return strings.TrimSpace(strings.SplitN(option, "=", 2)[0])
+}
+
+// nfsMountOptionKey returns the key used to decide if two mount options set the same
+// property, so that "soft" overrides "hard", "udp" overrides "proto=tcp" and so on
+func nfsMountOptionKey(option string) string {
+	name := nfsMountOptionName(option)
+	switch name {
+	case "nfsvers":
+		return "vers"
+	case "tcp", "udp":
+		return "proto"
+	case "hard", "soft":
+		return "hard"
+	case "ro", "rw":
+		return "rw"
+	}
+	if !strings.Contains(option, "=") {
+		return strings.TrimPrefix(name, "no")
+	}
+	return name
+}
+
+// mergeNFSMountOptions returns the mount options of the volume, followed by every default
+// option that sets a property the volume mount options do not set
+func mergeNFSMountOptions(defaults []string, mountFlags []string) []string {
+	keys := make(map[string]bool)
+	merged := make([]string, 0, len(defaults)+len(mountFlags))
+	for _, option := range mountFlags {
+		keys[nfsMountOptionKey(option)] = true
+		merged = append(merged, option)
+	}
+	for _, option := range defaults {
+		if !keys[nfsMountOptionKey(option)] {
+			merged = append(merged, option)
+		}
+	}
+	return merged
+}
+
+// validateNFSMountOptions checks that every mount option is in the allow-list. Any option is
+// accepted when the allow-list is empty.
+func validateNFSMountOptions(options []string, allowed []string) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+	allowList := make(map[string]bool)
+	for _, name := range allowed {
+		allowList[nfsMountOptionName(name)] = true
+	}
+	var rejected []string
+	for _, option := range options {
+		if !allowList[nfsMountOptionName(option)] {
+			rejected = append(rejected, option)
+		}
+	}
+	if len(rejected) > 0 {
+		return fmt.Errorf("NFS mount options %v are not allowed, allowed options are: %v", rejected, allowed)
+	}
+	return nil
+}
+
+// getNFSVersion returns the NFS version requested by the mount options, or an empty string when
+// the version is left to the client to negotiate
+func getNFSVersion(options []string) string {
+	version := ""
+	for _, option := range options {
+		if nfsMountOptionKey(option) == "vers" && strings.Contains(option, "=") {
+			version = strings.TrimSpace(strings.SplitN(option, "=", 2)[1])
+		}
+	}
+	return version
+}
+
+// checkNASNFSVersion verifies that the NAS server has an NFS server with the requested NFS version enabled
+func checkNASNFSVersion(nas *siotypes.NAS, version string) error {
+	if version == "" {
+		return nil
+	}
+	var enabled func(siotypes.NFSServerInstance) bool
+	switch {
+	case version == "3":
+		enabled = func(nfsServer siotypes.NFSServerInstance) bool { return nfsServer.IsNFSv3Enabled }
+	case version == "4" || strings.HasPrefix(version, "4."):
+		enabled = func(nfsServer siotypes.NFSServerInstance) bool { return nfsServer.IsNFSv4Enabled }
+	default:
+		return status.Errorf(codes.InvalidArgument, "unsupported NFS version %s, supported versions are 3 and 4.x", version)
+	}
+	for _, nfsServer := range nas.NfsServers {
+		if enabled(nfsServer) {
+			return nil
+		}
+	}
+	return status.Errorf(codes.FailedPrecondition,
+		"NFS version %s is not enabled on NAS server %s", version, nas.Name)
+}
+
+// getNFSMountOptions merges the volume mount flags with the driver default NFS mount options,
+// validates them against the allow-list, if one is configured, and checks the NFS version against the NAS server
+func (s *service) getNFSMountOptions(ctx context.Context, mountFlags []string, systemID string, fs *siotypes.FileSystem) ([]string, error) {
+	mntOptions := mergeNFSMountOptions(s.opts.NFSMountOptions, mountFlags)
+	if err := validateNFSMountOptions(mntOptions, s.opts.NFSAllowedMountOptions); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	version := getNFSVersion(mntOptions)
+	if version == "" {
+		return mntOptions, nil
+	}
+	var system *goscaleio.System
+	err := s.gatewayCall(ctx, systemID, "FindSystem", func() (err error) {
+		system, err = s.adminClientOf(systemID).FindSystem(systemID, "", "")
+		return err
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failure getting system %s: %s", systemID, err.Error())
+	}
+	var nas *siotypes.NAS
+	err = s.gatewayCall(ctx, systemID, "GetNASByIDName", func() (err error) {
+		nas, err = system.GetNASByIDName(fs.NasServerID, "")
+		return err
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failure getting NAS server %s: %s", fs.NasServerID, err.Error())
+	}
+	if err := checkNASNFSVersion(nas, version); err != nil {
+		return nil, err
+	}
+	return mntOptions, nil
+}
@@ -26,6 +26,7 @@ import (
 	csi "github.com/container-storage-interface/spec/lib/go/csi"
 	"github.com/dell/gofsutil"
 	"github.com/dell/goscaleio"
+	siotypes "github.com/dell/goscaleio/types/v1"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/codes"
@@ -162,7 +163,7 @@ func (s *service) NodePublishVolume(
 	Log.Printf("[NodePublishVolume] systemID: %s harvested from csiVolID: %s", systemID, csiVolID)
 	if systemID == "" {
 		// use default system
-		systemID = s.opts.defaultSystemID
+		systemID = s.defaultSystemID()
 	}
 	if systemID == "" {
 		return nil, status.Error(codes.InvalidArgument,
@@ -176,7 +177,7 @@ func (s *service) NodePublishVolume(
 	}
 
 	// ensure no ambiguity if legacy vol
-	err := s.checkVolumesMap(csiVolID)
+	err := s.checkVolumesMap(ctx, csiVolID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal,
 			"checkVolumesMap for id: %s failed : %s", csiVolID, err.Error())
@@ -185,7 +186,7 @@ func (s *service) NodePublishVolume(
 	if isNFS {
 		fsID := getFilesystemIDFromCsiVolumeID(csiVolID)
 
-		fs, err := s.getFilesystemByID(fsID, systemID)
+		fs, err := s.getFilesystemByID(ctx, fsID, systemID)
 		if err != nil {
 			if strings.EqualFold(err.Error(), sioGatewayFileSystemNotFound) || strings.Contains(err.Error(), "must be a hexadecimal number") {
 				return nil, status.Error(codes.NotFound,
@@ -193,14 +194,14 @@ func (s *service) NodePublishVolume(
 			}
 		}
 
-		client := s.adminClients[systemID]
+		client := s.adminClientOf(systemID)
 
-		NFSExport, err := s.getNFSExport(fs, client)
+		NFSExport, err := s.getNFSExport(ctx, systemID, fs, client)
 		if err != nil {
 			return nil, err
 		}
 
-		fileInterface, err := s.getFileInterface(systemID, fs, client)
+		fileInterface, err := s.getFileInterface(ctx, systemID, fs, client)
 		if err != nil {
 			return nil, err
 		}
@@ -209,7 +210,12 @@ func (s *service) NodePublishVolume(
 		// NFSExportURL = 10.1.1.1.1:/nfs-volume
 		path := fmt.Sprintf("%s:%s", fileInterface.IPAddress, NFSExport.Path)
 
-		if err := publishNFS(ctx, req, path); err != nil {
+		mntOptions, err := s.getNFSMountOptions(ctx, req.GetVolumeCapability().GetMount().GetMountFlags(), systemID, fs)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := publishNFS(ctx, req, path, mntOptions); err != nil {
 			return nil, err
 		}
 
@@ -254,7 +260,6 @@ func (s *service) NodeUnpublishVolume(
 			"volume ID is required")
 	}
 
-	isNFS := strings.Contains(csiVolID, "/")
 	var ephemeralVolume bool
 	// For ephemeral volumes, kubernetes gives us an internal ID, so we need to use the lockfile to find the Powerflex ID this is mapped to.
 	lockFile := ephemeralStagingMountPath + csiVolID + "/id"
@@ -273,6 +278,7 @@ func (s *service) NodeUnpublishVolume(
 
 	}
 
+	isNFS := strings.Contains(csiVolID, "/")
 	if isNFS {
 		fsID := getFilesystemIDFromCsiVolumeID(csiVolID)
 		Log.Printf("NodeUnpublishVolume fileSystemID: %s", fsID)
@@ -280,7 +286,7 @@ func (s *service) NodeUnpublishVolume(
 		systemID := s.getSystemIDFromCsiVolumeID(csiVolID)
 		if systemID == "" {
 			// use default system
-			systemID = s.opts.defaultSystemID
+			systemID = s.defaultSystemID()
 		}
 		Log.Printf("NodeUnpublishVolume systemID: %s", systemID)
 		if systemID == "" {
@@ -288,9 +294,17 @@ func (s *service) NodeUnpublishVolume(
 				"systemID is not found in the request and there is no default system")
 		}
 
-		fs, err := s.getFilesystemByID(fsID, systemID)
+		fs, err := s.getFilesystemByID(ctx, fsID, systemID)
 		if err != nil {
 			if strings.EqualFold(err.Error(), sioGatewayFileSystemNotFound) || strings.Contains(err.Error(), "must be a hexadecimal number") {
+				// an inline ephemeral filesystem may already be deleted, only the lockfile is left to clean up
+				if ephemeralVolume {
+					Log.Infof("Inline ephemeral filesystem %s not found, cleaning up", fsID)
+					if err := s.ephemeralNodeUnpublish(ctx, req); err != nil {
+						return nil, err
+					}
+					return &csi.NodeUnpublishVolumeResponse{}, nil
+				}
 				return nil, status.Error(codes.NotFound,
 					"filesystem not found")
 			}
@@ -302,7 +316,7 @@ func (s *service) NodeUnpublishVolume(
 		}
 
 		// ensure no ambiguity if legacy vol
-		err = s.checkVolumesMap(csiVolID)
+		err = s.checkVolumesMap(ctx, csiVolID)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal,
 				"checkVolumesMap for id: %s failed : %s", csiVolID, err.Error())
@@ -312,6 +326,15 @@ func (s *service) NodeUnpublishVolume(
 			return nil, err
 		}
 
+		if ephemeralVolume {
+			Log.Info("Detected ephemeral")
+			err := s.ephemeralNodeUnpublish(ctx, req)
+			if err != nil {
+				Log.Errorf("ephemeralNodeUnpublish returned error: %v", err)
+				return nil, err
+			}
+		}
+
 		return &csi.NodeUnpublishVolumeResponse{}, nil
 
 	}
@@ -322,7 +345,7 @@ func (s *service) NodeUnpublishVolume(
 	systemID := s.getSystemIDFromCsiVolumeID(csiVolID)
 	if systemID == "" {
 		// use default system
-		systemID = s.opts.defaultSystemID
+		systemID = s.defaultSystemID()
 	}
 	Log.Printf("NodeUnpublishVolume systemID: %s", systemID)
 	if systemID == "" {
@@ -336,7 +359,7 @@ func (s *service) NodeUnpublishVolume(
 	}
 
 	// ensure no ambiguity if legacy vol
-	err := s.checkVolumesMap(csiVolID)
+	err := s.checkVolumesMap(ctx, csiVolID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal,
 			"checkVolumesMap for id: %s failed : %s", csiVolID, err.Error())
@@ -395,7 +418,7 @@ func (s *service) getSDCMappedVol(volumeID string, systemID string, maxRetry int
 	var sdcMappedVol *goscaleio.SdcMappedVolume
 	var err error
 	for i := 0; i < maxRetry; i++ {
-		if id, ok := s.connectedSystemNameToID[systemID]; ok {
+		if id, ok := s.systemIDByName(systemID); ok {
 			Log.Printf("Node publish getMappedVol name: %s id: %s", systemID, id)
 			systemID = id
 		}
@@ -437,8 +460,8 @@ func getMappedVol(volID string, systemID string) (*goscaleio.SdcMappedVolume, er
 
 // getSystemName gets the system name for each system and append it to connectedSystemID variable
 func (s *service) getSystemName(_ context.Context, systems []string) bool {
-	for systemID := range s.opts.arrays {
-		if id, ok := s.connectedSystemNameToID[systemID]; ok {
+	for systemID := range s.arrayConfigs() {
+		if id, ok := s.systemIDByName(systemID); ok {
 			for _, system := range systems {
 				if id == system {
 					Log.Printf("nodeProbe found system Name: %s with id %s", systemID, id)
@@ -486,17 +509,18 @@ func (s *service) nodeProbe(ctx context.Context) error {
 		case1: if IsSdcRenameEnabled=true and prefix given then set the prefix+worker_node_name for sdc name.
 		case2: if IsSdcRenameEnabled=true and prefix not given then set worker_node_name for sdc name.
 	*/
-	if s.opts.IsSdcRenameEnabled {
-		err = s.renameSDC(s.opts)
+	reloadable := s.reloadable()
+	if reloadable.IsSdcRenameEnabled {
+		err = s.renameSDC(ctx, reloadable.SdcPrefix)
 		if err != nil {
 			return err
 		}
 	}
 
 	// support for pre-approved guid
-	if s.opts.IsApproveSDCEnabled {
+	if reloadable.IsApproveSDCEnabled {
 		Log.Infof("Approve SDC enabled")
-		if err := s.approveSDC(s.opts); err != nil {
+		if err := s.approveSDC(ctx); err != nil {
 			return err
 		}
 	}
@@ -514,16 +538,20 @@ func (s *service) nodeProbe(ctx context.Context) error {
 	return nil
 }
 
-func (s *service) approveSDC(opts Opts) error {
+func (s *service) approveSDC(ctx context.Context) error {
 	for _, systemID := range connectedSystemID {
-		system := s.systems[systemID]
+		system := s.systemOf(systemID)
 
 		if system == nil {
 			continue
 		}
 
 		// fetch SDC details
-		sdc, err := s.systems[systemID].FindSdc("SdcGUID", opts.SdcGUID)
+		var sdc *goscaleio.Sdc
+		err := s.gatewayCall(ctx, systemID, "FindSdc", func() (err error) {
+			sdc, err = s.systemOf(systemID).FindSdc("SdcGUID", s.opts.SdcGUID)
+			return err
+		})
 		if err != nil {
 			return status.Errorf(codes.FailedPrecondition, "%s", err)
 		}
@@ -531,7 +559,11 @@ func (s *service) approveSDC(opts Opts) error {
 		// fetch the restrictedSdcMode
 		if system.System.RestrictedSdcMode == "Guid" {
 			if !sdc.Sdc.SdcApproved {
-				resp, err := system.ApproveSdcByGUID(sdc.Sdc.SdcGUID)
+				var resp *siotypes.ApproveSdcByGUIDResponse
+				err := s.gatewayCall(ctx, systemID, "ApproveSdcByGUID", func() (err error) {
+					resp, err = system.ApproveSdcByGUID(sdc.Sdc.SdcGUID)
+					return err
+				})
 				if err != nil {
 					return status.Errorf(codes.FailedPrecondition, "%s", err)
 				}
@@ -553,7 +585,7 @@ func (s *service) approveSDC(opts Opts) error {
 	return nil
 }
 
-func (s *service) renameSDC(opts Opts) error {
+func (s *service) renameSDC(ctx context.Context, sdcPrefix string) error {
 	// fetch hostname
 	hostName, ok := os.LookupEnv("HOSTNAME")
 	if !ok {
@@ -562,19 +594,23 @@ func (s *service) renameSDC(opts Opts) error {
 
 	// fetch SDC details
 	for _, systemID := range connectedSystemID {
-		if s.systems[systemID] == nil {
+		if s.systemOf(systemID) == nil {
 			continue
 		}
-		sdc, err := s.systems[systemID].FindSdc("SdcGUID", opts.SdcGUID)
+		var sdc *goscaleio.Sdc
+		err := s.gatewayCall(ctx, systemID, "FindSdc", func() (err error) {
+			sdc, err = s.systemOf(systemID).FindSdc("SdcGUID", s.opts.SdcGUID)
+			return err
+		})
 		if err != nil {
 			return status.Errorf(codes.FailedPrecondition, "%s", err)
 		}
 		sdcID := sdc.Sdc.ID
 
 		var newName string
-		if len(opts.SdcPrefix) > 0 {
+		if len(sdcPrefix) > 0 {
 			// case1: if IsSdcRenameEnabled=true and prefix given then set the prefix+worker_node_name for sdc name.
-			newName = opts.SdcPrefix + "-" + hostName
+			newName = sdcPrefix + "-" + hostName
 		} else {
 			// case2: if IsSdcRenameEnabled=true and prefix not given then set worker_node_name for sdc name.
 			newName = hostName
@@ -584,11 +620,13 @@ func (s *service) renameSDC(opts Opts) error {
 		} else {
 			Log.Infof("Assigning name: %s to SDC with GUID %s on system %s", newName, s.opts.SdcGUID,
 				systemID)
-			err = s.adminClients[systemID].RenameSdc(sdcID, newName)
+			err = s.gatewayCall(ctx, systemID, "RenameSdc", func() error {
+				return s.adminClientOf(systemID).RenameSdc(sdcID, newName)
+			})
 			if err != nil {
 				return status.Errorf(codes.FailedPrecondition, "Failed to rename SDC: %s", err)
 			}
-			err = s.getSDCName(opts.SdcGUID, systemID)
+			err = s.getSDCName(ctx, s.opts.SdcGUID, systemID)
 			if err != nil {
 				return err
 			}
@@ -597,8 +635,12 @@ func (s *service) renameSDC(opts Opts) error {
 	return nil
 }
 
-func (s *service) getSDCName(sdcGUID string, systemID string) error {
-	sdc, err := s.systems[systemID].FindSdc("SdcGUID", sdcGUID)
+func (s *service) getSDCName(ctx context.Context, sdcGUID string, systemID string) error {
+	var sdc *goscaleio.Sdc
+	err := s.gatewayCall(ctx, systemID, "FindSdc", func() (err error) {
+		sdc, err = s.systemOf(systemID).FindSdc("SdcGUID", sdcGUID)
+		return err
+	})
 	if err != nil {
 		return status.Errorf(codes.FailedPrecondition, "%s", err)
 	}
@@ -763,10 +805,11 @@ func (s *service) NodeGetInfo(
 		} else {
 			// As per the csi spec the plugin MUST NOT set negative values to
 			// 'MaxVolumesPerNode' in the NodeGetInfoResponse response
-			if s.opts.MaxVolumesPerNode < 0 {
+			maxVolumesPerNode := s.reloadable().MaxVolumesPerNode
+			if maxVolumesPerNode < 0 {
 				return nil, status.Error(codes.InvalidArgument, GetMessage("maxVxflexosVolumesPerNode MUST NOT be set to negative value"))
 			}
-			maxVxflexosVolumesPerNode = s.opts.MaxVolumesPerNode
+			maxVxflexosVolumesPerNode = maxVolumesPerNode
 		}
 	}
 
@@ -809,7 +852,7 @@ func (s *service) NodeGetVolumeStats(ctx context.Context, req *csi.NodeGetVolume
 
 	if systemID == "" {
 		// use default system
-		systemID = s.opts.defaultSystemID
+		systemID = s.defaultSystemID()
 	}
 
 	if systemID == "" {
@@ -820,7 +863,7 @@ func (s *service) NodeGetVolumeStats(ctx context.Context, req *csi.NodeGetVolume
 	_, err := s.getSDCMappedVol(volID, systemID, 30)
 	if err != nil {
 		// volume not known to SDC, next check if it exists at all
-		_, _, err := s.listVolumes(systemID, 0, 0, false, false, volID, "")
+		_, _, err := s.listVolumes(ctx, systemID, 0, 0, false, false, volID, "")
 		if err != nil && strings.Contains(err.Error(), sioGatewayVolumeNotFound) {
 			message = fmt.Sprintf("Volume is not found by node driver at %s", time.Now().Format("2006-01-02 15:04:05"))
 		} else if err != nil {
@@ -962,7 +1005,7 @@ func (s *service) NodeExpandVolume(ctx context.Context, req *csi.NodeExpandVolum
 			"volume ID is required")
 	}
 	// ensure no ambiguity if legacy vol
-	err = s.checkVolumesMap(csiVolID)
+	err = s.checkVolumesMap(ctx, csiVolID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal,
 			"checkVolumesMap for id: %s failed : %s", csiVolID, err.Error())
@@ -979,7 +1022,7 @@ func (s *service) NodeExpandVolume(ctx context.Context, req *csi.NodeExpandVolum
 	systemID := s.getSystemIDFromCsiVolumeID(csiVolID)
 	if systemID == "" {
 		// use default system
-		systemID = s.opts.defaultSystemID
+		systemID = s.defaultSystemID()
 	}
 	Log.Printf("NodeExpandVolume systemID: %s", systemID)
 	if systemID == "" {
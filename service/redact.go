@@ -0,0 +1,191 @@
+// Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// ParamLogRedactKeys is a comma separated list of regular expressions matching the keys whose values are
+	// masked in the logs, in addition to defaultLogRedactKeys
+	ParamLogRedactKeys = "CSI_LOG_REDACT_KEYS"
+
+	// redactedValue replaces the masked values
+	redactedValue = "********"
+)
+
+// defaultLogRedactKeys match the keys of credentials, secrets and tokens
+var defaultLogRedactKeys = []string{
+	"password", "passwd", "secret", "token", "credential", "private[-_]?key", "api[-_]?key", "authorization",
+}
+
+// logRedactor masks the sensitive values in the driver logs and in the request and response logs of gocsi
+var logRedactor = newRedactor()
+
+// redactor masks the values of the keys matching its patterns
+type redactor struct {
+	mu sync.RWMutex
+	// keys matches a whole sensitive key
+	keys *regexp.Regexp
+	// pairs matches a sensitive key and its value in a log line, as key=value, key: value, [key]=value,
+	// "key":"value" or map[key:value]; the value may be a quoted string, a Go map or a text proto message
+	pairs *regexp.Regexp
+	// entries matches a sensitive map entry of a text proto message, as key:"key" value:"value"
+	entries *regexp.Regexp
+}
+
+// newRedactor returns a redactor of the default sensitive keys
+func newRedactor() *redactor {
+	r := &redactor{}
+	if err := r.setPatterns(defaultLogRedactKeys); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// setPatterns replaces the patterns of the sensitive keys, leaving the current patterns when one does not compile
+func (r *redactor) setPatterns(patterns []string) error {
+	alternatives := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid log redaction pattern %q: %v", pattern, err)
+		}
+		alternatives = append(alternatives, pattern)
+	}
+	if len(alternatives) == 0 {
+		return fmt.Errorf("no log redaction pattern given")
+	}
+
+	key := `[\w./-]*(?:` + strings.Join(alternatives, "|") + `)[\w./-]*`
+	value := `(?:"(?:[^"\\]|\\.)*"|map\[[^\]]*\]|<[^>]*>|\{[^}]*\}|[^\s,\]}>"]+)`
+	keys := regexp.MustCompile(`(?i)^` + key + `$`)
+	pairs := regexp.MustCompile(`(?i)(` + key + `"?\]?\s*(?:=|:)\s*)` + value)
+	entries := regexp.MustCompile(`(?i)(key:\s*"` + key + `"\s+value:\s*)"(?:[^"\\]|\\.)*"`)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys, r.pairs, r.entries = keys, pairs, entries
+	return nil
+}
+
+// isSensitive returns true when the values of key are masked
+func (r *redactor) isSensitive(key string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.keys.MatchString(key)
+}
+
+// redactValue returns value, or the mask when key is sensitive
+func (r *redactor) redactValue(key, value string) string {
+	if r.isSensitive(key) {
+		return redactedValue
+	}
+	return value
+}
+
+// redactText masks the values of the sensitive keys in a log line
+func (r *redactor) redactText(text string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	text = r.entries.ReplaceAllString(text, `${1}"`+redactedValue+`"`)
+	return r.pairs.ReplaceAllString(text, "${1}"+redactedValue)
+}
+
+// redactMessage returns a copy of a CSI or extension message whose string maps have the values of the sensitive
+// keys masked, every value being masked when the name of the map is sensitive, as for the CSI secrets
+func (r *redactor) redactMessage(msg protoadapt.MessageV1) protoadapt.MessageV1 {
+	if msg == nil {
+		return nil
+	}
+	clone := proto.Clone(protoadapt.MessageV2Of(msg))
+	r.redactFields(clone.ProtoReflect())
+	return protoadapt.MessageV1Of(clone)
+}
+
+// redactFields masks the sensitive values of the string maps of m and of its nested messages
+func (r *redactor) redactFields(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap() && fd.MapKey().Kind() == protoreflect.StringKind && fd.MapValue().Kind() == protoreflect.StringKind:
+			all := r.isSensitive(string(fd.Name()))
+			entries := v.Map()
+			entries.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+				if all || r.isSensitive(k.String()) {
+					entries.Set(k, protoreflect.ValueOfString(redactedValue))
+				}
+				return true
+			})
+		case fd.IsList() && fd.Kind() == protoreflect.MessageKind:
+			for i := 0; i < v.List().Len(); i++ {
+				r.redactFields(v.List().Get(i).Message())
+			}
+		case !fd.IsMap() && !fd.IsList() && fd.Kind() == protoreflect.MessageKind:
+			r.redactFields(v.Message())
+		}
+		return true
+	})
+}
+
+// redactingFormatter masks the sensitive values of the log entries before formatting them
+type redactingFormatter struct {
+	logrus.Formatter
+}
+
+// Format formats a copy of entry whose message and fields have the sensitive values masked
+func (f *redactingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	redacted := *entry
+	redacted.Message = logRedactor.redactText(entry.Message)
+	redacted.Data = make(logrus.Fields, len(entry.Data))
+	for key, value := range entry.Data {
+		switch v := value.(type) {
+		case string:
+			redacted.Data[key] = logRedactor.redactText(logRedactor.redactValue(key, v))
+		case error:
+			redacted.Data[key] = logRedactor.redactText(v.Error())
+		default:
+			if logRedactor.isSensitive(key) {
+				redacted.Data[key] = redactedValue
+			} else {
+				redacted.Data[key] = value
+			}
+		}
+	}
+	return f.Formatter.Format(&redacted)
+}
+
+// setRedactingFormatter formats the entries of logger with formatter after masking their sensitive values
+func setRedactingFormatter(logger *logrus.Logger, formatter logrus.Formatter) {
+	if _, ok := formatter.(*redactingFormatter); !ok {
+		formatter = &redactingFormatter{Formatter: formatter}
+	}
+	logger.SetFormatter(formatter)
+}
+
+// redactRequest returns req with the sensitive values masked, for logging
+func redactRequest(req protoadapt.MessageV1) protoadapt.MessageV1 {
+	return logRedactor.redactMessage(req)
+}
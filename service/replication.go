@@ -8,6 +8,7 @@ import (
 	"time"
 
 	csi "github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/dell/csi-vxflexos/v2/replicationext"
 	"github.com/dell/dell-csi-extensions/replication"
 	"github.com/dell/goscaleio"
 	"golang.org/x/net/context"
@@ -32,9 +33,18 @@ const (
 	KeyReplicationClusterID = "remoteClusterID"
 	// KeyReplicationVGPrefix represents key for replication vg prefix
 	KeyReplicationVGPrefix = "volumeGroupPrefix"
+	// KeyReplicationEnabled represents key for replication enabled
+	KeyReplicationEnabled = "isReplicationEnabled"
 
 	sioReplicationPairsDoesNotExist = "Error in get relationship ReplicationPair"
 	sioReplicationGroupNotFound     = "The Replication Consistency Group was not found"
+
+	// rcgTestFailover is the failover type of a replication consistency group in test failover
+	rcgTestFailover = "TestFailover"
+
+	// nfsReplicationNotSupported is returned for NFS volumes, the PowerFlex gateway API used
+	// by the driver only replicates block volumes through replication consistency groups
+	nfsReplicationNotSupported = "replication is not supported for NFS volumes"
 )
 
 var (
@@ -122,12 +132,22 @@ func (s *service) GetReplicationCapabilities(_ context.Context, _ *replication.G
 				Type: replication.ActionTypes_ABORT_SNAPSHOT,
 			},
 		},
+		{
+			Actions: &replication.SupportedActions_Type{
+				Type: replication.ActionTypes_TEST_FAILOVER,
+			},
+		},
+		{
+			Actions: &replication.SupportedActions_Type{
+				Type: replication.ActionTypes_TEST_FAILOVER_STOP,
+			},
+		},
 	}
 	return rep, nil
 }
 
 func (s *service) CreateRemoteVolume(ctx context.Context, req *replication.CreateRemoteVolumeRequest) (*replication.CreateRemoteVolumeResponse, error) {
-	Log.Printf("[CreateRemoteVolume] - req %+v", req)
+	Log.Printf("[CreateRemoteVolume] - req %+v", redactRequest(req))
 
 	volHandleCtx := req.GetVolumeHandle()
 	parameters := req.GetParameters()
@@ -145,16 +165,20 @@ func (s *service) CreateRemoteVolume(ctx context.Context, req *replication.Creat
 		return nil, status.Error(codes.InvalidArgument, "failed to provide system ID or volume ID")
 	}
 
+	if strings.Contains(volHandleCtx, "/") {
+		return nil, status.Errorf(codes.Unimplemented, "%s: %s", nfsReplicationNotSupported, volHandleCtx)
+	}
+
 	if err := s.requireProbe(ctx, systemID); err != nil {
 		return nil, err
 	}
 
-	vol, err := s.getVolByID(volumeID, systemID)
+	vol, err := s.getVolByID(ctx, volumeID, systemID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "can't query volume: %s", err.Error())
 	}
 
-	_, err = s.getSystem(systemID)
+	_, err = s.getSystem(ctx, systemID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "could not get (local) system %s: %s", systemID, err.Error())
 	}
@@ -164,22 +188,6 @@ func (s *service) CreateRemoteVolume(ctx context.Context, req *replication.Creat
 		return nil, status.Errorf(codes.InvalidArgument, "replication enabled but no remote system specified in storage class")
 	}
 
-	// Probe the remote system
-	if err := s.requireProbe(ctx, remoteSystemID); err != nil {
-		Log.Infof("Remote probe failed: %s", err)
-		return nil, err
-	}
-
-	remoteSystem, err := s.getSystem(remoteSystemID)
-	if err != nil {
-		return nil, err
-	}
-
-	_, err = s.getPeerMdms(systemID)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "can't getPeerMDMs: %s", err.Error())
-	}
-
 	remoteStoragePool, ok := parameters[s.WithRP(KeyReplicationRemoteStoragePool)]
 	if !ok {
 		return nil, status.Errorf(codes.InvalidArgument, "replication enabled but no remote storage pool specified in storage class")
@@ -190,6 +198,16 @@ func (s *service) CreateRemoteVolume(ctx context.Context, req *replication.Creat
 		log.Printf("Remote protection domain not provided; there could be conflicts if two storage pools share a name")
 	}
 
+	if err := s.checkReplicationPeer(ctx, systemID, remoteSystemID, protectionDomain, remoteStoragePool); err != nil {
+		Log.Infof("Remote preflight failed: %s", err)
+		return nil, err
+	}
+
+	remoteSystem, err := s.getSystem(ctx, remoteSystemID)
+	if err != nil {
+		return nil, err
+	}
+
 	name := "replicated-" + vol.Name
 	volReq := createRemoteCreateVolumeRequest(name, remoteStoragePool, remoteSystem.ID, protectionDomain, int64(vol.SizeInKb))
 
@@ -216,7 +234,7 @@ func (s *service) CreateRemoteVolume(ctx context.Context, req *replication.Creat
 
 // DeleteLocalVolume deletes the backend volume on the storage array.
 func (s *service) DeleteLocalVolume(ctx context.Context, req *replication.DeleteLocalVolumeRequest) (*replication.DeleteLocalVolumeResponse, error) {
-	Log.Printf("[DeleteLocalVolume] - req %+v", req)
+	Log.Printf("[DeleteLocalVolume] - req %+v", redactRequest(req))
 
 	volHandleCtx := req.GetVolumeHandle()
 
@@ -233,7 +251,11 @@ func (s *service) DeleteLocalVolume(ctx context.Context, req *replication.Delete
 		return nil, status.Error(codes.InvalidArgument, "failed to provide system ID or volume ID")
 	}
 
-	vol, err := s.getVolByID(volumeID, systemID)
+	if strings.Contains(volHandleCtx, "/") {
+		return nil, status.Errorf(codes.Unimplemented, "%s: %s", nfsReplicationNotSupported, volHandleCtx)
+	}
+
+	vol, err := s.getVolByID(ctx, volumeID, systemID)
 	if err != nil {
 		if strings.EqualFold(err.Error(), sioGatewayVolumeNotFound) {
 			log.Printf("[DeleteLocalVolume] - volume already deleted.")
@@ -257,8 +279,112 @@ func (s *service) DeleteLocalVolume(ctx context.Context, req *replication.Delete
 	return &replication.DeleteLocalVolumeResponse{}, nil
 }
 
+// RemoveVolumeFromProtectionGroup stops replicating a volume while keeping the volume and its data. The replication
+// pair is removed, the remote copy is deleted when requested, and the replication consistency group is deleted once
+// it has no pairs left. The volume is returned as a non-replicated volume.
+//
+// The remote volume and the group are taken from the pair, or from the request once the pair is gone, so that a
+// retry after a failed remote delete or group delete finishes the work.
+func (s *service) RemoveVolumeFromProtectionGroup(ctx context.Context, req *replicationext.RemoveVolumeFromProtectionGroupRequest) (*replicationext.RemoveVolumeFromProtectionGroupResponse, error) {
+	Log.Printf("[RemoveVolumeFromProtectionGroup] - req %+v", redactRequest(req))
+
+	volHandleCtx := req.GetVolumeHandle()
+	remoteSystemID := req.GetRemoteSystemId()
+	deleteRemote := req.GetDeleteRemoteVolume()
+	remoteVolumeID := getVolumeIDFromCsiVolumeID(req.GetRemoteVolumeHandle())
+	groupID := req.GetProtectionGroupId()
+
+	if volHandleCtx == "" {
+		return nil, status.Error(codes.InvalidArgument, "volume ID is required")
+	}
+
+	volumeID := getVolumeIDFromCsiVolumeID(volHandleCtx)
+	systemID := s.getSystemIDFromCsiVolumeID(volHandleCtx)
+
+	if volumeID == "" || systemID == "" {
+		return nil, status.Error(codes.InvalidArgument, "failed to provide system ID or volume ID")
+	}
+
+	if strings.Contains(volHandleCtx, "/") {
+		return nil, status.Errorf(codes.Unimplemented, "%s: %s", nfsReplicationNotSupported, volHandleCtx)
+	}
+
+	if deleteRemote && remoteSystemID == "" {
+		return nil, status.Error(codes.InvalidArgument, "remote system ID is required to delete the remote volume")
+	}
+
+	if err := s.requireProbe(ctx, systemID); err != nil {
+		return nil, err
+	}
+
+	vol, err := s.getVolByID(ctx, volumeID, systemID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't query volume: %s", err.Error())
+	}
+
+	if vol.VolumeReplicationState != "UnmarkedForReplication" {
+		pair, err := s.findReplicationPairByVolID(ctx, systemID, volumeID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "can't query replication pair: %s", err.Error())
+		}
+		remoteVolumeID = pair.RemoteVolumeID
+		groupID = pair.ReplicationConsistencyGroupID
+
+		if _, err := s.removeVolumeFromReplicationPair(ctx, systemID, volumeID); err != nil {
+			return nil, status.Errorf(codes.Internal, "error removing replication pair: %s", err.Error())
+		}
+		Log.Printf("[RemoveVolumeFromProtectionGroup] - Removed Pair: %+v", pair)
+	}
+
+	if deleteRemote {
+		if remoteVolumeID == "" {
+			return nil, status.Error(codes.InvalidArgument, "remote volume ID is required to delete the remote volume of a volume that is no longer replicated")
+		}
+		if err := s.requireProbe(ctx, remoteSystemID); err != nil {
+			return nil, err
+		}
+		_, err := s.DeleteVolume(ctx, &csi.DeleteVolumeRequest{VolumeId: remoteSystemID + "-" + remoteVolumeID})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error deleting remote volume: %s", err.Error())
+		}
+	}
+
+	if groupID != "" {
+		pairs, err := s.getReplicationPairs(ctx, systemID, groupID)
+		if err != nil && !strings.EqualFold(err.Error(), sioReplicationGroupNotFound) {
+			return nil, status.Errorf(codes.Internal, "can't query replication pairs: %s", err.Error())
+		}
+		if err == nil && len(pairs) == 0 {
+			_, err := s.DeleteStorageProtectionGroup(ctx, &replication.DeleteStorageProtectionGroupRequest{
+				ProtectionGroupId: groupID,
+				ProtectionGroupAttributes: map[string]string{
+					s.opts.replicationContextPrefix + "systemName": systemID,
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	s.clearCache()
+	vol, err = s.getVolByID(ctx, volumeID, systemID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't query volume: %s", err.Error())
+	}
+
+	csiVolume := s.getCSIVolume(ctx, vol, systemID)
+	return &replicationext.RemoveVolumeFromProtectionGroupResponse{
+		Volume: &replication.Volume{
+			VolumeId:      csiVolume.GetVolumeId(),
+			CapacityBytes: csiVolume.GetCapacityBytes(),
+			VolumeContext: csiVolume.GetVolumeContext(),
+		},
+	}, nil
+}
+
 func (s *service) CreateStorageProtectionGroup(ctx context.Context, req *replication.CreateStorageProtectionGroupRequest) (*replication.CreateStorageProtectionGroupResponse, error) {
-	Log.Printf("[CreateStorageProtectionGroup] - req %+v", req)
+	Log.Printf("[CreateStorageProtectionGroup] - req %+v", redactRequest(req))
 
 	volHandleCtx := req.GetVolumeHandle()
 	if volHandleCtx == "" {
@@ -277,16 +403,31 @@ func (s *service) CreateStorageProtectionGroup(ctx context.Context, req *replica
 		return nil, status.Error(codes.InvalidArgument, "failed to provide system ID or volume ID")
 	}
 
+	if strings.Contains(volHandleCtx, "/") {
+		return nil, status.Errorf(codes.Unimplemented, "%s: %s", nfsReplicationNotSupported, volHandleCtx)
+	}
+
 	if err := s.requireProbe(ctx, systemID); err != nil {
 		return nil, err
 	}
 
-	localSystem, err := s.getSystem(systemID)
+	localSystem, err := s.getSystem(ctx, systemID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "couldn't getSystem (local): %s", err.Error())
 	}
 
-	localProtectionDomain, err := s.getProtectionDomain(systemID, parameters[KeyProtectionDomain])
+	vol, err := s.getVolByID(ctx, volumeID, systemID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't query volume: %s", err.Error())
+	}
+
+	// A volume added to replication after it was provisioned is grouped in the protection domain of its storage pool
+	var localProtectionDomain string
+	if pdName := parameters[KeyProtectionDomain]; pdName != "" {
+		localProtectionDomain, err = s.getProtectionDomain(ctx, systemID, pdName)
+	} else {
+		localProtectionDomain, err = s.getVolumeProtectionDomain(ctx, systemID, vol)
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "couldn't getProtectionDomain (local): %s", err.Error())
 	}
@@ -298,19 +439,19 @@ func (s *service) CreateStorageProtectionGroup(ctx context.Context, req *replica
 		return nil, status.Errorf(codes.InvalidArgument, "replication enabled but no remote system specified in storage class")
 	}
 
-	remoteSystem, err := s.getSystem(remoteSystemID)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "couldn't getSystem (remote): %s", err.Error())
+	if err := s.checkReplicationPeer(ctx, systemID, remoteSystemID,
+		parameters[s.WithRP(KeyReplicationProtectionDomain)], parameters[s.WithRP(KeyReplicationRemoteStoragePool)]); err != nil {
+		return nil, err
 	}
 
-	remoteProtectionDomain, err := s.getProtectionDomain(remoteSystemID, parameters[s.WithRP(KeyReplicationProtectionDomain)])
+	remoteSystem, err := s.getSystem(ctx, remoteSystemID)
 	if err != nil {
-		return nil, err
+		return nil, status.Errorf(codes.Internal, "couldn't getSystem (remote): %s", err.Error())
 	}
 
-	_, err = s.getPeerMdms(systemID)
+	remoteProtectionDomain, err := s.getProtectionDomain(ctx, remoteSystemID, parameters[s.WithRP(KeyReplicationProtectionDomain)])
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "can't query peer mdms: %s", err.Error())
+		return nil, err
 	}
 
 	rpo, ok := parameters[s.WithRP(KeyReplicationRPO)]
@@ -318,6 +459,20 @@ func (s *service) CreateStorageProtectionGroup(ctx context.Context, req *replica
 		return nil, status.Errorf(codes.InvalidArgument, "replication enabled but no RPO specified in storage class")
 	}
 
+	// A volume that is already replicated keeps its replication pair and group
+	if vol.VolumeReplicationState == "Replicated" {
+		pair, err := s.findReplicationPairByVolID(ctx, systemID, vol.ID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "can't query replication pairs: %s", err.Error())
+		}
+		group, err := s.getReplicationConsistencyGroupByID(ctx, systemID, pair.ReplicationConsistencyGroupID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "No replication consistency groups found: %s", err.Error())
+		}
+		Log.Printf("[CreateStorageProtectionGroup] - volume %s is already replicated in group %s", vol.ID, group.ID)
+		return s.getStorageProtectionGroupResponse(localSystem.ID, remoteSystem.ID, group), nil
+	}
+
 	var consistencyGroupName string
 	if name, ok := parameters[s.WithRP(KeyReplicationConsistencyGroupName)]; ok {
 		consistencyGroupName = name
@@ -341,7 +496,7 @@ func (s *service) CreateStorageProtectionGroup(ctx context.Context, req *replica
 			rcgPrefix = "rcg"
 		}
 
-		consistencyGroupName, err = s.createUniqueConsistencyGroupName(systemID, rpo,
+		consistencyGroupName, err = s.createUniqueConsistencyGroupName(ctx, systemID,
 			localProtectionDomain, remoteProtectionDomain, remoteClusterID, clusterUID, rcgPrefix)
 		if err != nil {
 			return nil, err
@@ -349,87 +504,99 @@ func (s *service) CreateStorageProtectionGroup(ctx context.Context, req *replica
 		Log.Printf("[CreateStorageProtectionGroup] - consistencyGroupName: %+s", consistencyGroupName)
 	}
 
-	localRcg, err := s.CreateReplicationConsistencyGroup(systemID, consistencyGroupName,
+	localRcg, created, err := s.CreateReplicationConsistencyGroup(ctx, systemID, consistencyGroupName,
 		rpo, localProtectionDomain, remoteProtectionDomain, "", remoteSystem.ID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "invalid rcg response: %s", err.Error())
 	}
 
-	vol, err := s.getVolByID(volumeID, systemID)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "can't query volume: %s", err.Error())
-	}
-
 	remoteVolumeName := "replicated-" + vol.Name
 
-	if err := s.requireProbe(ctx, remoteSystem.ID); err != nil {
-		return nil, status.Errorf(codes.Internal, "can't probe remote system: %s", err.Error())
-	}
-
-	adminClient := s.adminClients[remoteSystem.ID]
+	adminClient := s.adminClientOf(remoteSystem.ID)
 	if adminClient == nil {
 		return nil, fmt.Errorf("can't find adminClient by id %s", systemID)
 	}
 
-	remoteVolumeID, err := adminClient.FindVolumeID(remoteVolumeName)
+	var remoteVolumeID string
+	err = s.gatewayCall(ctx, remoteSystem.ID, "FindVolumeID", func() (err error) {
+		remoteVolumeID, err = adminClient.FindVolumeID(remoteVolumeName)
+		return err
+	})
 	if err != nil {
+		if created {
+			s.rollbackReplicationConsistencyGroup(ctx, systemID, localRcg.ID)
+		}
 		return nil, status.Errorf(codes.Internal, "can't find volume %s by name: %s", remoteVolumeName, err.Error())
 	}
 
 	replicationPairName := "rp-" + vol.ID[:12] + "-" + remoteVolumeID[:12]
-	_, err = s.CreateReplicationPair(systemID, replicationPairName, vol.ID, remoteVolumeID, localRcg.ID)
+	_, err = s.CreateReplicationPair(ctx, systemID, replicationPairName, vol.ID, remoteVolumeID, localRcg.ID)
 	if err != nil {
+		if created {
+			s.rollbackReplicationConsistencyGroup(ctx, systemID, localRcg.ID)
+		}
 		return nil, status.Errorf(codes.Internal, "can't createReplicationPair: %s", err.Error())
 	}
 
-	group, err := s.getReplicationConsistencyGroupByID(systemID, localRcg.ID)
+	group, err := s.getReplicationConsistencyGroupByID(ctx, systemID, localRcg.ID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "No replication consistency groups found: %s", err.Error())
 	}
 
+	Log.Printf("[CreateStorageProtectionGroup] - localRcg: %+s, group.ID: %s", localRcg.ID, group.ID)
+
+	return s.getStorageProtectionGroupResponse(localSystem.ID, remoteSystem.ID, group), nil
+}
+
+// getStorageProtectionGroupResponse returns the local and remote attributes of a replication consistency group
+func (s *service) getStorageProtectionGroupResponse(localSystemID, remoteSystemID string, group *siotypes.ReplicationConsistencyGroup) *replication.CreateStorageProtectionGroupResponse {
 	localParams := map[string]string{
-		s.opts.replicationContextPrefix + "systemName":     localSystem.ID,
-		s.opts.replicationContextPrefix + "remoteSystemID": remoteSystem.ID,
+		s.opts.replicationContextPrefix + "systemName":     localSystemID,
+		s.opts.replicationContextPrefix + "remoteSystemID": remoteSystemID,
 	}
 
 	remoteParams := map[string]string{
-		s.opts.replicationContextPrefix + "systemName":     remoteSystem.ID,
-		s.opts.replicationContextPrefix + "remoteSystemID": localSystem.ID,
+		s.opts.replicationContextPrefix + "systemName":     remoteSystemID,
+		s.opts.replicationContextPrefix + "remoteSystemID": localSystemID,
 	}
 
-	Log.Printf("[CreateStorageProtectionGroup] - localRcg: %+s, group.ID: %s", localRcg.ID, group.ID)
-
 	return &replication.CreateStorageProtectionGroupResponse{
 		LocalProtectionGroupId:         group.ID,
 		LocalProtectionGroupAttributes: localParams,
 
 		RemoteProtectionGroupId:         group.RemoteID,
 		RemoteProtectionGroupAttributes: remoteParams,
-	}, nil
+	}
+}
+
+func (s *service) GetStorageProtectionGroupStatus(ctx context.Context, req *replication.GetStorageProtectionGroupStatusRequest) (*replication.GetStorageProtectionGroupStatusResponse, error) {
+	Log.Printf("[GetStorageProtectionGroupStatus] - req %+v", redactRequest(req))
+	resp, _, err := s.getStorageProtectionGroupStatus(ctx, req)
+	return resp, err
 }
 
-func (s *service) GetStorageProtectionGroupStatus(_ context.Context, req *replication.GetStorageProtectionGroupStatusRequest) (*replication.GetStorageProtectionGroupStatusResponse, error) {
-	Log.Printf("[GetStorageProtectionGroupStatus] - req %+v", req)
+// getStorageProtectionGroupStatus returns the status of the protection group along with its RCG level attributes
+func (s *service) getStorageProtectionGroupStatus(ctx context.Context, req *replication.GetStorageProtectionGroupStatusRequest) (*replication.GetStorageProtectionGroupStatusResponse, *replicationGroupStatus, error) {
 
 	localParams := req.GetProtectionGroupAttributes()
 
 	protectionGroupSystem, ok := localParams[s.opts.replicationContextPrefix+"systemName"]
 	if !ok {
-		return nil, status.Errorf(codes.InvalidArgument, "Error: can't find `systemName` in replication group")
+		return nil, nil, status.Errorf(codes.InvalidArgument, "Error: can't find `systemName` in replication group")
 	}
 
-	group, err := s.getReplicationConsistencyGroupByID(protectionGroupSystem, req.ProtectionGroupId)
+	group, err := s.getReplicationConsistencyGroupByID(ctx, protectionGroupSystem, req.ProtectionGroupId)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "No replication consistency groups found: %s", err.Error())
+		return nil, nil, status.Errorf(codes.Internal, "No replication consistency groups found: %s", err.Error())
 	}
 
-	pairs, err := s.getReplicationPairs(protectionGroupSystem, req.ProtectionGroupId)
+	pairs, err := s.getReplicationPairs(ctx, protectionGroupSystem, req.ProtectionGroupId)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if len(pairs) == 0 {
-		return nil, status.Errorf(codes.Internal, "no replication pairs exist")
+		return nil, nil, status.Errorf(codes.Internal, "no replication pairs exist")
 	}
 
 	Log.Printf("[GetStorageProtectionGroupStatus] - group %+v", group)
@@ -453,21 +620,26 @@ func (s *service) GetStorageProtectionGroupStatus(_ context.Context, req *replic
 		}
 	}
 
+	groupStatus := s.getReplicationGroupStatus(protectionGroupSystem, group, pairs, time.Now())
+	groupStatus.recordMetrics(protectionGroupSystem, group)
+	s.recordStateTransition(ctx, protectionGroupSystem, group, pairs, state)
+	Log.Printf("[GetStorageProtectionGroupStatus] - state %s attributes %+v", state, groupStatus.attributes())
+
 	return &replication.GetStorageProtectionGroupStatusResponse{
 		Status: &replication.StorageProtectionGroupStatus{
 			State:    state,
 			IsSource: group.ReplicationDirection == "LocalToRemote",
 		},
-	}, nil
+	}, groupStatus, nil
 }
 
-func (s *service) DeleteStorageProtectionGroup(_ context.Context, req *replication.DeleteStorageProtectionGroupRequest) (*replication.DeleteStorageProtectionGroupResponse, error) {
-	Log.Printf("[DeleteStorageProtectionGroup] %+v", req)
+func (s *service) DeleteStorageProtectionGroup(ctx context.Context, req *replication.DeleteStorageProtectionGroupRequest) (*replication.DeleteStorageProtectionGroupResponse, error) {
+	Log.Printf("[DeleteStorageProtectionGroup] %+v", redactRequest(req))
 	localParams := req.GetProtectionGroupAttributes()
 
 	protectionGroupSystem := localParams[s.opts.replicationContextPrefix+"systemName"]
 
-	pairs, err := s.getReplicationPairs(protectionGroupSystem, req.ProtectionGroupId)
+	pairs, err := s.getReplicationPairs(ctx, protectionGroupSystem, req.ProtectionGroupId)
 	if err != nil {
 		// Handle the case where it doesn't exist. Already deleted.
 		if strings.EqualFold(err.Error(), sioReplicationGroupNotFound) {
@@ -480,7 +652,7 @@ func (s *service) DeleteStorageProtectionGroup(_ context.Context, req *replicati
 		return nil, status.Errorf(codes.Internal, "unable to delete protection group, pairs exist")
 	}
 
-	err = s.DeleteReplicationConsistencyGroup(protectionGroupSystem, req.ProtectionGroupId)
+	err = s.DeleteReplicationConsistencyGroup(ctx, protectionGroupSystem, req.ProtectionGroupId)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "error deleting the replication consistency group: %s", err.Error())
 	}
@@ -489,7 +661,7 @@ func (s *service) DeleteStorageProtectionGroup(_ context.Context, req *replicati
 }
 
 func (s *service) ExecuteAction(ctx context.Context, req *replication.ExecuteActionRequest) (*replication.ExecuteActionResponse, error) {
-	Log.Printf("[ExecuteAction] - req %+v", req)
+	Log.Printf("[ExecuteAction] - req %+v", redactRequest(req))
 
 	action := req.GetAction().GetActionTypes().String()
 	protectionGroupID := req.GetProtectionGroupId()
@@ -505,7 +677,7 @@ func (s *service) ExecuteAction(ctx context.Context, req *replication.ExecuteAct
 		return nil, status.Errorf(codes.FailedPrecondition, "%s", err.Error())
 	}
 
-	group, err := s.getReplicationConsistencyGroupByID(localSystem, protectionGroupID)
+	group, err := s.getReplicationConsistencyGroupByID(ctx, localSystem, protectionGroupID)
 	if err != nil {
 		return nil, status.Errorf(codes.FailedPrecondition, "No replication consistency groups found: %s", err.Error())
 	}
@@ -524,7 +696,7 @@ func (s *service) ExecuteAction(ctx context.Context, req *replication.ExecuteAct
 			return nil, status.Errorf(codes.FailedPrecondition, "rg is not synchronized, can't process snapshot")
 		}
 
-		resp, err := s.CreateReplicationConsistencyGroupSnapshot(client, group)
+		resp, err := s.CreateReplicationConsistencyGroupSnapshot(ctx, localSystem, client, group)
 		if err != nil {
 			return nil, status.Error(codes.Unknown, err.Error())
 		}
@@ -532,7 +704,7 @@ func (s *service) ExecuteAction(ctx context.Context, req *replication.ExecuteAct
 		attempts := 0
 
 		for len(actionAttributes) == 0 && attempts < snapshotMaxRetries {
-			actionAttributes, err = s.getConsistencyGroupSnapshotContent(localSystem, remoteSystem, protectionGroupID, resp.SnapshotGroupID)
+			actionAttributes, err = s.getConsistencyGroupSnapshotContent(ctx, localSystem, remoteSystem, protectionGroupID, resp.SnapshotGroupID)
 			if err != nil {
 				return nil, status.Error(codes.Unknown, err.Error())
 			}
@@ -540,17 +712,17 @@ func (s *service) ExecuteAction(ctx context.Context, req *replication.ExecuteAct
 			attempts++
 		}
 	case replication.ActionTypes_FAILOVER_REMOTE.String():
-		if err := s.ExecuteSwitchoverOnReplicationGroup(client, group); err != nil {
+		if err := s.ExecuteSwitchoverOnReplicationGroup(ctx, localSystem, client, group); err != nil {
 			return nil, status.Error(codes.Unknown, err.Error())
 		}
 
 	case replication.ActionTypes_UNPLANNED_FAILOVER_LOCAL.String():
-		if err := s.ExecuteFailoverOnReplicationGroup(client, group); err != nil {
+		if err := s.ExecuteFailoverOnReplicationGroup(ctx, localSystem, client, group); err != nil {
 			return nil, status.Error(codes.Unknown, err.Error())
 		}
 
 	case replication.ActionTypes_REPROTECT_LOCAL.String():
-		if err := s.ExecuteReverseOnReplicationGroup(client, group); err != nil {
+		if err := s.ExecuteReverseOnReplicationGroup(ctx, localSystem, client, group); err != nil {
 			return nil, status.Error(codes.Unknown, err.Error())
 		}
 
@@ -558,32 +730,47 @@ func (s *service) ExecuteAction(ctx context.Context, req *replication.ExecuteAct
 		failover := statusResp.Status.State == replication.StorageProtectionGroupStatus_FAILEDOVER
 		paused := statusResp.Status.State == replication.StorageProtectionGroupStatus_SUSPENDED
 		if paused || failover {
-			if err := s.ExecuteResumeOnReplicationGroup(client, group, failover); err != nil {
+			if err := s.ExecuteResumeOnReplicationGroup(ctx, localSystem, client, group, failover); err != nil {
 				return nil, status.Error(codes.Unknown, err.Error())
 			}
 		}
 	case replication.ActionTypes_SUSPEND.String():
 		paused := statusResp.Status.State == replication.StorageProtectionGroupStatus_SUSPENDED
 		if !paused {
-			if err := s.ExecutePauseOnReplicationGroup(client, group); err != nil {
+			if err := s.ExecutePauseOnReplicationGroup(ctx, localSystem, client, group); err != nil {
 				return nil, status.Error(codes.Unknown, err.Error())
 			}
 		}
 	case replication.ActionTypes_SYNC.String():
-		if _, err := s.ExecuteSyncOnReplicationGroup(client, group); err != nil {
+		if _, err := s.ExecuteSyncOnReplicationGroup(ctx, localSystem, client, group); err != nil {
 			return nil, status.Error(codes.Unknown, err.Error())
 		}
+	case replication.ActionTypes_TEST_FAILOVER.String():
+		if !isTestFailover(group) {
+			if err := s.ExecuteTestFailoverOnReplicationGroup(ctx, localSystem, group); err != nil {
+				return nil, status.Error(codes.Unknown, err.Error())
+			}
+		}
+	case replication.ActionTypes_TEST_FAILOVER_STOP.String():
+		if isTestFailover(group) {
+			if err := s.ExecuteTestFailoverStopOnReplicationGroup(ctx, localSystem, group); err != nil {
+				return nil, status.Error(codes.Unknown, err.Error())
+			}
+		}
 	default:
 		return nil, status.Errorf(codes.Unknown, "The requested action does not match with supported actions")
 	}
 
-	statusResp, err = s.GetStorageProtectionGroupStatus(ctx, &replication.GetStorageProtectionGroupStatusRequest{
+	statusResp, groupStatus, err := s.getStorageProtectionGroupStatus(ctx, &replication.GetStorageProtectionGroupStatusRequest{
 		ProtectionGroupId:         protectionGroupID,
 		ProtectionGroupAttributes: localParams,
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "unable to get storage protection group status: %s", err.Error())
 	}
+	for key, value := range groupStatus.attributes() {
+		actionAttributes[s.WithRP(key)] = value
+	}
 
 	resp := &replication.ExecuteActionResponse{
 		Success: true,
@@ -637,13 +824,17 @@ func getRemoteCSIVolume(volumeID string, size int) *replication.Volume {
 	return volume
 }
 
-func (s *service) getReplicationConsistencyGroupByID(systemID string, groupID string) (*siotypes.ReplicationConsistencyGroup, error) {
-	adminClient := s.adminClients[systemID]
+func (s *service) getReplicationConsistencyGroupByID(ctx context.Context, systemID string, groupID string) (*siotypes.ReplicationConsistencyGroup, error) {
+	adminClient := s.adminClientOf(systemID)
 	if adminClient == nil {
 		return nil, fmt.Errorf("can't find adminClient by id %s", systemID)
 	}
 
-	group, err := adminClient.GetReplicationConsistencyGroupByID(groupID)
+	var group *siotypes.ReplicationConsistencyGroup
+	err := s.gatewayCall(ctx, systemID, "GetReplicationConsistencyGroupByID", func() (err error) {
+		group, err = adminClient.GetReplicationConsistencyGroupByID(groupID)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -651,7 +842,10 @@ func (s *service) getReplicationConsistencyGroupByID(systemID string, groupID st
 	return group, nil
 }
 
-func (s *service) createUniqueConsistencyGroupName(systemID, rpo, localPd, remotePd, remoteClusterID, clusterUID, rcgPrefix string) (string, error) {
+// createUniqueConsistencyGroupName returns the name of the group that replicates between the given protection domains,
+// or a new versioned name when there is none. The RPO is not part of the name, so an existing group is reused whatever
+// its RPO; that RPO is changed with ModifyStorageProtectionGroup.
+func (s *service) createUniqueConsistencyGroupName(ctx context.Context, systemID, localPd, remotePd, remoteClusterID, clusterUID, rcgPrefix string) (string, error) {
 	consistencyGroupName := rcgPrefix + "-"
 	clusterUID = strings.Replace(clusterUID, "-", "", -1)
 	remoteClusterID = strings.Replace(remoteClusterID, "-", "", -1)
@@ -668,12 +862,16 @@ func (s *service) createUniqueConsistencyGroupName(systemID, rpo, localPd, remot
 		}
 	}
 
-	adminClient := s.adminClients[systemID]
+	adminClient := s.adminClientOf(systemID)
 	if adminClient == nil {
 		return "", fmt.Errorf("can't find adminClient by id %s", systemID)
 	}
 
-	rcgs, err := adminClient.GetReplicationConsistencyGroups()
+	var rcgs []*siotypes.ReplicationConsistencyGroup
+	err := s.gatewayCall(ctx, systemID, "GetReplicationConsistencyGroups", func() (err error) {
+		rcgs, err = adminClient.GetReplicationConsistencyGroups()
+		return err
+	})
 	if err != nil {
 		return "", err
 	}
@@ -682,7 +880,7 @@ func (s *service) createUniqueConsistencyGroupName(systemID, rpo, localPd, remot
 	var found bool
 	for _, rcg := range rcgs {
 		if strings.Contains(rcg.Name, consistencyGroupName) {
-			if rcg.ProtectionDomainID == localPd && rcg.RemoteProtectionDomainID == remotePd && strconv.Itoa(rcg.RpoInSeconds) == rpo {
+			if rcg.ProtectionDomainID == localPd && rcg.RemoteProtectionDomainID == remotePd {
 				consistencyGroupName = rcg.Name
 				found = true
 				break
@@ -700,13 +898,17 @@ func (s *service) createUniqueConsistencyGroupName(systemID, rpo, localPd, remot
 	return consistencyGroupName, nil
 }
 
-func (s *service) getReplicationPairs(systemID string, groupID string) ([]*siotypes.ReplicationPair, error) {
-	adminClient := s.adminClients[systemID]
+func (s *service) getReplicationPairs(ctx context.Context, systemID string, groupID string) ([]*siotypes.ReplicationPair, error) {
+	adminClient := s.adminClientOf(systemID)
 	if adminClient == nil {
 		return nil, fmt.Errorf("can't find adminClient by id %s", systemID)
 	}
 
-	group, err := adminClient.GetReplicationConsistencyGroupByID(groupID)
+	var group *siotypes.ReplicationConsistencyGroup
+	err := s.gatewayCall(ctx, systemID, "GetReplicationConsistencyGroupByID", func() (err error) {
+		group, err = adminClient.GetReplicationConsistencyGroupByID(groupID)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -714,7 +916,11 @@ func (s *service) getReplicationPairs(systemID string, groupID string) ([]*sioty
 	rcg := goscaleio.NewReplicationConsistencyGroup(adminClient)
 	rcg.ReplicationConsistencyGroup = group
 
-	pairs, err := rcg.GetReplicationPairs()
+	var pairs []*siotypes.ReplicationPair
+	err = s.gatewayCall(ctx, systemID, "GetReplicationPairs", func() (err error) {
+		pairs, err = rcg.GetReplicationPairs()
+		return err
+	})
 	if err != nil {
 		if !strings.EqualFold(err.Error(), sioReplicationPairsDoesNotExist) {
 			Log.Printf("Error getting replication pairs: %s", err.Error())
@@ -725,24 +931,134 @@ func (s *service) getReplicationPairs(systemID string, groupID string) ([]*sioty
 	return pairs, nil
 }
 
+// getVolumeProtectionDomain returns the ID of the protection domain of the storage pool of a volume
+func (s *service) getVolumeProtectionDomain(ctx context.Context, systemID string, vol *siotypes.Volume) (string, error) {
+	adminClient := s.adminClientOf(systemID)
+	if adminClient == nil {
+		return "", fmt.Errorf("can't find adminClient by id %s", systemID)
+	}
+
+	var pool *siotypes.StoragePool
+	err := s.gatewayCall(ctx, systemID, "FindStoragePool", func() (err error) {
+		pool, err = adminClient.FindStoragePool(vol.StoragePoolID, "", "", "")
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return pool.ProtectionDomainID, nil
+}
+
 func isFailover(group *siotypes.ReplicationConsistencyGroup) bool {
-	return group.FailoverType != "None"
+	return group.FailoverType != "None" && !isTestFailover(group)
+}
+
+// isTestFailover returns true while a test failover of the group is running, the group keeps replicating
+func isTestFailover(group *siotypes.ReplicationConsistencyGroup) bool {
+	return group.FailoverType == rcgTestFailover
 }
 
 func isPaused(group *siotypes.ReplicationConsistencyGroup) bool {
 	return group.PauseMode != "None"
 }
 
-func (s *service) getConsistencyGroupSnapshotContent(localSystem, remoteSystem, protectionGroup, snapshotGroup string) (map[string]string, error) {
+// CreateVolumesFromProtectionGroupSnapshot provisions one volume per snapshot of a replication consistency
+// group snapshot, as returned in the action attributes of CREATE_SNAPSHOT, on the system of the snapshots.
+// Each volume goes through CreateVolume with the snapshot as content source and is named after the name prefix
+// and the snapshot ID. The volumes form a consistent set, so all of them are deleted again when one fails.
+// The returned volumes are keyed by the replicated source volume of each snapshot.
+func (s *service) CreateVolumesFromProtectionGroupSnapshot(ctx context.Context, req *replicationext.CreateVolumesFromProtectionGroupSnapshotRequest) (*replicationext.CreateVolumesFromProtectionGroupSnapshotResponse, error) {
+	Log.Printf("[CreateVolumesFromProtectionGroupSnapshot] - req %+v", redactRequest(req))
+
+	snapshots := req.GetSnapshots()
+	namePrefix := req.GetNamePrefix()
+	if len(snapshots) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no replication consistency group snapshots provided")
+	}
+	if namePrefix == "" {
+		return nil, status.Error(codes.InvalidArgument, "volume name prefix is required")
+	}
+
+	// the access mode is not stored on the array, the volumes are published with the capabilities of their PVs
+	capabilities := []*csi.VolumeCapability{
+		{
+			AccessType: &csi.VolumeCapability_Block{Block: &csi.VolumeCapability_BlockVolume{}},
+			AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+		},
+	}
+
+	volumes := make(map[string]*replication.Volume)
+	for sourceVolume, snapshotID := range snapshots {
+		snapID := getVolumeIDFromCsiVolumeID(snapshotID)
+		systemID := s.getSystemIDFromCsiVolumeID(snapshotID)
+		if snapID == "" || systemID == "" {
+			s.deleteRestoredVolumes(ctx, volumes)
+			return nil, status.Errorf(codes.InvalidArgument, "invalid snapshot ID %s for volume %s", snapshotID, sourceVolume)
+		}
+
+		snap, err := s.getVolByID(ctx, snapID, systemID)
+		if err != nil {
+			s.deleteRestoredVolumes(ctx, volumes)
+			return nil, status.Errorf(codes.NotFound, "Snapshot not found: %s, error: %s", snapshotID, err.Error())
+		}
+
+		parameters := make(map[string]string)
+		for key, value := range req.GetParameters() {
+			parameters[key] = value
+		}
+		parameters[KeySystemID] = systemID
+		parameters[KeyStoragePool] = s.getStoragePoolNameFromID(ctx, systemID, snap.StoragePoolID)
+
+		volReq := &csi.CreateVolumeRequest{
+			Name:               namePrefix + "-" + snapID,
+			CapacityRange:      &csi.CapacityRange{RequiredBytes: int64(snap.SizeInKb) * bytesInKiB},
+			VolumeCapabilities: capabilities,
+			Parameters:         parameters,
+			VolumeContentSource: &csi.VolumeContentSource{
+				Type: &csi.VolumeContentSource_Snapshot{
+					Snapshot: &csi.VolumeContentSource_SnapshotSource{SnapshotId: snapshotID},
+				},
+			},
+		}
+
+		resp, err := s.CreateVolume(ctx, volReq)
+		if err != nil {
+			Log.Errorf("[CreateVolumesFromProtectionGroupSnapshot] - failed creating volume from snapshot %s: %s", snapshotID, err.Error())
+			s.deleteRestoredVolumes(ctx, volumes)
+			return nil, err
+		}
+		volumes[sourceVolume] = &replication.Volume{
+			VolumeId:      resp.GetVolume().GetVolumeId(),
+			CapacityBytes: resp.GetVolume().GetCapacityBytes(),
+			VolumeContext: resp.GetVolume().GetVolumeContext(),
+		}
+		Log.Printf("[CreateVolumesFromProtectionGroupSnapshot] - created volume %s from snapshot %s of volume %s",
+			resp.GetVolume().GetVolumeId(), snapshotID, sourceVolume)
+	}
+
+	return &replicationext.CreateVolumesFromProtectionGroupSnapshotResponse{Volumes: volumes}, nil
+}
+
+// deleteRestoredVolumes deletes the volumes created from a replication consistency group snapshot
+func (s *service) deleteRestoredVolumes(ctx context.Context, volumes map[string]*replication.Volume) {
+	for _, volume := range volumes {
+		if _, err := s.DeleteVolume(ctx, &csi.DeleteVolumeRequest{VolumeId: volume.GetVolumeId()}); err != nil {
+			Log.Errorf("[CreateVolumesFromProtectionGroupSnapshot] - failed deleting volume %s: %s", volume.GetVolumeId(), err.Error())
+		}
+	}
+}
+
+func (s *service) getConsistencyGroupSnapshotContent(ctx context.Context, localSystem, remoteSystem, protectionGroup, snapshotGroup string) (map[string]string, error) {
 	actionAttributes := make(map[string]string)
 
-	pairs, err := s.getReplicationPairs(localSystem, protectionGroup)
+	pairs, err := s.getReplicationPairs(ctx, localSystem, protectionGroup)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, pair := range pairs {
-		existingSnaps, _, err := s.listVolumes(remoteSystem, 0, 0, false, false, "", pair.RemoteVolumeID)
+		existingSnaps, _, err := s.listVolumes(ctx, remoteSystem, 0, 0, false, false, "", pair.RemoteVolumeID)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,242 @@
+// Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dell/dell-csi-extensions/replication"
+	siotypes "github.com/dell/goscaleio/types/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// defaultAutoReprotectGracePeriod is how long a group stays failed over, with both systems reachable,
+	// before it is reprotected when no grace period is configured
+	defaultAutoReprotectGracePeriod = 5 * time.Minute
+
+	autoReprotectDecisionWait            = "Wait"
+	autoReprotectDecisionPeerUnreachable = "PeerUnreachable"
+	autoReprotectDecisionReprotected     = "Reprotected"
+	autoReprotectDecisionFailed          = "Failed"
+)
+
+// autoReprotectEventReasons maps the auto reprotect decisions to the reason of their Kubernetes event
+var autoReprotectEventReasons = map[string]string{
+	autoReprotectDecisionWait:            "ReplicationAutoReprotectPending",
+	autoReprotectDecisionPeerUnreachable: "ReplicationAutoReprotectPeerUnreachable",
+	autoReprotectDecisionReprotected:     "ReplicationAutoReprotected",
+	autoReprotectDecisionFailed:          "ReplicationAutoReprotectFailed",
+}
+
+// autoReprotectDecision is the decision the auto reprotect policy made for one failed over group
+type autoReprotectDecision struct {
+	SystemID  string
+	GroupID   string
+	GroupName string
+	Decision  string
+	Reason    string
+}
+
+// autoReprotectState tracks a failed over replication consistency group across auto reprotect runs
+type autoReprotectState struct {
+	since        time.Time // first time the group was seen failed over with both systems reachable
+	lastDecision string
+}
+
+// runReplicationAutoReprotect periodically reprotects and resumes replication consistency groups that were
+// left failed over after an unplanned failover
+func (s *service) runReplicationAutoReprotect(ctx context.Context, interval time.Duration) {
+	Log.Infof("replication auto reprotect started, interval: %s, grace period: %s", interval, s.opts.AutoReprotectGracePeriod)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			Log.Info("replication auto reprotect stopped")
+			return
+		case <-ticker.C:
+			s.autoReprotectReplicationGroups(ctx, time.Now())
+		}
+	}
+}
+
+// autoReprotectReplicationGroups checks the status of the replication consistency groups of every system.
+// A group that is failed over is reprotected and resumed once both of its systems are reachable and the
+// grace period has passed since that was first seen. Each group is handled once, from the first of its
+// two systems in system ID order.
+func (s *service) autoReprotectReplicationGroups(ctx context.Context, now time.Time) []*autoReprotectDecision {
+	arrays := s.arrayConfigs()
+	systemIDs := make([]string, 0, len(arrays))
+	for systemID := range arrays {
+		systemIDs = append(systemIDs, systemID)
+	}
+	sort.Strings(systemIDs)
+
+	decisions := make([]*autoReprotectDecision, 0)
+	handled := make(map[string]bool)
+	for _, systemID := range systemIDs {
+		if err := s.requireProbe(ctx, systemID); err != nil {
+			Log.WithError(err).Warnf("replication auto reprotect skipping system %s", systemID)
+			continue
+		}
+		var groups []*siotypes.ReplicationConsistencyGroup
+		err := s.gatewayCall(ctx, systemID, "GetReplicationConsistencyGroups", func() (err error) {
+			groups, err = s.adminClientOf(systemID).GetReplicationConsistencyGroups()
+			return err
+		})
+		if err != nil {
+			Log.WithError(err).Warnf("replication auto reprotect could not list replication consistency groups on system %s", systemID)
+			continue
+		}
+		for _, group := range groups {
+			key := systemID + "/" + group.ID
+			if handled[key] {
+				continue
+			}
+			handled[key] = true
+			handled[group.RemoteMdmID+"/"+group.RemoteID] = true
+
+			if decision := s.autoReprotectReplicationGroup(ctx, systemID, group, now); decision != nil {
+				decisions = append(decisions, decision)
+			}
+		}
+	}
+
+	// forget the groups that no longer exist
+	s.rcgFailovers.Range(func(key, _ interface{}) bool {
+		if !handled[key.(string)] {
+			s.rcgFailovers.Delete(key)
+		}
+		return true
+	})
+	return decisions
+}
+
+// autoReprotectReplicationGroup applies the auto reprotect policy to one group, it returns nil when the
+// group is not failed over
+func (s *service) autoReprotectReplicationGroup(ctx context.Context, systemID string, group *siotypes.ReplicationConsistencyGroup, now time.Time) *autoReprotectDecision {
+	key := systemID + "/" + group.ID
+	statusResp, err := s.GetStorageProtectionGroupStatus(ctx, &replication.GetStorageProtectionGroupStatusRequest{
+		ProtectionGroupId:         group.ID,
+		ProtectionGroupAttributes: map[string]string{s.opts.replicationContextPrefix + "systemName": systemID},
+	})
+	if err != nil {
+		Log.WithError(err).Warnf("replication auto reprotect could not get the status of group %s on system %s", group.Name, systemID)
+		return nil
+	}
+	if statusResp.Status.State != replication.StorageProtectionGroupStatus_FAILEDOVER {
+		s.rcgFailovers.Delete(key)
+		return nil
+	}
+
+	state := &autoReprotectState{}
+	if value, loaded := s.rcgFailovers.LoadOrStore(key, state); loaded {
+		state = value.(*autoReprotectState)
+	}
+	decision := &autoReprotectDecision{
+		SystemID:  systemID,
+		GroupID:   group.ID,
+		GroupName: group.Name,
+	}
+
+	if err := s.checkReplicationPeer(ctx, systemID, group.RemoteMdmID, "", ""); err != nil {
+		state.since = time.Time{}
+		decision.Decision = autoReprotectDecisionPeerUnreachable
+		decision.Reason = err.Error()
+		return s.logAutoReprotectDecision(ctx, state, decision)
+	}
+
+	if state.since.IsZero() {
+		state.since = now
+	}
+	if elapsed := now.Sub(state.since); elapsed < s.opts.AutoReprotectGracePeriod {
+		decision.Decision = autoReprotectDecisionWait
+		decision.Reason = fmt.Sprintf("group is failed over, reprotect in %s", s.opts.AutoReprotectGracePeriod-elapsed)
+		return s.logAutoReprotectDecision(ctx, state, decision)
+	}
+
+	if err := s.reprotectReplicationGroup(ctx, systemID, group); err != nil {
+		decision.Decision = autoReprotectDecisionFailed
+		decision.Reason = err.Error()
+		return s.logAutoReprotectDecision(ctx, state, decision)
+	}
+
+	s.rcgFailovers.Delete(key)
+	decision.Decision = autoReprotectDecisionReprotected
+	decision.Reason = fmt.Sprintf("group was failed over for more than %s", s.opts.AutoReprotectGracePeriod)
+	return s.logAutoReprotectDecision(ctx, state, decision)
+}
+
+// reprotectReplicationGroup reprotects a failed over group and resumes its replication, like the
+// REPROTECT_LOCAL and RESUME actions
+func (s *service) reprotectReplicationGroup(ctx context.Context, systemID string, group *siotypes.ReplicationConsistencyGroup) error {
+	client, err := s.verifySystem(systemID)
+	if err != nil {
+		return err
+	}
+
+	objects := map[string]string{"protectionGroupId": group.ID, "name": group.Name}
+	err = s.ExecuteReverseOnReplicationGroup(ctx, systemID, client, group)
+	s.auditOperation(ctx, auditAutoReprotectReverse, systemID, objects, err)
+	if err != nil {
+		return fmt.Errorf("reprotect failed: %s", err.Error())
+	}
+
+	group, err = s.getReplicationConsistencyGroupByID(ctx, systemID, group.ID)
+	if err != nil {
+		return fmt.Errorf("can't get group after reprotect: %s", err.Error())
+	}
+	if group.AbstractState == "StoppedByUser" && (isFailover(group) || isPaused(group)) {
+		err := s.ExecuteResumeOnReplicationGroup(ctx, systemID, client, group, isFailover(group))
+		s.auditOperation(ctx, auditAutoReprotectResume, systemID, objects, err)
+		if err != nil {
+			return fmt.Errorf("resume failed: %s", err.Error())
+		}
+	}
+	return nil
+}
+
+// logAutoReprotectDecision logs the decision and emits it as a Kubernetes event when it differs from the
+// previous decision for the group
+func (s *service) logAutoReprotectDecision(ctx context.Context, state *autoReprotectState, decision *autoReprotectDecision) *autoReprotectDecision {
+	fields := map[string]interface{}{
+		"systemID":  decision.SystemID,
+		"groupID":   decision.GroupID,
+		"groupName": decision.GroupName,
+		"decision":  decision.Decision,
+		"reason":    decision.Reason,
+	}
+	eventType := corev1.EventTypeNormal
+	switch decision.Decision {
+	case autoReprotectDecisionFailed:
+		eventType = corev1.EventTypeWarning
+		Log.WithFields(fields).Error("replication auto reprotect failed")
+	case autoReprotectDecisionPeerUnreachable:
+		eventType = corev1.EventTypeWarning
+		Log.WithFields(fields).Warn("replication auto reprotect waiting for the remote system")
+	default:
+		Log.WithFields(fields).Info("replication auto reprotect decision")
+	}
+
+	if decision.Decision != state.lastDecision {
+		s.recordEvent(ctx, eventType, autoReprotectEventReasons[decision.Decision],
+			"replication consistency group %s on system %s: %s", decision.GroupName, decision.SystemID, decision.Reason)
+	}
+	state.lastDecision = decision.Decision
+	return decision
+}
@@ -0,0 +1,181 @@
+// Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/dell/csi-vxflexos/v2/replicationext"
+	"github.com/dell/dell-csi-extensions/replication"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The ReplicationExtension service serves the replication operations of the driver that the dell-csi-extensions
+// replication API has no RPC or state for.
+var _ replicationext.ReplicationExtensionServer = (*service)(nil)
+
+// DescribeStorageProtectionGroup returns the status of a protection group with its status attributes and whether
+// a test failover of it is running, which the replication API has no state for
+func (s *service) DescribeStorageProtectionGroup(ctx context.Context, req *replication.GetStorageProtectionGroupStatusRequest) (*replicationext.DescribeStorageProtectionGroupResponse, error) {
+	Log.Printf("[DescribeStorageProtectionGroup] - req %+v", redactRequest(req))
+
+	statusResp, groupStatus, err := s.getStorageProtectionGroupStatus(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &replicationext.DescribeStorageProtectionGroupResponse{
+		Status:       statusResp.Status,
+		TestFailover: groupStatus.TestFailover,
+		Attributes:   s.replicationStatusAttributes(groupStatus),
+	}, nil
+}
+
+// ModifyStorageProtectionGroup changes the RPO of a protection group on both systems. The replication pairs stay in
+// the group, and the volumes provisioned later with the new RPO join it, as its name does not depend on the RPO.
+func (s *service) ModifyStorageProtectionGroup(ctx context.Context, req *replicationext.ModifyStorageProtectionGroupRequest) (*replicationext.ModifyStorageProtectionGroupResponse, error) {
+	Log.Printf("[ModifyStorageProtectionGroup] - req %+v", redactRequest(req))
+
+	localParams := req.GetProtectionGroupAttributes()
+	localSystem := localParams[s.opts.replicationContextPrefix+"systemName"]
+	remoteSystem := localParams[s.opts.replicationContextPrefix+"remoteSystemID"]
+	if req.GetProtectionGroupId() == "" || localSystem == "" {
+		return nil, status.Error(codes.InvalidArgument, "protection group ID and systemName attribute are required")
+	}
+	if req.GetRpoSeconds() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "RPO must be a positive number of seconds")
+	}
+	rpo := strconv.Itoa(int(req.GetRpoSeconds()))
+
+	if _, err := s.verifySystem(localSystem); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s", err.Error())
+	}
+	group, err := s.getReplicationConsistencyGroupByID(ctx, localSystem, req.GetProtectionGroupId())
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "No replication consistency groups found: %s", err.Error())
+	}
+	if group.RpoInSeconds != int(req.GetRpoSeconds()) {
+		if err := s.ModifyRPOOfReplicationGroup(ctx, localSystem, group, rpo); err != nil {
+			return nil, status.Errorf(codes.Unknown, "unable to change the RPO of group %s: %s", group.Name, err.Error())
+		}
+	}
+
+	// the remote group is changed as well when the gateway did not apply the RPO to both groups
+	if remoteSystem != "" && group.RemoteID != "" {
+		if _, err := s.verifySystem(remoteSystem); err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err.Error())
+		}
+		remoteGroup, err := s.getReplicationConsistencyGroupByID(ctx, remoteSystem, group.RemoteID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "No remote replication consistency groups found: %s", err.Error())
+		}
+		if remoteGroup.RpoInSeconds != int(req.GetRpoSeconds()) {
+			if err := s.ModifyRPOOfReplicationGroup(ctx, remoteSystem, remoteGroup, rpo); err != nil {
+				return nil, status.Errorf(codes.Unknown, "unable to change the RPO of remote group %s: %s", remoteGroup.Name, err.Error())
+			}
+		}
+	}
+
+	statusResp, groupStatus, err := s.getStorageProtectionGroupStatus(ctx, &replication.GetStorageProtectionGroupStatusRequest{
+		ProtectionGroupId:         req.GetProtectionGroupId(),
+		ProtectionGroupAttributes: localParams,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &replicationext.ModifyStorageProtectionGroupResponse{
+		Status:     statusResp.Status,
+		Attributes: s.replicationStatusAttributes(groupStatus),
+	}, nil
+}
+
+// AddVolumeToProtectionGroup replicates a volume that was provisioned without replication. It creates the remote
+// volume, then the replication pair in the group CreateStorageProtectionGroup derives from the parameters, and
+// returns the remote volume and the group attributes, so the replication controller can adopt the volume. The
+// remote volume is deleted again when the pair cannot be created, unless it existed before.
+func (s *service) AddVolumeToProtectionGroup(ctx context.Context, req *replicationext.AddVolumeToProtectionGroupRequest) (*replicationext.AddVolumeToProtectionGroupResponse, error) {
+	Log.Printf("[AddVolumeToProtectionGroup] - req %+v", redactRequest(req))
+
+	volHandleCtx := req.GetVolumeHandle()
+	parameters := req.GetParameters()
+	volumeID := getVolumeIDFromCsiVolumeID(volHandleCtx)
+	systemID := s.getSystemIDFromCsiVolumeID(volHandleCtx)
+	if volumeID == "" || systemID == "" {
+		return nil, status.Error(codes.InvalidArgument, "failed to provide system ID or volume ID")
+	}
+
+	if err := s.requireProbe(ctx, systemID); err != nil {
+		return nil, err
+	}
+	vol, err := s.getVolByID(ctx, volumeID, systemID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't query volume: %s", err.Error())
+	}
+
+	remoteVolumeExisted := false
+	remoteSystemID := parameters[s.WithRP(KeyReplicationRemoteSystem)]
+	if remoteClient := s.adminClientOf(remoteSystemID); remoteClient != nil {
+		err := s.gatewayCall(ctx, remoteSystemID, "FindVolumeID", func() (err error) {
+			_, err = remoteClient.FindVolumeID("replicated-" + vol.Name)
+			return err
+		})
+		remoteVolumeExisted = err == nil
+	}
+
+	remoteResp, err := s.CreateRemoteVolume(ctx, &replication.CreateRemoteVolumeRequest{
+		VolumeHandle: volHandleCtx,
+		Parameters:   parameters,
+	})
+	if err != nil {
+		return nil, err
+	}
+	remoteVolume := remoteResp.GetRemoteVolume()
+
+	groupResp, err := s.CreateStorageProtectionGroup(ctx, &replication.CreateStorageProtectionGroupRequest{
+		VolumeHandle: volHandleCtx,
+		Parameters:   parameters,
+	})
+	if err != nil {
+		if !remoteVolumeExisted {
+			if _, derr := s.DeleteVolume(ctx, &csi.DeleteVolumeRequest{VolumeId: remoteVolume.GetVolumeId()}); derr != nil {
+				Log.Errorf("[AddVolumeToProtectionGroup] - failed deleting remote volume %s: %s", remoteVolume.GetVolumeId(), derr.Error())
+			}
+		}
+		return nil, err
+	}
+
+	Log.Printf("[AddVolumeToProtectionGroup] - volume %s replicated to %s in group %s", volHandleCtx, remoteVolume.GetVolumeId(),
+		groupResp.GetLocalProtectionGroupId())
+	return &replicationext.AddVolumeToProtectionGroupResponse{
+		RemoteVolume:                    remoteVolume,
+		LocalProtectionGroupId:          groupResp.GetLocalProtectionGroupId(),
+		LocalProtectionGroupAttributes:  groupResp.GetLocalProtectionGroupAttributes(),
+		RemoteProtectionGroupId:         groupResp.GetRemoteProtectionGroupId(),
+		RemoteProtectionGroupAttributes: groupResp.GetRemoteProtectionGroupAttributes(),
+	}, nil
+}
+
+// replicationStatusAttributes returns the status attributes of a group with the replication prefix, as in the action
+// attributes of ExecuteAction
+func (s *service) replicationStatusAttributes(groupStatus *replicationGroupStatus) map[string]string {
+	attributes := make(map[string]string)
+	for key, value := range groupStatus.attributes() {
+		attributes[s.WithRP(key)] = value
+	}
+	return attributes
+}
@@ -0,0 +1,180 @@
+// Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dell/csi-vxflexos/v2/replicationext"
+	"github.com/dell/dell-csi-extensions/replication"
+	"github.com/dell/goscaleio"
+	siotypes "github.com/dell/goscaleio/types/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// multiGroupMember is a protection group taking part in a multi-group action
+type multiGroupMember struct {
+	systemID string
+	client   *goscaleio.Client
+	group    *siotypes.ReplicationConsistencyGroup
+	paused   bool
+	done     bool
+}
+
+// ExecuteMultiGroupAction runs FAILOVER_REMOTE or UNPLANNED_FAILOVER_LOCAL on several protection groups as one
+// operation, for applications whose volumes are spread over more than one replication consistency group.
+// All groups must be synchronized. They are paused so they stay at the same point in time, verified to be
+// consistent and then failed over or switched over one by one. When any group fails, the groups already
+// handled are switched back or restored and all groups are resumed, so no partial failover is left behind.
+// After a switchover, a group that can't be resumed fails the call while the switchover of all groups is kept.
+func (s *service) ExecuteMultiGroupAction(ctx context.Context, req *replicationext.ExecuteMultiGroupActionRequest) (*replicationext.ExecuteMultiGroupActionResponse, error) {
+	Log.Printf("[ExecuteMultiGroupAction] - req %+v", redactRequest(req))
+
+	action := req.GetActionType()
+	groups := req.GetProtectionGroups()
+	if action != replication.ActionTypes_FAILOVER_REMOTE && action != replication.ActionTypes_UNPLANNED_FAILOVER_LOCAL {
+		return nil, status.Errorf(codes.InvalidArgument, "action %s is not supported on multiple protection groups", action)
+	}
+	if len(groups) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no protection groups provided")
+	}
+
+	members := make([]*multiGroupMember, 0, len(groups))
+	for _, pg := range groups {
+		systemID := pg.GetProtectionGroupAttributes()[s.opts.replicationContextPrefix+"systemName"]
+		client, err := s.verifySystem(systemID)
+		if err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err.Error())
+		}
+
+		group, err := s.getReplicationConsistencyGroupByID(ctx, systemID, pg.GetProtectionGroupId())
+		if err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "No replication consistency groups found: %s", err.Error())
+		}
+
+		statusResp, err := s.GetStorageProtectionGroupStatus(ctx, &replication.GetStorageProtectionGroupStatusRequest{
+			ProtectionGroupId:         pg.GetProtectionGroupId(),
+			ProtectionGroupAttributes: pg.GetProtectionGroupAttributes(),
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "unable to get storage protection group status: %s", err.Error())
+		}
+		if statusResp.Status.State != replication.StorageProtectionGroupStatus_SYNCHRONIZED {
+			return nil, status.Errorf(codes.FailedPrecondition, "group %s is not synchronized, state: %s", group.Name, statusResp.Status.State)
+		}
+
+		members = append(members, &multiGroupMember{systemID: systemID, client: client, group: group})
+	}
+
+	for _, member := range members {
+		if err := s.ExecutePauseOnReplicationGroup(ctx, member.systemID, member.client, member.group); err != nil {
+			s.rollbackMultiGroupAction(ctx, action, members)
+			return nil, status.Errorf(codes.Aborted, "pausing group %s failed, all groups were rolled back: %s", member.group.Name, err.Error())
+		}
+		member.paused = true
+	}
+
+	for _, member := range members {
+		group, err := s.getReplicationConsistencyGroupByID(ctx, member.systemID, member.group.ID)
+		if err != nil {
+			s.rollbackMultiGroupAction(ctx, action, members)
+			return nil, status.Errorf(codes.Aborted, "can't get group %s, all groups were rolled back: %s", member.group.Name, err.Error())
+		}
+		if group.CurrConsistMode != goscaleio.Consistent {
+			s.rollbackMultiGroupAction(ctx, action, members)
+			return nil, status.Errorf(codes.Aborted, "group %s is not consistent after pausing, mode: %s, all groups were rolled back",
+				group.Name, group.CurrConsistMode)
+		}
+		member.group = group
+	}
+
+	for _, member := range members {
+		var err error
+		if action == replication.ActionTypes_FAILOVER_REMOTE {
+			err = s.ExecuteSwitchoverOnReplicationGroup(ctx, member.systemID, member.client, member.group)
+		} else {
+			err = s.ExecuteFailoverOnReplicationGroup(ctx, member.systemID, member.client, member.group)
+		}
+		if err != nil {
+			s.rollbackMultiGroupAction(ctx, action, members)
+			return nil, status.Errorf(codes.Aborted, "%s of group %s failed, all groups were rolled back: %s", action, member.group.Name, err.Error())
+		}
+		member.done = true
+	}
+
+	// A switchover keeps replicating in the new direction, a failover stops replication until reprotected.
+	// The switchover is kept when a group can't be resumed, that group is left paused for the RESUME action.
+	if action == replication.ActionTypes_FAILOVER_REMOTE {
+		var failed []string
+		for _, member := range members {
+			if err := s.ExecuteResumeOnReplicationGroup(ctx, member.systemID, member.client, member.group, false); err != nil {
+				Log.Errorf("[ExecuteMultiGroupAction] - resuming group %s failed: %s", member.group.Name, err.Error())
+				failed = append(failed, fmt.Sprintf("%s: %s", member.group.Name, err.Error()))
+			}
+		}
+		if len(failed) > 0 {
+			return nil, status.Errorf(codes.Internal, "%s of all groups succeeded but resuming groups failed, they are left paused: %s",
+				action, strings.Join(failed, "; "))
+		}
+	}
+
+	statuses := make(map[string]*replication.StorageProtectionGroupStatus)
+	for i, member := range members {
+		statusResp, err := s.GetStorageProtectionGroupStatus(ctx, &replication.GetStorageProtectionGroupStatusRequest{
+			ProtectionGroupId:         member.group.ID,
+			ProtectionGroupAttributes: groups[i].GetProtectionGroupAttributes(),
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to get storage protection group status: %s", err.Error())
+		}
+		statuses[member.group.ID] = statusResp.Status
+	}
+	return &replicationext.ExecuteMultiGroupActionResponse{Statuses: statuses}, nil
+}
+
+// rollbackMultiGroupAction switches back or restores the groups a multi-group action was already executed on
+// and resumes the other groups it paused. A restore resumes the group, so a restored group is not resumed again.
+func (s *service) rollbackMultiGroupAction(ctx context.Context, action replication.ActionTypes, members []*multiGroupMember) {
+	for _, member := range members {
+		if !member.done {
+			continue
+		}
+		var err error
+		if action == replication.ActionTypes_FAILOVER_REMOTE {
+			err = s.ExecuteSwitchoverOnReplicationGroup(ctx, member.systemID, member.client, member.group)
+		} else {
+			err = s.ExecuteResumeOnReplicationGroup(ctx, member.systemID, member.client, member.group, true)
+		}
+		if err != nil {
+			Log.Errorf("[ExecuteMultiGroupAction] - rolling back %s of group %s failed: %s", action, member.group.Name, err.Error())
+			continue
+		}
+		Log.Printf("[ExecuteMultiGroupAction] - rolled back %s of group %s", action, member.group.Name)
+		if action != replication.ActionTypes_FAILOVER_REMOTE {
+			member.paused = false
+		}
+	}
+
+	for _, member := range members {
+		if !member.paused {
+			continue
+		}
+		if err := s.ExecuteResumeOnReplicationGroup(ctx, member.systemID, member.client, member.group, false); err != nil {
+			Log.Errorf("[ExecuteMultiGroupAction] - resuming group %s failed: %s", member.group.Name, err.Error())
+		}
+	}
+}
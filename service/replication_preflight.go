@@ -0,0 +1,101 @@
+// Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// peerMdmJoined is the membership state of a peer MDM that is connected to the system
+	peerMdmJoined = "Joined"
+	// peerMdmCouplingSuccess is the coupling state of a peer MDM whose coupling succeeded
+	peerMdmCouplingSuccess = "SUCCESS"
+)
+
+// checkReplicationPeer verifies, before any replication object is created, that the remote system has
+// credentials in the array secret and can be probed, that it is connected to the local system through
+// a peer MDM, and that the remote protection domain and storage pool exist when they are given.
+func (s *service) checkReplicationPeer(ctx context.Context, systemID, remoteSystemID, remoteProtectionDomain, remoteStoragePool string) error {
+	if s.arrayOf(remoteSystemID) == nil && s.adminClientOf(remoteSystemID) == nil {
+		return status.Errorf(codes.FailedPrecondition,
+			"no credentials for remote system %s in the array secret", remoteSystemID)
+	}
+
+	if err := s.requireProbe(ctx, remoteSystemID); err != nil {
+		return status.Errorf(codes.FailedPrecondition, "remote system %s is not reachable: %s", remoteSystemID, err.Error())
+	}
+
+	mdms, err := s.getPeerMdms(ctx, systemID)
+	if err != nil {
+		return status.Errorf(codes.FailedPrecondition, "can't query peer mdms: %s", err.Error())
+	}
+
+	peered := false
+	for _, mdm := range mdms {
+		if mdm.PeerSystemID != remoteSystemID {
+			continue
+		}
+		if mdm.MembershipState != peerMdmJoined || mdm.CouplingRC != peerMdmCouplingSuccess {
+			return status.Errorf(codes.FailedPrecondition,
+				"peer mdm %s of remote system %s is not connected, membership state: %s, coupling: %s",
+				mdm.Name, remoteSystemID, mdm.MembershipState, mdm.CouplingRC)
+		}
+		peered = true
+	}
+	if !peered {
+		return status.Errorf(codes.FailedPrecondition, "system %s is not peered with remote system %s", systemID, remoteSystemID)
+	}
+
+	pdID := ""
+	if remoteProtectionDomain != "" {
+		pdID, err = s.getProtectionDomainIDFromName(ctx, remoteSystemID, remoteProtectionDomain)
+		if err != nil {
+			return status.Errorf(codes.FailedPrecondition,
+				"remote protection domain %s not found on system %s: %s", remoteProtectionDomain, remoteSystemID, err.Error())
+		}
+	}
+
+	if remoteStoragePool != "" {
+		if _, err := s.getStoragePoolID(ctx, remoteStoragePool, remoteSystemID, pdID); err != nil {
+			return status.Errorf(codes.FailedPrecondition,
+				"remote storage pool %s not found on system %s: %s", remoteStoragePool, remoteSystemID, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// rollbackReplicationConsistencyGroup deletes a replication consistency group that the same request created for a
+// volume whose replication pair could not be created, unless a concurrent request added a pair to it meanwhile.
+// Groups that existed before the request are never rolled back, even when they have no pairs.
+func (s *service) rollbackReplicationConsistencyGroup(ctx context.Context, systemID, groupID string) {
+	pairs, err := s.getReplicationPairs(ctx, systemID, groupID)
+	if err != nil {
+		Log.Errorf("[rollbackReplicationConsistencyGroup] - can't query replication pairs of group %s: %s", groupID, err.Error())
+		return
+	}
+	if len(pairs) != 0 {
+		return
+	}
+	err = s.DeleteReplicationConsistencyGroup(ctx, systemID, groupID)
+	s.auditOperation(ctx, auditReplicationRollback, systemID, map[string]string{"protectionGroupId": groupID}, err)
+	if err != nil {
+		Log.Errorf("[rollbackReplicationConsistencyGroup] - can't delete group %s: %s", groupID, err.Error())
+		return
+	}
+	Log.Printf("[rollbackReplicationConsistencyGroup] - deleted group %s", groupID)
+}
@@ -0,0 +1,215 @@
+// Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/dell/csi-vxflexos/v2/k8sutils"
+	"github.com/dell/dell-csi-extensions/replication"
+	"github.com/dell/goscaleio"
+	siotypes "github.com/dell/goscaleio/types/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// KeyReplicationStatusSinceConsistent represents key for the seconds since the driver last saw the protection
+	// group consistent, empty until it has seen it consistent
+	KeyReplicationStatusSinceConsistent = "secondsSinceSeenConsistent"
+	// KeyReplicationStatusRPO represents key for the target RPO in seconds of the protection group status
+	KeyReplicationStatusRPO = "rpoSeconds"
+	// KeyReplicationStatusRPOCompliant represents key for the protection group having been seen consistent within
+	// its RPO, empty until the driver has seen it consistent
+	KeyReplicationStatusRPOCompliant = "rpoCompliant"
+	// KeyReplicationStatusPairs represents key for the number of replication pairs of the protection group status
+	KeyReplicationStatusPairs = "pairCount"
+	// KeyReplicationStatusPairsInInitialCopy represents key for the number of pairs still in initial copy
+	KeyReplicationStatusPairsInInitialCopy = "pairsInInitialCopy"
+	// KeyReplicationStatusLastSeenConsistent represents key for the last time the driver saw the protection group
+	// consistent, empty until it has seen it consistent
+	KeyReplicationStatusLastSeenConsistent = "lastSeenConsistentTime"
+	// KeyReplicationStatusTestFailover represents key for a test failover running on the protection group
+	KeyReplicationStatusTestFailover = "testFailover"
+
+	// initialCopyDone is the initial copy state of a replication pair whose initial copy has completed
+	initialCopyDone = "Done"
+
+	metricReplicationSinceConsistent    = "powerflex_replication_seconds_since_seen_consistent"
+	metricReplicationRPO                = "powerflex_replication_rpo_seconds"
+	metricReplicationRPOCompliant       = "powerflex_replication_rpo_compliant"
+	metricReplicationPairs              = "powerflex_replication_pairs"
+	metricReplicationPairsInInitialCopy = "powerflex_replication_pairs_in_initial_copy"
+	metricReplicationLastSeenConsistent = "powerflex_replication_last_seen_consistent_timestamp_seconds"
+	metricReplicationStateTransitions   = "powerflex_replication_state_transitions_total"
+
+	// eventReasonReplicationStateChanged is the reason of the events emitted on the PVs and PVCs of a
+	// replication consistency group when its state changes
+	eventReasonReplicationStateChanged = "ReplicationStateChanged"
+)
+
+// replicationGroupStatus holds the RCG level attributes reported with the protection group status
+type replicationGroupStatus struct {
+	SinceConsistent    time.Duration
+	RPO                time.Duration
+	RPOCompliant       bool
+	Pairs              int
+	PairsInInitialCopy int
+	LastSeenConsistent time.Time // zero until the driver has seen the group consistent
+	TestFailover       bool
+}
+
+// getReplicationGroupStatus computes the status attributes of a replication consistency group.
+// PowerFlex does not report the replication lag of a group, only whether it is consistent. The driver remembers
+// the last time it polled the group and found it consistent, and reports the time since then. It is not the lag
+// of the replicated data: it only moves when the group is polled, starts over when the driver restarts and is
+// unknown until the driver has seen the group consistent.
+func (s *service) getReplicationGroupStatus(systemID string, group *siotypes.ReplicationConsistencyGroup, pairs []*siotypes.ReplicationPair, now time.Time) *replicationGroupStatus {
+	groupStatus := &replicationGroupStatus{
+		RPO:          time.Duration(group.RpoInSeconds) * time.Second,
+		Pairs:        len(pairs),
+		TestFailover: isTestFailover(group),
+	}
+	for _, pair := range pairs {
+		if pair.InitialCopyState != "" && pair.InitialCopyState != initialCopyDone {
+			groupStatus.PairsInInitialCopy++
+		}
+	}
+
+	key := systemID + "/" + group.ID
+	if group.CurrConsistMode == goscaleio.Consistent && groupStatus.PairsInInitialCopy == 0 {
+		s.rcgLastSeenConsistent.Store(key, now)
+	}
+	if lastSeen, ok := s.rcgLastSeenConsistent.Load(key); ok {
+		groupStatus.LastSeenConsistent = lastSeen.(time.Time)
+		groupStatus.SinceConsistent = now.Sub(groupStatus.LastSeenConsistent)
+		groupStatus.RPOCompliant = groupStatus.SinceConsistent <= groupStatus.RPO
+	}
+	return groupStatus
+}
+
+// attributes returns the status as protection group attributes
+func (g *replicationGroupStatus) attributes() map[string]string {
+	attributes := map[string]string{
+		KeyReplicationStatusSinceConsistent:    "",
+		KeyReplicationStatusRPO:                strconv.FormatInt(int64(g.RPO.Seconds()), 10),
+		KeyReplicationStatusRPOCompliant:       "",
+		KeyReplicationStatusPairs:              strconv.Itoa(g.Pairs),
+		KeyReplicationStatusPairsInInitialCopy: strconv.Itoa(g.PairsInInitialCopy),
+		KeyReplicationStatusLastSeenConsistent: "",
+		KeyReplicationStatusTestFailover:       strconv.FormatBool(g.TestFailover),
+	}
+	if !g.LastSeenConsistent.IsZero() {
+		attributes[KeyReplicationStatusSinceConsistent] = strconv.FormatInt(int64(g.SinceConsistent.Seconds()), 10)
+		attributes[KeyReplicationStatusRPOCompliant] = strconv.FormatBool(g.RPOCompliant)
+		attributes[KeyReplicationStatusLastSeenConsistent] = g.LastSeenConsistent.UTC().Format(time.RFC3339)
+	}
+	return attributes
+}
+
+// recordMetrics publishes the status as metrics of the replication consistency group. The metrics derived from
+// the last time the group was seen consistent are only published once the driver has seen it consistent.
+func (g *replicationGroupStatus) recordMetrics(systemID string, group *siotypes.ReplicationConsistencyGroup) {
+	labels := metricLabels{"system_id": systemID, "rcg_id": group.ID, "rcg_name": group.Name}
+	driverMetrics.setGauge(metricReplicationRPO, "Target RPO of the replication consistency group in seconds", labels, g.RPO.Seconds())
+	driverMetrics.setGauge(metricReplicationPairs, "Number of replication pairs of the replication consistency group", labels, float64(g.Pairs))
+	driverMetrics.setGauge(metricReplicationPairsInInitialCopy, "Number of replication pairs still in initial copy", labels, float64(g.PairsInInitialCopy))
+	if g.LastSeenConsistent.IsZero() {
+		return
+	}
+	rpoCompliant := 0.0
+	if g.RPOCompliant {
+		rpoCompliant = 1
+	}
+	driverMetrics.setGauge(metricReplicationSinceConsistent, "Seconds since the driver last saw the replication consistency group consistent", labels, g.SinceConsistent.Seconds())
+	driverMetrics.setGauge(metricReplicationRPOCompliant, "1 if the driver saw the replication consistency group consistent within its RPO", labels, rpoCompliant)
+	driverMetrics.setGauge(metricReplicationLastSeenConsistent, "Unix time the driver last saw the replication consistency group consistent", labels, float64(g.LastSeenConsistent.Unix()))
+}
+
+// recordStateTransition remembers the state of the replication consistency group. When the state differs from
+// the last known one, the transition is counted and an event is emitted on the PVs and PVCs of its volumes.
+func (s *service) recordStateTransition(ctx context.Context, systemID string, group *siotypes.ReplicationConsistencyGroup,
+	pairs []*siotypes.ReplicationPair, state replication.StorageProtectionGroupStatus_State,
+) {
+	previous, known := s.rcgStates.Load(systemID + "/" + group.ID)
+	s.rcgStates.Store(systemID+"/"+group.ID, state)
+	if !known || previous == state {
+		return
+	}
+	from := previous.(replication.StorageProtectionGroupStatus_State).String()
+	Log.Printf("[GetStorageProtectionGroupStatus] - group %s on system %s changed from %s to %s", group.Name, systemID, from, state)
+
+	labels := metricLabels{"system_id": systemID, "rcg_id": group.ID, "rcg_name": group.Name, "from": from, "to": state.String()}
+	driverMetrics.incCounter(metricReplicationStateTransitions, "Number of state transitions of the replication consistency group", labels)
+
+	eventType := corev1.EventTypeWarning
+	if state == replication.StorageProtectionGroupStatus_SYNCHRONIZED || state == replication.StorageProtectionGroupStatus_SYNC_IN_PROGRESS {
+		eventType = corev1.EventTypeNormal
+	}
+	for _, object := range s.getReplicatedVolumeObjects(ctx, systemID, pairs) {
+		recordObjectEvent(ctx, object, eventType, eventReasonReplicationStateChanged,
+			"replication consistency group %s on system %s changed from %s to %s", group.Name, systemID, from, state)
+	}
+}
+
+// getReplicatedVolumeObjects returns the PVs of the local volumes of the replication pairs, and the PVCs bound to them
+func (s *service) getReplicatedVolumeObjects(ctx context.Context, systemID string, pairs []*siotypes.ReplicationPair) []corev1.ObjectReference {
+	if K8sClientset == nil {
+		if err := k8sutils.CreateKubeClientSet(); err != nil {
+			Log.WithError(err).Error("unable to create k8s clientset for replication events")
+			return nil
+		}
+		K8sClientset = k8sutils.Clientset
+	}
+
+	volumeIDs := make(map[string]bool)
+	for _, pair := range pairs {
+		volumeIDs[pair.LocalVolumeID] = true
+	}
+
+	pvs, err := K8sClientset.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		Log.WithError(err).Error("unable to list PersistentVolumes for replication events")
+		return nil
+	}
+
+	objects := make([]corev1.ObjectReference, 0)
+	for _, pv := range pvs.Items {
+		if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != Name {
+			continue
+		}
+		handle := pv.Spec.CSI.VolumeHandle
+		if !volumeIDs[getVolumeIDFromCsiVolumeID(handle)] || s.getSystemIDFromCsiVolumeID(handle) != systemID {
+			continue
+		}
+		objects = append(objects, corev1.ObjectReference{
+			Kind:       "PersistentVolume",
+			APIVersion: "v1",
+			Name:       pv.Name,
+			UID:        pv.UID,
+		})
+		if claim := pv.Spec.ClaimRef; claim != nil {
+			objects = append(objects, corev1.ObjectReference{
+				Kind:       "PersistentVolumeClaim",
+				APIVersion: "v1",
+				Name:       claim.Name,
+				Namespace:  claim.Namespace,
+				UID:        claim.UID,
+			})
+		}
+	}
+	return objects
+}
@@ -18,7 +18,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -33,6 +35,7 @@ import (
 	"github.com/container-storage-interface/spec/lib/go/csi"
 	"github.com/dell/csi-vxflexos/v2/core"
 	"github.com/dell/csi-vxflexos/v2/k8sutils"
+	"github.com/dell/csi-vxflexos/v2/replicationext"
 	"github.com/dell/dell-csi-extensions/podmon"
 	"github.com/dell/dell-csi-extensions/replication"
 	volumeGroupSnapshot "github.com/dell/dell-csi-extensions/volumeGroupSnapshot"
@@ -104,15 +107,23 @@ var Log = logrus.New()
 
 // ArrayConnectionData contains data required to connect to array
 type ArrayConnectionData struct {
-	SystemID                  string `json:"systemID"`
-	Username                  string `json:"username"`
-	Password                  string `json:"password"`
-	Endpoint                  string `json:"endpoint"`
-	SkipCertificateValidation bool   `json:"skipCertificateValidation,omitempty"`
-	Insecure                  bool   `json:"insecure,omitempty"`
-	IsDefault                 bool   `json:"isDefault,omitempty"`
-	AllSystemNames            string `json:"allSystemNames"`
-	NasName                   string `json:"nasName"`
+	SystemID                  string            `json:"systemID"`
+	Username                  string            `json:"username"`
+	Password                  string            `json:"password"`
+	Endpoint                  string            `json:"endpoint"`
+	SkipCertificateValidation bool              `json:"skipCertificateValidation,omitempty"`
+	Insecure                  bool              `json:"insecure,omitempty"`
+	IsDefault                 bool              `json:"isDefault,omitempty"`
+	AllSystemNames            string            `json:"allSystemNames"`
+	NasName                   string            `json:"nasName"`
+	CACertificate             string            `json:"caCertificate,omitempty"`     // PEM bundle of the CAs the gateway certificate is validated against
+	ClientCertificate         string            `json:"clientCertificate,omitempty"` // PEM client certificate presented to the gateway
+	ClientKey                 string            `json:"clientKey,omitempty"`         // PEM key of the client certificate
+	TLSServerName             string            `json:"tlsServerName,omitempty"`     // name the gateway certificate is validated for
+	UsernameFile              string            `json:"usernameFile,omitempty"`      // file holding the username, instead of username
+	PasswordFile              string            `json:"passwordFile,omitempty"`      // file holding the password, instead of password
+	Vault                     *VaultCredentials `json:"vault,omitempty"`             // secret store holding the username and the password
+	Endpoints                 []string          `json:"endpoints,omitempty"`         // gateways tried in order after endpoint when it is unavailable
 }
 
 // Manifest is the SP's manifest.
@@ -130,6 +141,7 @@ type Service interface {
 	csi.NodeServer
 	BeforeServe(context.Context, *gocsi.StoragePlugin, net.Listener) error
 	RegisterAdditionalServers(server *grpc.Server)
+	AuditInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error)
 	ProcessMapSecretChange() error
 }
 
@@ -144,23 +156,48 @@ type Opts struct {
 	DisableCerts               bool   // used for unit testing only
 	Lsmod                      string // used for unit testing only
 	drvCfgQueryMDM             string // used for testing only
-	EnableSnapshotCGDelete     bool   // when snapshot deleted, enable deleting of all snaps in the CG of the snapshot
 	EnableListVolumesSnapshots bool   // when listing volumes, include snapshots and volumes
 	AllowRWOMultiPodAccess     bool   // allow multiple pods to access a RWO volume on the same node
 	IsHealthMonitorEnabled     bool   // allow driver to make use of the alpha feature gate, CSIVolumeHealth
-	IsSdcRenameEnabled         bool   // allow driver to enable renaming SDC
-	SdcPrefix                  string // prefix to be set for SDC name
-	IsApproveSDCEnabled        bool
 	replicationContextPrefix   string
 	replicationPrefix          string
-	MaxVolumesPerNode          int64
-	IsQuotaEnabled             bool   // allow driver to enable quota limits for NFS volumes
-	ExternalAccess             string // used for adding extra IP/IP range to the NFS export
 	KubeNodeName               string
+	NFSExportReconcileInterval time.Duration // how often stale hosts are pruned from NFS exports, 0 disables it
+	NFSExportReconcileDryRun   bool          // only report stale NFS export hosts, do not remove them
+	NFSMountOptions            []string      // default mount options for NFS volumes
+	NFSAllowedMountOptions     []string      // names of the NFS mount options that may be used
+	AutoReprotectInterval      time.Duration // how often failed over replication groups are checked, 0 disables it
+	AutoReprotectGracePeriod   time.Duration // how long a group stays failed over before it is reprotected
+	VolumeMetricsInterval      time.Duration // how often the volume and storage pool metrics are collected, 0 disables it
+	TracingEndpoint            string        // OTLP/HTTP endpoint the traces are exported to, empty disables tracing
+	TracingSampleRatio         float64       // fraction of the requests that are traced
+	AuditLogPath               string        // file the audit records are appended to, empty disables auditing
+	AuditLogMaxSizeMB          int           // size at which the audit log is rotated
+	AuditLogMaxBackups         int           // number of rotated audit logs kept
+	CredentialRefreshInterval  time.Duration // how often the credentials are read again from their provider, 0 disables it
+	GatewayHealthCheckInterval time.Duration // how often a failed gateway is checked for recovery, 0 disables it
+	PodName                    string        // name of the driver pod, events are emitted on it
+	PodNamespace               string        // namespace of the driver pod
+	reloadableOpts
+}
+
+// reloadableOpts are the driver settings updateReloadableParams may change at runtime, the service reads them with
+// reloadable
+type reloadableOpts struct {
+	EnableSnapshotCGDelete bool   // when snapshot deleted, enable deleting of all snaps in the CG of the snapshot
+	IsSdcRenameEnabled     bool   // allow driver to enable renaming SDC
+	SdcPrefix              string // prefix to be set for SDC name
+	IsApproveSDCEnabled    bool
+	MaxVolumesPerNode      int64
+	IsQuotaEnabled         bool   // allow driver to enable quota limits for NFS volumes
+	ExternalAccess         string // used for adding extra IP/IP range to the NFS export
 }
 
 type service struct {
 	opts                Opts
+	optsRWL             sync.RWMutex // guards the reloadableOpts, arrays and defaultSystemID of opts
+	connectionsRWL      sync.RWMutex // guards adminClients, systems, connectedSystemNameToID and gatewayProxies
+	reauthenticateMutex sync.Mutex   // serializes reading the credentials again after the gateway rejected them
 	adminClients        map[string]*sio.Client
 	systems             map[string]*sio.System
 	mode                string
@@ -176,6 +213,20 @@ type service struct {
 	// maps the first 24 bits of a volume ID to the volume's systemID
 	volumePrefixToSystems   map[string][]string
 	connectedSystemNameToID map[string]string
+	// maps systemID/groupID to the last time the driver saw the replication consistency group consistent
+	rcgLastSeenConsistent sync.Map
+	// maps systemID/groupID to the auto reprotect state of a failed over replication consistency group
+	rcgFailovers sync.Map
+	// maps systemID/groupID to the last known state of the replication consistency group
+	rcgStates sync.Map
+	// serves the driver metrics when enabled in the driver config params
+	metrics metricsListener
+	// audit log of the operations that change the storage, nil when auditing is disabled
+	auditLog *auditLog
+	// driver config params file, read without the environment, the reloadable settings are read from it
+	driverConfigParams *viper.Viper
+	// maps systemID to the TLS proxy of the arrays with custom TLS settings
+	gatewayProxies map[string]*gatewayProxy
 }
 
 // Process dynamic changes to configMap or Secret.
@@ -191,6 +242,8 @@ func (s *service) ProcessMapSecretChange() error {
 	if err := s.updateDriverConfigParams(Log, vc); err != nil {
 		return err
 	}
+	s.configureMetricsListener(vc)
+	s.driverConfigParams = readDriverConfigParams()
 	vc.WatchConfig()
 	vc.OnConfigChange(func(_ fsnotify.Event) {
 		// Putting in mutex to allow tests to pass with race flag
@@ -200,6 +253,9 @@ func (s *service) ProcessMapSecretChange() error {
 		if err := s.updateDriverConfigParams(Log, vc); err != nil {
 			Log.Warn(err)
 		}
+		s.configureMetricsListener(vc)
+		s.driverConfigParams = readDriverConfigParams()
+		s.updateReloadableParams(s.driverConfigParams)
 	})
 
 	// dynamic array secret change
@@ -214,10 +270,10 @@ func (s *service) ProcessMapSecretChange() error {
 		mx.Lock()
 		defer mx.Unlock()
 		Log.WithField("file", ArrayConfigFile).Info("array configuration file changed")
-		var err error
-		s.opts.arrays, err = getArrayConfig(context.Background())
+		err := s.reloadArrayConfig(context.Background())
 		if err != nil {
-			Log.WithError(err).Error("unable to reload multi array config file")
+			Log.WithError(err).Error("unable to reload multi array config file, keeping the current config")
+			return
 		}
 		err = s.doProbe(context.Background())
 		if err != nil {
@@ -301,13 +357,26 @@ func (s *service) updateDriverConfigParams(logger *logrus.Logger, v *viper.Viper
 	logFormat = strings.ToLower(logFormat)
 	logger.WithField("format", logFormat).Info("Read CSI_LOG_FORMAT from log configuration file")
 	if strings.EqualFold(logFormat, "json") {
-		logger.SetFormatter(&logrus.JSONFormatter{})
+		setRedactingFormatter(logger, &logrus.JSONFormatter{})
 	} else {
 		// use text formatter by defualt
 		if logFormat != "text" {
 			logger.WithField("format", logFormat).Info("CSI_LOG_FORMAT value not recognized, setting to text")
 		}
-		logger.SetFormatter(&logrus.TextFormatter{})
+		setRedactingFormatter(logger, &logrus.TextFormatter{})
+	}
+	// gocsi logs the requests and responses with the standard logger
+	setRedactingFormatter(logrus.StandardLogger(), logrus.StandardLogger().Formatter)
+
+	// the configured keys are masked in addition to the default ones, which cannot be turned off
+	redactKeys := append([]string{}, defaultLogRedactKeys...)
+	for _, key := range strings.Split(v.GetString(ParamLogRedactKeys), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			redactKeys = append(redactKeys, key)
+		}
+	}
+	if err := logRedactor.setPatterns(redactKeys); err != nil {
+		logger.WithError(err).Error("CSI_LOG_REDACT_KEYS value not valid, keeping the previous patterns")
 	}
 
 	level := DefaultLogLevel
@@ -331,11 +400,139 @@ func (s *service) updateDriverConfigParams(logger *logrus.Logger, v *viper.Viper
 	return nil
 }
 
+// readDriverConfigParams reads the driver config params file without the environment, so the environment of the
+// driver does not override the reloadable settings set in the file
+func readDriverConfigParams() *viper.Viper {
+	v := viper.New()
+	v.SetConfigFile(DriverConfigParamsFile)
+	if err := v.ReadInConfig(); err != nil {
+		Log.WithError(err).Error("unable to read config file, keeping the reloadable settings")
+	}
+	return v
+}
+
+// reloadable returns the current driver settings that may change at runtime
+func (s *service) reloadable() reloadableOpts {
+	s.optsRWL.RLock()
+	defer s.optsRWL.RUnlock()
+	return s.opts.reloadableOpts
+}
+
+// arrayOf returns the connection data of an array by its configured ID or its ID, nil when it is not configured.
+// The connection data is replaced, never changed, once it is added to the arrays.
+func (s *service) arrayOf(systemID string) *ArrayConnectionData {
+	s.optsRWL.RLock()
+	defer s.optsRWL.RUnlock()
+	return s.opts.arrays[systemID]
+}
+
+// arrayConfigs returns a copy of the connection data of the arrays by configured ID and ID, the arrays are
+// iterated over with it as the array config may be reloaded meanwhile
+func (s *service) arrayConfigs() map[string]*ArrayConnectionData {
+	s.optsRWL.RLock()
+	defer s.optsRWL.RUnlock()
+	return maps.Clone(s.opts.arrays)
+}
+
+// replaceArray replaces the connection data current of an array with updated under all the IDs it is added by
+func (s *service) replaceArray(current, updated *ArrayConnectionData) {
+	s.optsRWL.Lock()
+	defer s.optsRWL.Unlock()
+	for key, array := range s.opts.arrays {
+		if array == current {
+			s.opts.arrays[key] = updated
+		}
+	}
+}
+
+// defaultSystemID returns the ID of the default array, empty when there is none
+func (s *service) defaultSystemID() string {
+	s.optsRWL.RLock()
+	defer s.optsRWL.RUnlock()
+	return s.opts.defaultSystemID
+}
+
+// adminClientOf returns the admin client of a system by its ID or name, nil when it is not connected
+func (s *service) adminClientOf(systemID string) *sio.Client {
+	s.connectionsRWL.RLock()
+	defer s.connectionsRWL.RUnlock()
+	return s.adminClients[systemID]
+}
+
+// systemOf returns a system by its ID or name, nil when it is not connected
+func (s *service) systemOf(systemID string) *sio.System {
+	s.connectionsRWL.RLock()
+	defer s.connectionsRWL.RUnlock()
+	return s.systems[systemID]
+}
+
+// systemIDByName returns the ID of a connected system by its name or alternate name
+func (s *service) systemIDByName(name string) (string, bool) {
+	s.connectionsRWL.RLock()
+	defer s.connectionsRWL.RUnlock()
+	id, ok := s.connectedSystemNameToID[name]
+	return id, ok
+}
+
+// gatewayProxyOf returns the TLS proxy of a system, nil when it has none
+func (s *service) gatewayProxyOf(systemID string) *gatewayProxy {
+	s.connectionsRWL.RLock()
+	defer s.connectionsRWL.RUnlock()
+	return s.gatewayProxies[systemID]
+}
+
+// updateReloadableParams applies the driver settings set in the driver config params that may change at runtime.
+// An invalid value is reported and the setting keeps its current value.
+func (s *service) updateReloadableParams(v *viper.Viper) {
+	if v == nil {
+		return
+	}
+	s.optsRWL.Lock()
+	defer s.optsRWL.Unlock()
+	reloadParam(v, EnvEnableSnapshotCGDelete, &s.opts.EnableSnapshotCGDelete, strconv.ParseBool)
+	reloadParam(v, EnvIsSDCRenameEnabled, &s.opts.IsSdcRenameEnabled, strconv.ParseBool)
+	reloadParam(v, EnvSDCPrefix, &s.opts.SdcPrefix, func(value string) (string, error) {
+		return strings.TrimSpace(value), nil
+	})
+	reloadParam(v, EnvIsApproveSDCEnabled, &s.opts.IsApproveSDCEnabled, strconv.ParseBool)
+	reloadParam(v, EnvMaxVolumesPerNode, &s.opts.MaxVolumesPerNode, func(value string) (int64, error) {
+		maxVolumes, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err == nil && maxVolumes < 0 {
+			err = fmt.Errorf("maximum number of volumes per node %d is negative", maxVolumes)
+		}
+		return maxVolumes, err
+	})
+	reloadParam(v, EnvQuotaEnabled, &s.opts.IsQuotaEnabled, strconv.ParseBool)
+	reloadParam(v, EnvExternalAccess, &s.opts.ExternalAccess, func(value string) (string, error) {
+		if value = strings.TrimSpace(value); value == "" {
+			return "", nil
+		}
+		return ParseCIDR(value)
+	})
+}
+
+// reloadParam sets current to the parsed value of key when it is set and valid, and logs the change
+func reloadParam[T comparable](v *viper.Viper, key string, current *T, parse func(string) (T, error)) {
+	if !v.IsSet(key) {
+		return
+	}
+	value, err := parse(v.GetString(key))
+	if err != nil {
+		Log.WithError(err).Warnf("invalid value %q of driver config param %s, keeping %v", v.GetString(key), key, *current)
+		return
+	}
+	if value != *current {
+		Log.WithFields(logrus.Fields{"param": key, "old": *current, "new": value}).Info("driver config param changed")
+		*current = value
+	}
+}
+
 func (s *service) BeforeServe(
 	//nolint:revive
 	ctx context.Context, sp *gocsi.StoragePlugin, lis net.Listener,
 ) error {
 	defer func() {
+		current := s.reloadable()
 		fields := map[string]interface{}{
 			"sdcGUID":                s.opts.SdcGUID,
 			"thickprovision":         s.opts.Thick,
@@ -344,13 +541,24 @@ func (s *service) BeforeServe(
 			"mode":                   s.mode,
 			"allowRWOMultiPodAccess": s.opts.AllowRWOMultiPodAccess,
 			"IsHealthMonitorEnabled": s.opts.IsHealthMonitorEnabled,
-			"IsSdcRenameEnabled":     s.opts.IsSdcRenameEnabled,
-			"sdcPrefix":              s.opts.SdcPrefix,
-			"IsApproveSDCEnabled":    s.opts.IsApproveSDCEnabled,
-			"MaxVolumesPerNode":      s.opts.MaxVolumesPerNode,
-			"IsQuotaEnabled":         s.opts.IsQuotaEnabled,
-			"ExternalAccess":         s.opts.ExternalAccess,
+			"IsSdcRenameEnabled":     current.IsSdcRenameEnabled,
+			"sdcPrefix":              current.SdcPrefix,
+			"IsApproveSDCEnabled":    current.IsApproveSDCEnabled,
+			"MaxVolumesPerNode":      current.MaxVolumesPerNode,
+			"IsQuotaEnabled":         current.IsQuotaEnabled,
+			"ExternalAccess":         current.ExternalAccess,
 			"KubeNodeName":           s.opts.KubeNodeName,
+			"nfsReconcileInterval":   s.opts.NFSExportReconcileInterval,
+			"nfsReconcileDryRun":     s.opts.NFSExportReconcileDryRun,
+			"nfsMountOptions":        s.opts.NFSMountOptions,
+			"autoReprotectInterval":  s.opts.AutoReprotectInterval,
+			"autoReprotectGrace":     s.opts.AutoReprotectGracePeriod,
+			"credentialRefresh":      s.opts.CredentialRefreshInterval,
+			"gatewayHealthCheck":     s.opts.GatewayHealthCheckInterval,
+			"volumeMetricsInterval":  s.opts.VolumeMetricsInterval,
+			"tracingEndpoint":        s.opts.TracingEndpoint,
+			"tracingSampleRatio":     s.opts.TracingSampleRatio,
+			"auditLogPath":           s.opts.AuditLogPath,
 		}
 
 		Log.WithFields(fields).Infof("configured %s", Name)
@@ -381,7 +589,7 @@ func (s *service) BeforeServe(
 	if pd, ok := csictx.LookupEnv(ctx, "X_CSI_PRIVATE_MOUNT_DIR"); ok {
 		s.privDir = pd
 	}
-	if snapshotCGDelete, ok := csictx.LookupEnv(ctx, "X_CSI_VXFLEXOS_ENABLESNAPSHOTCGDELETE"); ok {
+	if snapshotCGDelete, ok := csictx.LookupEnv(ctx, EnvEnableSnapshotCGDelete); ok {
 		if snapshotCGDelete == "true" {
 			opts.EnableSnapshotCGDelete = true
 		}
@@ -455,6 +663,97 @@ func (s *service) BeforeServe(
 	if kubeNodeName, ok := csictx.LookupEnv(ctx, EnvKubeNodeName); ok {
 		opts.KubeNodeName = kubeNodeName
 	}
+	if reconcileInterval, ok := csictx.LookupEnv(ctx, EnvNFSExportReconcileInterval); ok && reconcileInterval != "" {
+		opts.NFSExportReconcileInterval, err = time.ParseDuration(reconcileInterval)
+		if err != nil || opts.NFSExportReconcileInterval < 0 {
+			Log.Warnf("error while parsing env variable '%s' value '%s', disabling NFS export reconciliation", EnvNFSExportReconcileInterval, reconcileInterval)
+			opts.NFSExportReconcileInterval = 0
+		}
+	}
+	if reprotectInterval, ok := csictx.LookupEnv(ctx, EnvReplicationAutoReprotectInterval); ok && reprotectInterval != "" {
+		opts.AutoReprotectInterval, err = time.ParseDuration(reprotectInterval)
+		if err != nil || opts.AutoReprotectInterval < 0 {
+			Log.Warnf("error while parsing env variable '%s' value '%s', disabling replication auto reprotect", EnvReplicationAutoReprotectInterval, reprotectInterval)
+			opts.AutoReprotectInterval = 0
+		}
+	}
+	opts.AutoReprotectGracePeriod = defaultAutoReprotectGracePeriod
+	if gracePeriod, ok := csictx.LookupEnv(ctx, EnvReplicationAutoReprotectGracePeriod); ok && gracePeriod != "" {
+		opts.AutoReprotectGracePeriod, err = time.ParseDuration(gracePeriod)
+		if err != nil || opts.AutoReprotectGracePeriod < 0 {
+			Log.Warnf("error while parsing env variable '%s' value '%s', defaulting to %s", EnvReplicationAutoReprotectGracePeriod, gracePeriod, defaultAutoReprotectGracePeriod)
+			opts.AutoReprotectGracePeriod = defaultAutoReprotectGracePeriod
+		}
+	}
+	if metricsInterval, ok := csictx.LookupEnv(ctx, EnvVolumeMetricsInterval); ok && metricsInterval != "" {
+		opts.VolumeMetricsInterval, err = time.ParseDuration(metricsInterval)
+		if err != nil || opts.VolumeMetricsInterval < 0 {
+			Log.Warnf("error while parsing env variable '%s' value '%s', disabling the volume metrics collector", EnvVolumeMetricsInterval, metricsInterval)
+			opts.VolumeMetricsInterval = 0
+		}
+	}
+	if endpoint, ok := csictx.LookupEnv(ctx, EnvTracingEndpoint); ok {
+		opts.TracingEndpoint = endpoint
+	}
+	opts.TracingSampleRatio = defaultTracingSampleRatio
+	if ratio, ok := csictx.LookupEnv(ctx, EnvTracingSampleRatio); ok && ratio != "" {
+		opts.TracingSampleRatio, err = strconv.ParseFloat(ratio, 64)
+		if err != nil || opts.TracingSampleRatio < 0 || opts.TracingSampleRatio > 1 {
+			Log.Warnf("error while parsing env variable '%s' value '%s', defaulting to %v", EnvTracingSampleRatio, ratio, defaultTracingSampleRatio)
+			opts.TracingSampleRatio = defaultTracingSampleRatio
+		}
+	}
+	if auditLogPath, ok := csictx.LookupEnv(ctx, EnvAuditLogPath); ok {
+		opts.AuditLogPath = auditLogPath
+	}
+	opts.AuditLogMaxSizeMB = defaultAuditLogMaxSizeMB
+	if maxSize, ok := csictx.LookupEnv(ctx, EnvAuditLogMaxSizeMB); ok && maxSize != "" {
+		opts.AuditLogMaxSizeMB, err = strconv.Atoi(maxSize)
+		if err != nil || opts.AuditLogMaxSizeMB <= 0 {
+			Log.Warnf("error while parsing env variable '%s' value '%s', defaulting to %d", EnvAuditLogMaxSizeMB, maxSize, defaultAuditLogMaxSizeMB)
+			opts.AuditLogMaxSizeMB = defaultAuditLogMaxSizeMB
+		}
+	}
+	opts.AuditLogMaxBackups = defaultAuditLogMaxBackups
+	if maxBackups, ok := csictx.LookupEnv(ctx, EnvAuditLogMaxBackups); ok && maxBackups != "" {
+		opts.AuditLogMaxBackups, err = strconv.Atoi(maxBackups)
+		if err != nil || opts.AuditLogMaxBackups < 0 {
+			Log.Warnf("error while parsing env variable '%s' value '%s', defaulting to %d", EnvAuditLogMaxBackups, maxBackups, defaultAuditLogMaxBackups)
+			opts.AuditLogMaxBackups = defaultAuditLogMaxBackups
+		}
+	}
+	opts.CredentialRefreshInterval = defaultCredentialRefreshInterval
+	if refreshInterval, ok := csictx.LookupEnv(ctx, EnvCredentialRefreshInterval); ok && refreshInterval != "" {
+		opts.CredentialRefreshInterval, err = time.ParseDuration(refreshInterval)
+		if err != nil || opts.CredentialRefreshInterval < 0 {
+			Log.Warnf("error while parsing env variable '%s' value '%s', defaulting to %s", EnvCredentialRefreshInterval, refreshInterval, defaultCredentialRefreshInterval)
+			opts.CredentialRefreshInterval = defaultCredentialRefreshInterval
+		}
+	}
+	opts.GatewayHealthCheckInterval = defaultGatewayHealthCheckInterval
+	if healthInterval, ok := csictx.LookupEnv(ctx, EnvGatewayHealthCheckInterval); ok && healthInterval != "" {
+		opts.GatewayHealthCheckInterval, err = time.ParseDuration(healthInterval)
+		if err != nil || opts.GatewayHealthCheckInterval < 0 {
+			Log.Warnf("error while parsing env variable '%s' value '%s', defaulting to %s", EnvGatewayHealthCheckInterval, healthInterval, defaultGatewayHealthCheckInterval)
+			opts.GatewayHealthCheckInterval = defaultGatewayHealthCheckInterval
+		}
+	}
+	if podName, ok := csictx.LookupEnv(ctx, EnvPodName); ok {
+		opts.PodName = podName
+	}
+	if podNamespace, ok := csictx.LookupEnv(ctx, EnvPodNamespace); ok {
+		opts.PodNamespace = podNamespace
+	}
+	if nfsMountOptions, ok := csictx.LookupEnv(ctx, EnvNFSMountOptions); ok {
+		opts.NFSMountOptions = splitNFSMountOptions(nfsMountOptions)
+	}
+	if allowedMountOptions, ok := csictx.LookupEnv(ctx, EnvNFSAllowedMountOptions); ok && strings.TrimSpace(allowedMountOptions) != "" {
+		opts.NFSAllowedMountOptions = splitNFSMountOptions(allowedMountOptions)
+	}
+	if err := validateNFSMountOptions(opts.NFSMountOptions, opts.NFSAllowedMountOptions); err != nil {
+		Log.Warnf("ignoring default NFS mount options '%v': %s", opts.NFSMountOptions, err.Error())
+		opts.NFSMountOptions = nil
+	}
 
 	// log csiNode topology keys
 	if err = s.logCsiNodeTopologyKeys(); err != nil {
@@ -478,10 +777,37 @@ func (s *service) BeforeServe(
 
 	opts.Thick = pb(EnvThick)
 	opts.AutoProbe = true
+	opts.NFSExportReconcileDryRun = pb(EnvNFSExportReconcileDryRun)
 
+	s.optsRWL.Lock()
 	s.opts = opts
+	s.optsRWL.Unlock()
+	// the settings set in the driver config params take precedence over the environment
+	s.updateReloadableParams(s.driverConfigParams)
+	s.connectionsRWL.Lock()
 	s.adminClients = make(map[string]*sio.Client)
 	s.systems = make(map[string]*sio.System)
+	s.connectionsRWL.Unlock()
+
+	if s.opts.AuditLogPath != "" {
+		s.auditLog = newAuditLog(s.opts.AuditLogPath, s.opts.AuditLogMaxSizeMB, s.opts.AuditLogMaxBackups)
+	}
+	if err := s.setupTracing(ctx); err != nil {
+		Log.WithError(err).Error("unable to set up tracing, continuing without it")
+	}
+
+	if s.opts.CredentialRefreshInterval > 0 {
+		go s.runCredentialRefresher(context.Background(), s.opts.CredentialRefreshInterval)
+	}
+	if !strings.EqualFold(s.mode, "node") && s.opts.NFSExportReconcileInterval > 0 {
+		go s.runNFSExportReconciler(context.Background(), s.opts.NFSExportReconcileInterval)
+	}
+	if !strings.EqualFold(s.mode, "node") && s.opts.AutoReprotectInterval > 0 {
+		go s.runReplicationAutoReprotect(context.Background(), s.opts.AutoReprotectInterval)
+	}
+	if !strings.EqualFold(s.mode, "node") && s.opts.VolumeMetricsInterval > 0 {
+		go s.runVolumeMetricsCollector(context.Background(), s.opts.VolumeMetricsInterval)
+	}
 
 	if _, ok := csictx.LookupEnv(ctx, "X_CSI_VXFLEXOS_NO_PROBE_ON_START"); !ok {
 		return s.doProbe(ctx)
@@ -495,11 +821,15 @@ func (s *service) checkNFS(ctx context.Context, systemID string) (bool, error) {
 		return false, err
 	}
 
-	c := s.adminClients[systemID]
+	c := s.adminClientOf(systemID)
 	if c == nil {
 		return false, nil
 	}
-	version, err := c.GetVersion()
+	var version string
+	err = s.gatewayCall(ctx, systemID, "GetVersion", func() (err error) {
+		version, err = c.GetVersion()
+		return err
+	})
 	if err != nil {
 		return false, err
 	}
@@ -554,6 +884,7 @@ func (s *service) RegisterAdditionalServers(server *grpc.Server) {
 	podmon.RegisterPodmonServer(server, s)
 	volumeGroupSnapshot.RegisterVolumeGroupSnapshotServer(server, s)
 	replication.RegisterReplicationServer(server, s)
+	replicationext.RegisterReplicationExtensionServer(server, s)
 }
 
 // getVolProvisionType returns a string indicating thin or thick provisioning
@@ -581,14 +912,18 @@ func (s *service) getVolProvisionType(params map[string]string) string {
 }
 
 // getVolByID returns the PowerFlex volume from the given Powerflex volume ID
-func (s *service) getVolByID(id string, systemID string) (*siotypes.Volume, error) {
-	adminClient := s.adminClients[systemID]
+func (s *service) getVolByID(ctx context.Context, id string, systemID string) (*siotypes.Volume, error) {
+	adminClient := s.adminClientOf(systemID)
 	if adminClient == nil {
 		return nil, fmt.Errorf("can't find adminClient by id %s", systemID)
 	}
 	// The GetVolume API returns a slice of volumes, but when only passing
 	// in a volume ID, the response will be just the one volume
-	vols, err := adminClient.GetVolume("", strings.TrimSpace(id), "", "", false)
+	var vols []*siotypes.Volume
+	err := s.gatewayCall(ctx, systemID, "GetVolume", func() (err error) {
+		vols, err = adminClient.GetVolume("", strings.TrimSpace(id), "", "", false)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -596,18 +931,26 @@ func (s *service) getVolByID(id string, systemID string) (*siotypes.Volume, erro
 }
 
 // getFilesystemByID returns the PowerFlex filesystem from the given Powerflex filesystem ID
-func (s *service) getFilesystemByID(id string, systemID string) (*siotypes.FileSystem, error) {
-	adminClient := s.adminClients[systemID]
+func (s *service) getFilesystemByID(ctx context.Context, id string, systemID string) (*siotypes.FileSystem, error) {
+	adminClient := s.adminClientOf(systemID)
 	if adminClient == nil {
 		return nil, fmt.Errorf("can't find adminClient by id %s", systemID)
 	}
-	system, err := adminClient.FindSystem(systemID, "", "")
+	var system *sio.System
+	err := s.gatewayCall(ctx, systemID, "FindSystem", func() (err error) {
+		system, err = adminClient.FindSystem(systemID, "", "")
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("can't find system by id %s", systemID)
 	}
 	// The GetFileSystemByIDName API returns a filesystem, but when only passing
 	// in a filesystem ID or name, the response will be just the one filesystem
-	fs, err := system.GetFileSystemByIDName(id, "")
+	var fs *siotypes.FileSystem
+	err = s.gatewayCall(ctx, systemID, "GetFileSystemByIDName", func() (err error) {
+		fs, err = system.GetFileSystemByIDName(id, "")
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -615,14 +958,18 @@ func (s *service) getFilesystemByID(id string, systemID string) (*siotypes.FileS
 }
 
 // getSDCID returns SDC ID from the given sdc GUID and system ID.
-func (s *service) getSDCID(sdcGUID string, systemID string) (string, error) {
+func (s *service) getSDCID(ctx context.Context, sdcGUID string, systemID string) (string, error) {
 	sdcGUID = strings.ToUpper(sdcGUID)
 
 	// Need to translate sdcGUID to fmt.Errorf("getSDCID error systemID not found: %s", systemID)
-	if s.systems[systemID] == nil {
+	if s.systemOf(systemID) == nil {
 		return "", fmt.Errorf("getSDCID error systemID not found: %s", systemID)
 	}
-	id, err := s.systems[systemID].FindSdc("SdcGUID", sdcGUID)
+	var id *sio.Sdc
+	err := s.gatewayCall(ctx, systemID, "FindSdc", func() (err error) {
+		id, err = s.systemOf(systemID).FindSdc("SdcGUID", sdcGUID)
+		return err
+	})
 	if err != nil {
 		return "", fmt.Errorf("error finding SDC from GUID: %s, err: %s",
 			sdcGUID, err.Error())
@@ -632,13 +979,17 @@ func (s *service) getSDCID(sdcGUID string, systemID string) (string, error) {
 }
 
 // getSDCIPs returns SDC IPs from the given sdc GUID and system ID.
-func (s *service) getSDCIPs(sdcGUID string, systemID string) ([]string, error) { // name change
+func (s *service) getSDCIPs(ctx context.Context, sdcGUID string, systemID string) ([]string, error) { // name change
 	sdcGUID = strings.ToUpper(sdcGUID)
 
-	if s.systems[systemID] == nil {
+	if s.systemOf(systemID) == nil {
 		return nil, fmt.Errorf("getSDCIPs error systemID not found: %s", systemID)
 	}
-	id, err := s.systems[systemID].FindSdc("SdcGUID", sdcGUID)
+	var id *sio.Sdc
+	err := s.gatewayCall(ctx, systemID, "FindSdc", func() (err error) {
+		id, err = s.systemOf(systemID).FindSdc("SdcGUID", sdcGUID)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error finding SDC from GUID: %s, err: %s",
 			sdcGUID, err.Error())
@@ -648,9 +999,13 @@ func (s *service) getSDCIPs(sdcGUID string, systemID string) ([]string, error) {
 }
 
 // getStoragePoolID returns pool ID from the given name, system ID, and protectionDomain name
-func (s *service) getStoragePoolID(name, systemID, pdID string) (string, error) {
+func (s *service) getStoragePoolID(ctx context.Context, name, systemID, pdID string) (string, error) {
 	// Need to lookup ID from the gateway, with respect to PD if provided
-	pool, err := s.adminClients[systemID].FindStoragePool("", name, "", pdID)
+	var pool *siotypes.StoragePool
+	err := s.gatewayCall(ctx, systemID, "FindStoragePool", func() (err error) {
+		pool, err = s.adminClientOf(systemID).FindStoragePool("", name, "", pdID)
+		return err
+	})
 	if err != nil {
 		return "", err
 	}
@@ -659,10 +1014,10 @@ func (s *service) getStoragePoolID(name, systemID, pdID string) (string, error)
 }
 
 // getCSIVolume converts the given siotypes.Volume to a CSI volume
-func (s *service) getCSIVolume(vol *siotypes.Volume, systemID string) *csi.Volume {
+func (s *service) getCSIVolume(ctx context.Context, vol *siotypes.Volume, systemID string) *csi.Volume {
 	// Get storage pool name; add to cache of ID to Name if not present
-	storagePoolName := s.getStoragePoolNameFromID(systemID, vol.StoragePoolID)
-	installationID, err := s.getArrayInstallationID(systemID)
+	storagePoolName := s.getStoragePoolNameFromID(ctx, systemID, vol.StoragePoolID)
+	installationID, err := s.getArrayInstallationID(ctx, systemID)
 	if err != nil {
 		Log.Printf("getCSIVolume error system not found: %s with error: %v\n", systemID, err)
 	}
@@ -687,10 +1042,10 @@ func (s *service) getCSIVolume(vol *siotypes.Volume, systemID string) *csi.Volum
 }
 
 // getCSIVolumeFromFilesystem converts the given siotypes.FileSystem to a CSI volume
-func (s *service) getCSIVolumeFromFilesystem(fs *siotypes.FileSystem, systemID string) *csi.Volume {
+func (s *service) getCSIVolumeFromFilesystem(ctx context.Context, fs *siotypes.FileSystem, systemID string) *csi.Volume {
 	// Get storage pool name; add to cache of ID to Name if not present
-	storagePoolName := s.getStoragePoolNameFromID(systemID, fs.StoragePoolID)
-	installationID, err := s.getArrayInstallationID(systemID)
+	storagePoolName := s.getStoragePoolNameFromID(ctx, systemID, fs.StoragePoolID)
+	installationID, err := s.getArrayInstallationID(ctx, systemID)
 	if err != nil {
 		Log.Printf("getCSIVolumeFromFilesystem error system not found: %s with error: %v\n", systemID, err)
 	}
@@ -719,8 +1074,12 @@ func (s *service) getCSIVolumeFromFilesystem(fs *siotypes.FileSystem, systemID s
 }
 
 // getArryaInstallationID returns installation ID for the given system ID
-func (s *service) getArrayInstallationID(systemID string) (string, error) {
-	system, err := s.adminClients[systemID].FindSystem(systemID, "", "")
+func (s *service) getArrayInstallationID(ctx context.Context, systemID string) (string, error) {
+	var system *sio.System
+	err := s.gatewayCall(ctx, systemID, "FindSystem", func() (err error) {
+		system, err = s.adminClientOf(systemID).FindSystem(systemID, "", "")
+		return err
+	})
 	if err != nil {
 		return "", err
 	}
@@ -762,11 +1121,15 @@ func (s *service) getCSISnapshotFromFileSystem(fs *siotypes.FileSystem, systemID
 }
 
 // Returns storage pool name from the given storage pool ID and system ID
-func (s *service) getStoragePoolNameFromID(systemID, id string) string {
+func (s *service) getStoragePoolNameFromID(ctx context.Context, systemID, id string) string {
 	storagePoolName := s.storagePoolIDToName[id]
 	if storagePoolName == "" {
-		adminClient := s.adminClients[systemID]
-		pool, err := adminClient.FindStoragePool(id, "", "", "")
+		adminClient := s.adminClientOf(systemID)
+		var pool *siotypes.StoragePool
+		err := s.gatewayCall(ctx, systemID, "FindStoragePool", func() (err error) {
+			pool, err = adminClient.FindStoragePool(id, "", "", "")
+			return err
+		})
 		if err == nil {
 			storagePoolName = pool.Name
 			s.storagePoolIDToName[id] = pool.Name
@@ -793,7 +1156,7 @@ func (s *service) logStatistics() {
 	}
 }
 
-func getArrayConfig(_ context.Context) (map[string]*ArrayConnectionData, error) {
+func getArrayConfig(ctx context.Context) (map[string]*ArrayConnectionData, error) {
 	arrays := make(map[string]*ArrayConnectionData)
 
 	_, err := os.Stat(ArrayConfigFile)
@@ -831,15 +1194,31 @@ func getArrayConfig(_ context.Context) (map[string]*ArrayConnectionData, error)
 			if systemID == "" {
 				return nil, fmt.Errorf("invalid value for system name at index %d", i)
 			}
+			if err := resolveArrayCredentials(ctx, &c); err != nil {
+				return nil, fmt.Errorf("invalid credentials at index %d: %v", i, err)
+			}
 			if c.Username == "" {
 				return nil, fmt.Errorf("invalid value for Username at index %d", i)
 			}
 			if c.Password == "" {
 				return nil, fmt.Errorf("invalid value for Password at index %d", i)
 			}
+			if endpoints := c.gatewayEndpoints(); c.Endpoint == "" && len(endpoints) > 0 {
+				c.Endpoint = endpoints[0]
+			}
 			if c.Endpoint == "" {
 				return nil, fmt.Errorf("invalid value for Endpoint at index %d", i)
 			}
+			if endpoints := c.gatewayEndpoints(); len(endpoints) > 1 {
+				for _, endpoint := range endpoints {
+					if u, err := url.Parse(endpoint); err != nil || u.Host == "" {
+						return nil, fmt.Errorf("invalid value for Endpoints at index %d: %s", i, endpoint)
+					}
+				}
+			}
+			if err := validateGatewayTLS(&c); err != nil {
+				return nil, fmt.Errorf("invalid TLS settings at index %d: %v", i, err)
+			}
 			// ArrayConnectionData
 			if c.AllSystemNames != "" {
 				names := strings.Split(c.AllSystemNames, ",")
@@ -855,6 +1234,7 @@ func getArrayConfig(_ context.Context) (map[string]*ArrayConnectionData, error)
 
 			fields := map[string]interface{}{
 				"endpoint":                  c.Endpoint,
+				"endpoints":                 c.Endpoints,
 				"user":                      c.Username,
 				"password":                  "********",
 				"skipCertificateValidation": skipCertificateValidation,
@@ -862,6 +1242,14 @@ func getArrayConfig(_ context.Context) (map[string]*ArrayConnectionData, error)
 				"systemID":                  c.SystemID,
 				"allSystemNames":            c.AllSystemNames,
 				"nasName":                   c.NasName,
+				"caCertificate":             c.CACertificate,
+				"clientCertificate":         c.ClientCertificate,
+				"tlsServerName":             c.TLSServerName,
+				"usernameFile":              c.UsernameFile,
+				"passwordFile":              c.PasswordFile,
+			}
+			if c.Vault != nil {
+				fields["vault"] = c.Vault.Address + "/v1/" + strings.TrimLeft(c.Vault.Path, "/")
 			}
 
 			Log.WithFields(fields).Infof("configured %s", c.SystemID)
@@ -929,8 +1317,12 @@ func getFilesystemIDFromCsiVolumeID(csiVolID string) string {
 
 // getNFSExport method returns the NFSExport for a given filesystem
 // and returns a not found error if the NFSExport does not exist for filesystem.
-func (s *service) getNFSExport(fs *siotypes.FileSystem, client *goscaleio.Client) (*siotypes.NFSExport, error) {
-	nfsExportList, err := client.GetNFSExport()
+func (s *service) getNFSExport(ctx context.Context, systemID string, fs *siotypes.FileSystem, client *goscaleio.Client) (*siotypes.NFSExport, error) {
+	var nfsExportList []siotypes.NFSExport
+	err := s.gatewayCall(ctx, systemID, "GetNFSExport", func() (err error) {
+		nfsExportList, err = client.GetNFSExport()
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -945,18 +1337,30 @@ func (s *service) getNFSExport(fs *siotypes.FileSystem, client *goscaleio.Client
 }
 
 // getFileInterface method returns the FileInterface for the given filesytem.
-func (s *service) getFileInterface(systemID string, fs *siotypes.FileSystem, client *goscaleio.Client) (*siotypes.FileInterface, error) {
-	system, err := client.FindSystem(systemID, "", "")
+func (s *service) getFileInterface(ctx context.Context, systemID string, fs *siotypes.FileSystem, client *goscaleio.Client) (*siotypes.FileInterface, error) {
+	var system *sio.System
+	err := s.gatewayCall(ctx, systemID, "FindSystem", func() (err error) {
+		system, err = client.FindSystem(systemID, "", "")
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	nas, err := system.GetNASByIDName(fs.NasServerID, "")
+	var nas *siotypes.NAS
+	err = s.gatewayCall(ctx, systemID, "GetNASByIDName", func() (err error) {
+		nas, err = system.GetNASByIDName(fs.NasServerID, "")
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	fileInterface, err := system.GetFileInterface(nas.CurrentPreferredIPv4InterfaceID)
+	var fileInterface *siotypes.FileInterface
+	err = s.gatewayCall(ctx, systemID, "GetFileInterface", func() (err error) {
+		fileInterface, err = system.GetFileInterface(nas.CurrentPreferredIPv4InterfaceID)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -974,7 +1378,7 @@ func (s *service) getSystemIDFromCsiVolumeID(csiVolID string) string {
 		tokens := strings.Split(csiVolID, "/")
 		if len(tokens) > 1 {
 			sys := csiVolID[:i]
-			if id, ok := s.connectedSystemNameToID[sys]; ok {
+			if id, ok := s.systemIDByName(sys); ok {
 				return id
 			}
 			return sys
@@ -987,7 +1391,7 @@ func (s *service) getSystemIDFromCsiVolumeID(csiVolID string) string {
 		tokens := strings.Split(csiVolID, "-")
 		if len(tokens) > 1 {
 			sys := csiVolID[:i]
-			if id, ok := s.connectedSystemNameToID[sys]; ok {
+			if id, ok := s.systemIDByName(sys); ok {
 				return id
 			}
 			return sys
@@ -1096,12 +1500,16 @@ func externalAccessAlreadyAdded(export *siotypes.NFSExport, externalAccess strin
 	return false
 }
 
-func (s *service) unexportFilesystem(_ context.Context, _ *csi.ControllerUnpublishVolumeRequest, client *goscaleio.Client, fs *siotypes.FileSystem, volumeContextID string, nodeIPs []string, nodeID string) error {
+func (s *service) unexportFilesystem(ctx context.Context, _ *csi.ControllerUnpublishVolumeRequest, systemID string, client *goscaleio.Client, fs *siotypes.FileSystem, volumeContextID string, nodeIPs []string, nodeID string) error {
 	nfsExportName := NFSExportNamePrefix + fs.Name
 	nfsExportExists := false
 	var nfsExportID string
 	// Check if nfs export exists for the File system
-	nfsExportList, err := client.GetNFSExport()
+	var nfsExportList []siotypes.NFSExport
+	err := s.gatewayCall(ctx, systemID, "GetNFSExport", func() (err error) {
+		nfsExportList, err = client.GetNFSExport()
+		return err
+	})
 	if err != nil {
 		return err
 	}
@@ -1119,7 +1527,11 @@ func (s *service) unexportFilesystem(_ context.Context, _ *csi.ControllerUnpubli
 	}
 
 	// remove host access from NFS Export
-	nfsExportResp, err := client.GetNFSExportByIDName(nfsExportID, "")
+	var nfsExportResp *siotypes.NFSExport
+	err = s.gatewayCall(ctx, systemID, "GetNFSExportByIDName", func() (err error) {
+		nfsExportResp, err = client.GetNFSExportByIDName(nfsExportID, "")
+		return err
+	})
 	if err != nil {
 		return status.Errorf(codes.NotFound, "Could not find NFS Export: %s", err)
 	}
@@ -1165,7 +1577,9 @@ func (s *service) unexportFilesystem(_ context.Context, _ *csi.ControllerUnpubli
 		}
 	}
 
-	err = client.ModifyNFSExport(modifyParam, nfsExportID)
+	err = s.gatewayCall(ctx, systemID, "ModifyNFSExport", func() error {
+		return client.ModifyNFSExport(modifyParam, nfsExportID)
+	})
 	if err != nil {
 		return status.Errorf(codes.NotFound, "Allocating host %s access to NFS Export failed. Error: %v", nodeID, err)
 	}
@@ -1176,7 +1590,7 @@ func (s *service) unexportFilesystem(_ context.Context, _ *csi.ControllerUnpubli
 }
 
 // exportFilesystem - Method to export filesystem with idempotency
-func (s *service) exportFilesystem(_ context.Context, _ *csi.ControllerPublishVolumeRequest, client *goscaleio.Client, fs *siotypes.FileSystem, nodeIPs []string, externalAccess string, nodeID string, pContext map[string]string, am *csi.VolumeCapability_AccessMode) (*csi.ControllerPublishVolumeResponse, error) {
+func (s *service) exportFilesystem(ctx context.Context, _ *csi.ControllerPublishVolumeRequest, systemID string, client *goscaleio.Client, fs *siotypes.FileSystem, nodeIPs []string, externalAccess string, nodeID string, pContext map[string]string, am *csi.VolumeCapability_AccessMode) (*csi.ControllerPublishVolumeResponse, error) {
 	for i, nodeIP := range nodeIPs {
 		nodeIPs[i] = nodeIP + "/255.255.255.255"
 	}
@@ -1187,7 +1601,11 @@ func (s *service) exportFilesystem(_ context.Context, _ *csi.ControllerPublishVo
 	var nfsExportID string
 
 	// Check if nfs export exists for the File system
-	nfsExportList, err := client.GetNFSExport()
+	var nfsExportList []siotypes.NFSExport
+	err := s.gatewayCall(ctx, systemID, "GetNFSExport", func() (err error) {
+		nfsExportList, err = client.GetNFSExport()
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -1203,10 +1621,14 @@ func (s *service) exportFilesystem(_ context.Context, _ *csi.ControllerPublishVo
 	// Create NFS export if it doesn't exist
 	if !nfsExportExists {
 		Log.Debugf("NFS Export does not exist for fs: %s ,proceeding to create NFS Export", fs.Name)
-		resp, err := client.CreateNFSExport(&siotypes.NFSExportCreate{
-			Name:         nfsExportName,
-			FileSystemID: fs.ID,
-			Path:         NFSExportLocalPath + fs.Name,
+		var resp *siotypes.NFSExportCreateResponse
+		err := s.gatewayCall(ctx, systemID, "CreateNFSExport", func() (err error) {
+			resp, err = client.CreateNFSExport(&siotypes.NFSExportCreate{
+				Name:         nfsExportName,
+				FileSystemID: fs.ID,
+				Path:         NFSExportLocalPath + fs.Name,
+			})
+			return err
 		})
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "create NFS Export failed. Error:%v", err)
@@ -1215,7 +1637,11 @@ func (s *service) exportFilesystem(_ context.Context, _ *csi.ControllerPublishVo
 		nfsExportID = resp.ID
 	}
 
-	nfsExportResp, err := client.GetNFSExportByIDName(nfsExportID, "")
+	var nfsExportResp *siotypes.NFSExport
+	err = s.gatewayCall(ctx, systemID, "GetNFSExportByIDName", func() (err error) {
+		nfsExportResp, err = client.GetNFSExportByIDName(nfsExportID, "")
+		return err
+	})
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "Could not find NFS Export: %s", err)
 	}
@@ -1301,7 +1727,9 @@ func (s *service) exportFilesystem(_ context.Context, _ *csi.ControllerPublishVo
 		if externalAccess != "" && !externalAccessAlreadyAdded(nfsExportResp, externalAccess) {
 			readHostList = append(readHostList, externalAccess)
 		}
-		err := client.ModifyNFSExport(&siotypes.NFSExportModify{AddReadOnlyRootHosts: readHostList}, nfsExportID)
+		err := s.gatewayCall(ctx, systemID, "ModifyNFSExport", func() error {
+			return client.ModifyNFSExport(&siotypes.NFSExportModify{AddReadOnlyRootHosts: readHostList}, nfsExportID)
+		})
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "Allocating host access failed with the error: %v", err)
 		}
@@ -1310,7 +1738,9 @@ func (s *service) exportFilesystem(_ context.Context, _ *csi.ControllerPublishVo
 		if externalAccess != "" && !externalAccessAlreadyAdded(nfsExportResp, externalAccess) {
 			readWriteHostList = append(readWriteHostList, externalAccess)
 		}
-		err := client.ModifyNFSExport(&siotypes.NFSExportModify{AddReadWriteRootHosts: readWriteHostList}, nfsExportID)
+		err := s.gatewayCall(ctx, systemID, "ModifyNFSExport", func() error {
+			return client.ModifyNFSExport(&siotypes.NFSExportModify{AddReadWriteRootHosts: readWriteHostList}, nfsExportID)
+		})
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "Allocating host access failed with the error: %v", err)
 		}
@@ -1325,9 +1755,9 @@ func (s *service) exportFilesystem(_ context.Context, _ *csi.ControllerPublishVo
 // this function updates volumePrefixToSystems, a map of volume ID prefixes -> system IDs
 // this is needed for checkSystemVolumes, a function that verifies that any legacy vol ID
 // is found on the default system, only
-func (s *service) UpdateVolumePrefixToSystemsMap(systemID string) error {
+func (s *service) UpdateVolumePrefixToSystemsMap(ctx context.Context, systemID string) error {
 	// get one vol from system
-	vols, _, err := s.listVolumes(systemID, 0, 1, true, false, "", "")
+	vols, _, err := s.listVolumes(ctx, systemID, 0, 1, true, false, "", "")
 	if err != nil {
 
 		Log.WithError(err).Errorf("failed to list vols for array %s : %s ", systemID, err.Error())
@@ -1370,7 +1800,7 @@ func (s *service) UpdateVolumePrefixToSystemsMap(systemID string) error {
 	return nil
 }
 
-func (s *service) checkVolumesMap(volumeID string) error {
+func (s *service) checkVolumesMap(ctx context.Context, volumeID string) error {
 	systemID := s.getSystemIDFromCsiVolumeID(volumeID)
 
 	// ID is legacy, so we  ensure it's only found on default system
@@ -1391,7 +1821,7 @@ func (s *service) checkVolumesMap(volumeID string) error {
 			// key found, make sure vol isn't on non-default system
 			// For each systemID in s.volumePrefixToSystems[key], read all volumes from the system
 			for _, systemID := range s.volumePrefixToSystems[key] {
-				vols, _, err := s.listVolumes(systemID, 0, 0, true, false, "", "")
+				vols, _, err := s.listVolumes(ctx, systemID, 0, 0, true, false, "", "")
 				if err != nil {
 					Log.WithError(err).Errorf("failed to list vols for array %s : %s ", systemID, err.Error())
 					return fmt.Errorf("failed to list vols for array %s : %s ", systemID, err.Error())
@@ -1423,30 +1853,42 @@ func (s *service) calcKeyForMap(volumeID string) string {
 	return key
 }
 
-func (s *service) getProtectionDomainIDFromName(systemID, protectionDomainName string) (string, error) {
+func (s *service) getProtectionDomainIDFromName(ctx context.Context, systemID, protectionDomainName string) (string, error) {
 	if protectionDomainName == "" {
 		Log.Printf("Protection Domain not provided; there could be conflicts if two storage pools share a name")
 		return "", nil
 	}
-	system, err := s.adminClients[systemID].FindSystem(systemID, "", "")
+	var system *sio.System
+	err := s.gatewayCall(ctx, systemID, "FindSystem", func() (err error) {
+		system, err = s.adminClientOf(systemID).FindSystem(systemID, "", "")
+		return err
+	})
 	if err != nil {
 		return "", err
 	}
-	pd, err := system.FindProtectionDomain("", protectionDomainName, "")
+	var pd *siotypes.ProtectionDomain
+	err = s.gatewayCall(ctx, systemID, "FindProtectionDomain", func() (err error) {
+		pd, err = system.FindProtectionDomain("", protectionDomainName, "")
+		return err
+	})
 	if err != nil {
 		return "", err
 	}
 	return pd.ID, nil
 }
 
-func (s *service) getSystem(systemID string) (*siotypes.System, error) {
-	adminClient := s.adminClients[systemID]
+func (s *service) getSystem(ctx context.Context, systemID string) (*siotypes.System, error) {
+	adminClient := s.adminClientOf(systemID)
 	if adminClient == nil {
 		return nil, fmt.Errorf("can't find adminClient by id %s", systemID)
 	}
 
 	// Gets the desired system content. Needed for remote replication.
-	systems, err := adminClient.GetSystems()
+	var systems []*siotypes.System
+	err := s.gatewayCall(ctx, systemID, "GetSystems", func() (err error) {
+		systems, err = adminClient.GetSystems()
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -1458,21 +1900,25 @@ func (s *service) getSystem(systemID string) (*siotypes.System, error) {
 	return nil, fmt.Errorf("System %s not found", systemID)
 }
 
-func (s *service) getPeerMdms(systemID string) ([]*siotypes.PeerMDM, error) {
-	adminClient := s.adminClients[systemID]
+func (s *service) getPeerMdms(ctx context.Context, systemID string) ([]*siotypes.PeerMDM, error) {
+	adminClient := s.adminClientOf(systemID)
 	if adminClient == nil {
 		return nil, fmt.Errorf("can't find adminClient by id %s", systemID)
 	}
 
-	mdms, err := adminClient.GetPeerMDMs()
+	var mdms []*siotypes.PeerMDM
+	err := s.gatewayCall(ctx, systemID, "GetPeerMDMs", func() (err error) {
+		mdms, err = adminClient.GetPeerMDMs()
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 	return mdms, nil
 }
 
-func (s *service) getProtectionDomain(systemID string, pdName string) (string, error) {
-	pdID, err := s.getProtectionDomainIDFromName(systemID, pdName)
+func (s *service) getProtectionDomain(ctx context.Context, systemID string, pdName string) (string, error) {
+	pdID, err := s.getProtectionDomainIDFromName(ctx, systemID, pdName)
 	if err != nil {
 		return "", err
 	}
@@ -1481,12 +1927,20 @@ func (s *service) getProtectionDomain(systemID string, pdName string) (string, e
 		return pdID, nil
 	}
 
-	system, err := s.adminClients[systemID].FindSystem(systemID, "", "")
+	var system *sio.System
+	err = s.gatewayCall(ctx, systemID, "FindSystem", func() (err error) {
+		system, err = s.adminClientOf(systemID).FindSystem(systemID, "", "")
+		return err
+	})
 	if err != nil {
 		return "", err
 	}
 
-	pd, err := system.GetProtectionDomain("")
+	var pd []*siotypes.ProtectionDomain
+	err = s.gatewayCall(ctx, systemID, "GetProtectionDomain", func() (err error) {
+		pd, err = system.GetProtectionDomain("")
+		return err
+	})
 	if err != nil {
 		return "", err
 	}
@@ -1502,13 +1956,13 @@ func (s *service) getProtectionDomain(systemID string, pdName string) (string, e
 	return pdID, nil
 }
 
-func (s *service) removeVolumeFromReplicationPair(systemID string, volumeID string) (*siotypes.ReplicationPair, error) {
-	adminClient := s.adminClients[systemID]
+func (s *service) removeVolumeFromReplicationPair(ctx context.Context, systemID string, volumeID string) (*siotypes.ReplicationPair, error) {
+	adminClient := s.adminClientOf(systemID)
 	if adminClient == nil {
 		return nil, fmt.Errorf("can't find adminClient by id %s", systemID)
 	}
 
-	repPair, err := s.findReplicationPairByVolID(systemID, volumeID)
+	repPair, err := s.findReplicationPairByVolID(ctx, systemID, volumeID)
 	if err != nil {
 		return nil, err
 	}
@@ -1516,7 +1970,11 @@ func (s *service) removeVolumeFromReplicationPair(systemID string, volumeID stri
 	pair := goscaleio.NewReplicationPair(adminClient)
 	pair.ReplicaitonPair = repPair
 
-	resp, err := pair.RemoveReplicationPair(true)
+	var resp *siotypes.ReplicationPair
+	err = s.gatewayCall(ctx, systemID, "RemoveReplicationPair", func() (err error) {
+		resp, err = pair.RemoveReplicationPair(true)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -1524,14 +1982,18 @@ func (s *service) removeVolumeFromReplicationPair(systemID string, volumeID stri
 	return resp, nil
 }
 
-func (s *service) findReplicationPairByVolID(systemID, volumeID string) (*siotypes.ReplicationPair, error) {
-	adminClient := s.adminClients[systemID]
+func (s *service) findReplicationPairByVolID(ctx context.Context, systemID, volumeID string) (*siotypes.ReplicationPair, error) {
+	adminClient := s.adminClientOf(systemID)
 	if adminClient == nil {
 		return nil, fmt.Errorf("can't find adminClient by id %s", systemID)
 	}
 
 	// Gets a list of all replication pairs.
-	pairs, err := adminClient.GetAllReplicationPairs()
+	var pairs []*siotypes.ReplicationPair
+	err := s.gatewayCall(ctx, systemID, "GetAllReplicationPairs", func() (err error) {
+		pairs, err = adminClient.GetAllReplicationPairs()
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -1547,13 +2009,13 @@ func (s *service) findReplicationPairByVolID(systemID, volumeID string) (*siotyp
 
 func (s *service) expandReplicationPair(ctx context.Context, req *csi.ControllerExpandVolumeRequest, systemID, volumeID string) error {
 	Log.Printf("[expandReplicationPair] - Start: %s, %s", systemID, volumeID)
-	pair, err := s.findReplicationPairByVolID(systemID, volumeID)
+	pair, err := s.findReplicationPairByVolID(ctx, systemID, volumeID)
 	if err != nil {
 		return err
 	}
 
 	Log.Printf("[expandReplicationPair] - Pair Found: %+v", pair)
-	group, err := s.getReplicationConsistencyGroupByID(systemID, pair.ReplicationConsistencyGroupID)
+	group, err := s.getReplicationConsistencyGroupByID(ctx, systemID, pair.ReplicationConsistencyGroupID)
 	if err != nil {
 		return err
 	}
@@ -1580,30 +2042,38 @@ func (s *service) expandReplicationPair(ctx context.Context, req *csi.Controller
 		return err
 	}
 
-	vol, _ := s.getVolByID(volumeID, systemID)
+	vol, _ := s.getVolByID(ctx, volumeID, systemID)
 
 	attempts := 0
 	maxVolRetrievalRetries := 100
 
 	for int64(vol.SizeInKb) != requestedSize && attempts < maxVolRetrievalRetries {
 		time.Sleep(3 * time.Millisecond)
-		vol, _ = s.getVolByID(volumeID, systemID)
+		vol, _ = s.getVolByID(ctx, volumeID, systemID)
 		attempts++
 	}
 
 	return nil
 }
 
-func (s *service) getNASServerIDFromName(systemID, nasName string) (string, error) {
+func (s *service) getNASServerIDFromName(ctx context.Context, systemID, nasName string) (string, error) {
 	if nasName == "" {
 		Log.Printf("NAS server not provided.")
 		return "", nil
 	}
-	system, err := s.adminClients[systemID].FindSystem(systemID, "", "")
+	var system *sio.System
+	err := s.gatewayCall(ctx, systemID, "FindSystem", func() (err error) {
+		system, err = s.adminClientOf(systemID).FindSystem(systemID, "", "")
+		return err
+	})
 	if err != nil {
 		return "", err
 	}
-	nas, err := system.GetNASByIDName("", nasName)
+	var nas *siotypes.NAS
+	err = s.gatewayCall(ctx, systemID, "GetNASByIDName", func() (err error) {
+		nas, err = system.GetNASByIDName("", nasName)
+		return err
+	})
 	if err != nil {
 		return "", err
 	}
@@ -14,32 +14,56 @@
 package service
 
 import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"math/big"
 	"net"
+	"net/http"
 	"net/http/httptest"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"reflect"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
 	"github.com/google/uuid"
+	"github.com/prometheus/common/expfmt"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/cucumber/godog"
+	"github.com/dell/csi-vxflexos/v2/replicationext"
 	"github.com/dell/dell-csi-extensions/podmon"
 	"github.com/dell/dell-csi-extensions/replication"
 	volGroupSnap "github.com/dell/dell-csi-extensions/volumeGroupSnapshot"
 	"github.com/dell/gofsutil"
 	"github.com/dell/goscaleio"
 	types "github.com/dell/goscaleio/types/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/metadata"
 	v1 "k8s.io/api/core/v1"
@@ -140,13 +164,41 @@ type feature struct {
 	replicationCapabilitiesResponse       *replication.GetReplicationCapabilityResponse
 	clusterUID                            string
 	createStorageProtectionGroupResponse  *replication.CreateStorageProtectionGroupResponse
+	previousProtectionGroupResponse       *replication.CreateStorageProtectionGroupResponse
+	nonReplicatedVolume                   *replicationext.RemoveVolumeFromProtectionGroupResponse
+	createRemoteVolumeResponse            *replication.CreateRemoteVolumeResponse
+	restoredVolumes                       map[string]*replication.Volume
 	deleteStorageProtectionGroupResponse  *replication.DeleteStorageProtectionGroupResponse
+	executeActionResponse                 *replication.ExecuteActionResponse
+	describeProtectionGroupResponse       *replicationext.DescribeStorageProtectionGroupResponse
+	addVolumeToProtectionGroupResponse    *replicationext.AddVolumeToProtectionGroupResponse
+	replicationGroupStatus                *replicationGroupStatus
 	fileSystemID                          string
 	systemID                              string
 	context                               context.Context
 	nodeLabels                            map[string]string
 	maxVolSize                            int64
 	nfsExport                             types.NFSExport
+	nfsExportReconcileResults             []*nfsExportReconcileResult
+	autoReprotectStart                    time.Time
+	autoReprotectDecisions                []*autoReprotectDecision
+	spanRecorder                          *tracetest.SpanRecorder
+	auditLogPath                          string
+	logRedactKeys                         string
+	logOutput                             *bytes.Buffer
+	clientsBeforeReload                   map[string]*goscaleio.Client
+	arraysBeforeReload                    map[string]ArrayConnectionData
+	arraysBeforeRefresh                   map[string]*ArrayConnectionData
+	clientsBeforeRefresh                  map[string]*goscaleio.Client
+	tlsDir                                string
+	tlsServer                             *httptest.Server
+	tlsArray                              *ArrayConnectionData
+	tlsClient                             *goscaleio.Client
+	tlsProxy                              *gatewayProxy
+	credentialsDir                        string
+	vaultServer                           *httptest.Server
+	loadedArrays                          map[string]*ArrayConnectionData
+	gateways                              map[string]*gatewayStub
 }
 
 func (f *feature) checkGoRoutines(tag string) {
@@ -290,6 +342,10 @@ func (f *feature) aVxFlexOSServiceWithTimeoutMilliseconds(millis int) error {
 
 func (f *feature) getService() *service {
 	testControllerHasNoConnection = false
+	requiredGatewayPassword = ""
+	gatewaySessionExpired = false
+	driverMetrics = newMetricsRegistry()
+	logRedactor = newRedactor()
 	svc := new(service)
 
 	svc.adminClients = make(map[string]*goscaleio.Client)
@@ -458,6 +514,695 @@ func (f *feature) aValidDynamicLogChange(file, expectedLevel string) error {
 	return nil
 }
 
+func (f *feature) iReloadTheDriverConfigParamsWith(key, value string) error {
+	v := viper.New()
+	v.Set(key, value)
+	f.service.updateReloadableParams(v)
+	return nil
+}
+
+func (f *feature) iReloadTheDriverConfigParamsFileWithWhileTheEnvironmentSetsIt(key, value, envValue string) error {
+	file := filepath.Join(os.TempDir(), "driver-config-params.yaml")
+	if err := os.WriteFile(file, []byte(key+": \""+value+"\"\n"), 0o600); err != nil {
+		return err
+	}
+	defer os.Remove(file)
+	envValueBefore, envSet := os.LookupEnv(key)
+	_ = os.Setenv(key, envValue)
+	configFile := DriverConfigParamsFile
+	DriverConfigParamsFile = file
+	defer func() {
+		DriverConfigParamsFile = configFile
+		if envSet {
+			_ = os.Setenv(key, envValueBefore)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	}()
+	f.service.updateReloadableParams(readDriverConfigParams())
+	return nil
+}
+
+func (f *feature) theDriverSettingIs(key, expected string) error {
+	settings := map[string]interface{}{
+		EnvEnableSnapshotCGDelete: f.service.opts.EnableSnapshotCGDelete,
+		EnvIsSDCRenameEnabled:     f.service.opts.IsSdcRenameEnabled,
+		EnvSDCPrefix:              f.service.opts.SdcPrefix,
+		EnvIsApproveSDCEnabled:    f.service.opts.IsApproveSDCEnabled,
+		EnvMaxVolumesPerNode:      f.service.opts.MaxVolumesPerNode,
+		EnvQuotaEnabled:           f.service.opts.IsQuotaEnabled,
+		EnvExternalAccess:         f.service.opts.ExternalAccess,
+	}
+	if actual := fmt.Sprint(settings[key]); actual != expected {
+		return fmt.Errorf("expected %s to be %q but it was %q", key, expected, actual)
+	}
+	return nil
+}
+
+func (f *feature) iReloadTheArrayConfigWith(change string) error {
+	f.clientsBeforeReload = make(map[string]*goscaleio.Client)
+	for key, c := range f.service.adminClients {
+		f.clientsBeforeReload[key] = c
+	}
+	f.arraysBeforeReload = make(map[string]ArrayConnectionData)
+	arrays := make([]ArrayConnectionData, 0)
+	for key, array := range f.service.opts.arrays {
+		f.arraysBeforeReload[key] = *array
+		if key != array.SystemID {
+			continue
+		}
+		updated := *array
+		switch {
+		case change == "password" && key == arrayID:
+			updated.Password = "NewPassword123"
+		case change == "endpoint" && key == arrayID2:
+			updated.Endpoint = f.server.URL
+		case change == "endpoints" && key == arrayID:
+			updated.Endpoints = []string{"http://127.0.0.1:1"}
+		case change == "removed" && key == arrayID2:
+			continue
+		case change == "default":
+			updated.IsDefault = key == arrayID2
+		}
+		arrays = append(arrays, updated)
+	}
+	if change == "added" {
+		arrays = append(arrays, ArrayConnectionData{
+			SystemID: "1235e15806d1ec0f", Username: "admin", Password: "Password123", Endpoint: f.server.URL, Insecure: true,
+		})
+	}
+
+	config, err := json.Marshal(arrays)
+	if err != nil {
+		return err
+	}
+	if change == "invalid" {
+		config = []byte("[not an array config")
+	}
+	dir, err := os.MkdirTemp("", "array-config")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	arrayConfigFile := ArrayConfigFile
+	defer func() { ArrayConfigFile = arrayConfigFile }()
+	ArrayConfigFile = dir + "/config"
+	if err := os.WriteFile(ArrayConfigFile, config, 0o600); err != nil {
+		return err
+	}
+	f.err = f.service.reloadArrayConfig(context.Background())
+	return nil
+}
+
+func (f *feature) theArrayIs(systemID, result string) error {
+	before, after := f.clientsBeforeReload[systemID], f.service.adminClients[systemID]
+	switch result {
+	case "is reconnected":
+		if after == nil || after == before {
+			return fmt.Errorf("expected a new client for array %s", systemID)
+		}
+	case "keeps its connection":
+		if after == nil || after != before {
+			return fmt.Errorf("expected array %s to keep its client", systemID)
+		}
+		if array := f.service.opts.arrays[systemID]; array == nil || array.Password != f.arraysBeforeReload[systemID].Password ||
+			array.Endpoint != f.arraysBeforeReload[systemID].Endpoint {
+			return fmt.Errorf("expected array %s to keep its connection config", systemID)
+		}
+	case "is disconnected":
+		if after != nil || f.service.systems[systemID] != nil || f.service.opts.arrays[systemID] != nil {
+			return fmt.Errorf("expected array %s to be disconnected", systemID)
+		}
+	case "is added":
+		if f.service.opts.arrays[systemID] == nil {
+			return fmt.Errorf("expected array %s to be configured", systemID)
+		}
+	}
+	return nil
+}
+
+func (f *feature) theArrayConfigHasArraysWithDefault(count int, defaultSystemID string) error {
+	configured := 0
+	for key, array := range f.service.opts.arrays {
+		if key == array.SystemID {
+			configured++
+		}
+	}
+	if configured != count {
+		return fmt.Errorf("expected %d arrays but found %d", count, configured)
+	}
+	if f.service.opts.defaultSystemID != defaultSystemID {
+		return fmt.Errorf("expected default array %s but it was %s", defaultSystemID, f.service.opts.defaultSystemID)
+	}
+	return nil
+}
+
+// writeTestCertificate writes the PEM certificate and key of template to dir, signed by parent or self signed
+func writeTestCertificate(dir, name string, template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := os.WriteFile(dir+"/"+name+".crt", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		return nil, nil, err
+	}
+	if err := os.WriteFile(dir+"/"+name+".key", pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600); err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	return cert, key, err
+}
+
+func (f *feature) aGatewayThatRequiresATLSClientCertificate() error {
+	if f.tlsProxy != nil {
+		f.tlsProxy.close()
+		f.tlsProxy = nil
+	}
+	if f.tlsServer != nil {
+		f.tlsServer.Close()
+	}
+	dir, err := os.MkdirTemp("", "gateway-tls")
+	if err != nil {
+		return err
+	}
+	f.tlsDir = dir
+
+	certificate := func(serial int64, name string) *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+	}
+	caTemplate := certificate(1, "powerflex test CA")
+	caTemplate.IsCA, caTemplate.BasicConstraintsValid, caTemplate.KeyUsage = true, true, x509.KeyUsageCertSign
+	ca, caKey, err := writeTestCertificate(dir, "ca", caTemplate, nil, nil)
+	if err != nil {
+		return err
+	}
+	otherCA, otherCAKey, err := writeTestCertificate(dir, "other-ca", caTemplate, nil, nil)
+	if err != nil {
+		return err
+	}
+	serverTemplate := certificate(2, "gateway.powerflex.local")
+	serverTemplate.DNSNames, serverTemplate.ExtKeyUsage = []string{"gateway.powerflex.local"}, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	if _, _, err := writeTestCertificate(dir, "server", serverTemplate, ca, caKey); err != nil {
+		return err
+	}
+	clientTemplate := certificate(3, "csi-vxflexos")
+	clientTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	if _, _, err := writeTestCertificate(dir, "client", clientTemplate, ca, caKey); err != nil {
+		return err
+	}
+	if _, _, err := writeTestCertificate(dir, "other-client", clientTemplate, otherCA, otherCAKey); err != nil {
+		return err
+	}
+
+	serverCert, err := tls.LoadX509KeyPair(dir+"/server.crt", dir+"/server.key")
+	if err != nil {
+		return err
+	}
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca)
+	f.tlsServer = httptest.NewUnstartedServer(getHandler())
+	f.tlsServer.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+		MinVersion:   tls.VersionTLS12,
+	}
+	f.tlsServer.StartTLS()
+	return nil
+}
+
+// gatewayTLSArray returns the connection data of the TLS gateway with the given TLS settings
+func (f *feature) gatewayTLSArray(settings string) *ArrayConnectionData {
+	array := &ArrayConnectionData{
+		SystemID:          arrayID,
+		Username:          "admin",
+		Password:          "Password123",
+		Endpoint:          f.tlsServer.URL,
+		CACertificate:     f.tlsDir + "/ca.crt",
+		ClientCertificate: f.tlsDir + "/client.crt",
+		ClientKey:         f.tlsDir + "/client.key",
+		TLSServerName:     "gateway.powerflex.local",
+	}
+	switch settings {
+	case "no client certificate":
+		array.ClientCertificate, array.ClientKey = "", ""
+	case "no server name":
+		array.TLSServerName = ""
+	case "untrusted client certificate":
+		array.ClientCertificate, array.ClientKey = f.tlsDir+"/other-client.crt", f.tlsDir+"/other-client.key"
+	case "untrusted CA":
+		array.CACertificate = f.tlsDir + "/other-ca.crt"
+	case "skipped validation":
+		array.CACertificate, array.TLSServerName, array.SkipCertificateValidation = "", "", true
+	case "http endpoint":
+		array.Endpoint = strings.Replace(f.tlsServer.URL, "https://", "http://", 1)
+	case "missing CA file":
+		array.CACertificate = f.tlsDir + "/missing.crt"
+	case "certificate without key":
+		array.ClientKey = ""
+	case "CA and skipped validation":
+		array.Insecure = true
+	case "key as CA":
+		array.CACertificate = f.tlsDir + "/ca.key"
+	}
+	return array
+}
+
+func (f *feature) iConnectToTheGatewayWithTLSSettings(settings string) error {
+	f.tlsArray = f.gatewayTLSArray(settings)
+	f.tlsClient, f.tlsProxy, f.err = f.service.connectArray(context.Background(), f.tlsArray)
+	return nil
+}
+
+func (f *feature) theGatewayProxyRejectsTheRequestsWithoutItsSecret() error {
+	if f.tlsProxy == nil {
+		return errors.New("not connected to the gateway through a proxy")
+	}
+	for url, expected := range map[string]bool{
+		"http://" + f.tlsProxy.listener.Addr().String() + "/api/version":       true,
+		"http://" + f.tlsProxy.listener.Addr().String() + "/wrong/api/version": true,
+		f.tlsProxy.url() + "/api/version":                                      false,
+	} {
+		resp, err := http.Get(url)
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		if rejected := resp.StatusCode == http.StatusForbidden; rejected != expected {
+			return fmt.Errorf("expected the request to %s to be rejected %v but the proxy answered %s", url, expected, resp.Status)
+		}
+	}
+	return nil
+}
+
+func (f *feature) theClientCertificateFilesAreReplacedWith(replacement string) error {
+	var certificate, key []byte
+	switch replacement {
+	case "an untrusted certificate":
+		var err error
+		if certificate, err = os.ReadFile(f.tlsDir + "/other-client.crt"); err != nil {
+			return err
+		}
+		if key, err = os.ReadFile(f.tlsDir + "/other-client.key"); err != nil {
+			return err
+		}
+	case "garbage":
+		certificate, key = []byte("not a certificate"), []byte("not a key")
+	}
+	if err := os.WriteFile(f.tlsDir+"/client.crt", certificate, 0o600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(f.tlsDir+"/client.key", key, 0o600); err != nil {
+		return err
+	}
+	// wait for the proxy to see the change
+	time.Sleep(500 * time.Millisecond)
+	return nil
+}
+
+func (f *feature) iLogInToTheGatewayAgain() error {
+	if f.tlsClient == nil {
+		return errors.New("not connected to the gateway")
+	}
+	_, f.err = f.service.loginAdminClient(context.Background(), f.tlsClient, f.tlsArray)
+	return nil
+}
+
+func (f *feature) iLoadAnArrayConfigWithTLSSettings(settings string) error {
+	config, err := json.Marshal([]*ArrayConnectionData{f.gatewayTLSArray(settings)})
+	if err != nil {
+		return err
+	}
+	arrayConfigFile := ArrayConfigFile
+	defer func() { ArrayConfigFile = arrayConfigFile }()
+	ArrayConfigFile = f.tlsDir + "/config"
+	if err := os.WriteFile(ArrayConfigFile, config, 0o600); err != nil {
+		return err
+	}
+	_, f.err = getArrayConfig(context.Background())
+	return nil
+}
+
+func (f *feature) credentialFilesWithPassword(password string) error {
+	if f.vaultServer != nil {
+		f.vaultServer.Close()
+	}
+	dir, err := os.MkdirTemp("", "credentials")
+	if err != nil {
+		return err
+	}
+	f.credentialsDir = dir
+	if err := os.WriteFile(dir+"/username", []byte("admin\n"), 0o600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(dir+"/password", []byte(password+"\n"), 0o600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(dir+"/token", []byte("test-token\n"), 0o600); err != nil {
+		return err
+	}
+
+	// stand-in of a Vault server serving the password file from a KV v2 and a KV v1 engine
+	f.vaultServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(vaultTokenHeader) != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		password, err := os.ReadFile(dir + "/password")
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		secret := map[string]string{"username": "admin", "password": strings.TrimSpace(string(password))}
+		switch r.URL.Path {
+		case "/v1/secret/data/powerflex":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"data": secret}})
+		case "/v1/kv/powerflex":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": secret})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return nil
+}
+
+// setCredentialSource makes array read its credentials from source
+func (f *feature) setCredentialSource(array *ArrayConnectionData, source string) {
+	vault := &VaultCredentials{Address: f.vaultServer.URL, Path: "secret/data/powerflex", TokenFile: f.credentialsDir + "/token"}
+	switch source {
+	case "files":
+		array.Username, array.UsernameFile, array.Password, array.PasswordFile = "", f.credentialsDir+"/username", "", f.credentialsDir+"/password"
+	case "password file":
+		array.Password, array.PasswordFile = "", f.credentialsDir+"/password"
+	case "missing file":
+		array.Password, array.PasswordFile = "", f.credentialsDir+"/missing"
+	case "password and passwordFile":
+		array.PasswordFile = f.credentialsDir + "/password"
+	case "vault":
+		array.Username, array.Password, array.Vault = "", "", vault
+	case "vault kv1":
+		vault.Path = "kv/powerflex"
+		array.Username, array.Password, array.Vault = "", "", vault
+	case "vault and username":
+		array.Password, array.Vault = "", vault
+	case "vault with bad token":
+		vault.TokenFile = f.credentialsDir + "/username"
+		array.Username, array.Password, array.Vault = "", "", vault
+	case "vault with missing key":
+		vault.UsernameKey = "user"
+		array.Username, array.Password, array.Vault = "", "", vault
+	case "vault without path":
+		vault.Path = ""
+		array.Username, array.Password, array.Vault = "", "", vault
+	}
+}
+
+func (f *feature) iLoadAnArrayConfigWithCredentialsFrom(source string) error {
+	array := &ArrayConnectionData{SystemID: arrayID, Username: "admin", Password: "Password123", Endpoint: f.server.URL, Insecure: true}
+	f.setCredentialSource(array, source)
+	config, err := json.Marshal([]*ArrayConnectionData{array})
+	if err != nil {
+		return err
+	}
+	arrayConfigFile := ArrayConfigFile
+	defer func() { ArrayConfigFile = arrayConfigFile }()
+	ArrayConfigFile = f.credentialsDir + "/config"
+	if err := os.WriteFile(ArrayConfigFile, config, 0o600); err != nil {
+		return err
+	}
+	f.loadedArrays, f.err = getArrayConfig(context.Background())
+	return nil
+}
+
+func (f *feature) theLoadedArrayHasUsernameAndPassword(username, password string) error {
+	if username == "none" {
+		if f.loadedArrays != nil {
+			return fmt.Errorf("expected no array config to load")
+		}
+		return nil
+	}
+	array := f.loadedArrays[arrayID]
+	if array == nil {
+		return fmt.Errorf("array %s not loaded", arrayID)
+	}
+	if array.Username != username || array.Password != password {
+		return fmt.Errorf("expected credentials %s/%s but got %s/%s", username, password, array.Username, array.Password)
+	}
+	return nil
+}
+
+func (f *feature) theArrayReadsItsCredentialsFrom(systemID, source string) error {
+	array := f.service.opts.arrays[systemID]
+	if array == nil {
+		return fmt.Errorf("array %s not configured", systemID)
+	}
+	f.setCredentialSource(array, source)
+	return resolveArrayCredentials(context.Background(), array)
+}
+
+func (f *feature) thePasswordFileIsRotatedTo(password string) error {
+	return os.WriteFile(f.credentialsDir+"/password", []byte(password+"\n"), 0o600)
+}
+
+func (f *feature) theGatewayRequiresThePassword(password string) error {
+	if password == "none" {
+		password = ""
+	}
+	requiredGatewayPassword = password
+	return nil
+}
+
+func (f *feature) theGatewaySessionExpires() error {
+	gatewaySessionExpired = true
+	return nil
+}
+
+func (f *feature) iRefreshTheCredentials() error {
+	f.arraysBeforeRefresh = make(map[string]*ArrayConnectionData)
+	for key, array := range f.service.opts.arrays {
+		f.arraysBeforeRefresh[key] = array
+	}
+	f.clientsBeforeRefresh = make(map[string]*goscaleio.Client)
+	for key, c := range f.service.adminClients {
+		f.clientsBeforeRefresh[key] = c
+	}
+	f.service.refreshCredentials(context.Background())
+	return nil
+}
+
+// theArrayReadBeforeTheRefreshHasPassword checks the connection data of an array read before the credentials
+// were refreshed is left as it was, the refresh replaces it instead
+func (f *feature) theArrayReadBeforeTheRefreshHasPassword(systemID, password string) error {
+	array := f.arraysBeforeRefresh[systemID]
+	if array == nil {
+		return fmt.Errorf("array %s not configured", systemID)
+	}
+	if array.Password != password {
+		return fmt.Errorf("expected password %s for array %s read before the refresh but got %s", password, systemID, array.Password)
+	}
+	for key, current := range f.service.opts.arrays {
+		if current.SystemID == array.SystemID && current != f.service.opts.arrays[systemID] {
+			return fmt.Errorf("array %s is not replaced under %s", systemID, key)
+		}
+	}
+	return nil
+}
+
+func (f *feature) iLogInToArrayAgain(systemID string) error {
+	c, array := f.service.adminClients[systemID], f.service.opts.arrays[systemID]
+	if c == nil || array == nil {
+		return fmt.Errorf("array %s not connected", systemID)
+	}
+	_, f.err = f.service.loginAdminClient(context.Background(), c, array)
+	return nil
+}
+
+func (f *feature) theArrayPasswordIs(systemID, password string) error {
+	if actual := f.service.opts.arrays[systemID].Password; actual != password {
+		return fmt.Errorf("expected password %s for array %s but got %s", password, systemID, actual)
+	}
+	return nil
+}
+
+// theClientOfArrayBeforeTheRefreshIsLoggedInWithPassword checks the client of an array the requests used before
+// the credentials were refreshed is left as it was, a login with rotated credentials replaces it instead
+func (f *feature) theClientOfArrayBeforeTheRefreshIsLoggedInWithPassword(systemID, password string) error {
+	c := f.clientsBeforeRefresh[systemID]
+	if c == nil {
+		return fmt.Errorf("array %s not connected", systemID)
+	}
+	if actual := c.GetConfigConnect().Password; actual != password {
+		return fmt.Errorf("expected the client of array %s before the refresh to log in with password %s but it uses %s", systemID, password, actual)
+	}
+	for key, current := range f.service.adminClients {
+		if current == c && f.service.adminClients[systemID] != c {
+			return fmt.Errorf("client of array %s is not replaced under %s", systemID, key)
+		}
+	}
+	return nil
+}
+
+func (f *feature) theClientOfArrayIsLoggedInWithPassword(systemID, password string) error {
+	c := f.service.adminClients[systemID]
+	if c == nil {
+		return fmt.Errorf("array %s not connected", systemID)
+	}
+	if c.GetToken() == "" {
+		return fmt.Errorf("client of array %s is not logged in", systemID)
+	}
+	if actual := c.GetConfigConnect().Password; actual != password {
+		return fmt.Errorf("expected the client of array %s to log in with password %s but it uses %s", systemID, password, actual)
+	}
+	return nil
+}
+
+// gatewayStub serves the mock gateway while it is up, and fails the requests the way a gateway that is down
+// (connection closed), unavailable (503) or failing the operation (500) does
+type gatewayStub struct {
+	server *httptest.Server
+	state  atomic.Value
+}
+
+func (f *feature) theArrayHasGateways(systemID, names string) error {
+	array := f.service.opts.arrays[systemID]
+	if array == nil {
+		return fmt.Errorf("array %s not configured", systemID)
+	}
+	f.gateways = make(map[string]*gatewayStub)
+	upstream := getHandler()
+	array.Endpoint, array.Endpoints = "", nil
+	for _, name := range strings.Split(names, ",") {
+		stub := &gatewayStub{}
+		stub.state.Store("up")
+		stub.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch stub.state.Load() {
+			case "down":
+				panic(http.ErrAbortHandler)
+			case "unavailable":
+				w.WriteHeader(http.StatusServiceUnavailable)
+			case "failing":
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"message":"internal error","httpStatusCode":500,"errorCode":0}`))
+			default:
+				upstream.ServeHTTP(w, r)
+			}
+		}))
+		f.gateways[name] = stub
+		array.Endpoints = append(array.Endpoints, stub.server.URL)
+	}
+	array.Endpoint = array.Endpoints[0]
+	delete(f.service.adminClients, systemID)
+	delete(f.service.systems, systemID)
+	return nil
+}
+
+func (f *feature) theGatewayIs(name, state string) error {
+	stub := f.gateways[name]
+	if stub == nil {
+		return fmt.Errorf("no gateway %s", name)
+	}
+	stub.state.Store(state)
+	if state == "stopped" {
+		// connections are refused
+		stub.server.Close()
+	}
+	return nil
+}
+
+func (f *feature) iSendAPOSTRequestToTheGatewayOfArray(systemID string) error {
+	proxy := f.service.gatewayProxies[systemID]
+	if proxy == nil {
+		return fmt.Errorf("array %s has no gateway proxy", systemID)
+	}
+	resp, err := http.Post(proxy.url()+"/api/version", "application/json", strings.NewReader("{}"))
+	if err != nil {
+		f.err = err
+		return nil
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		f.err = fmt.Errorf("gateway answered %s", resp.Status)
+	}
+	return nil
+}
+
+func (f *feature) iCallTheGatewayOfArray(systemID string) error {
+	c := f.service.adminClients[systemID]
+	if c == nil {
+		return fmt.Errorf("array %s not connected", systemID)
+	}
+	_, f.err = c.GetVersion()
+	return nil
+}
+
+func (f *feature) iCheckTheHealthOfTheGatewaysOfArray(systemID string) error {
+	proxy := f.service.gatewayProxies[systemID]
+	if proxy == nil {
+		return fmt.Errorf("array %s has no gateway proxy", systemID)
+	}
+	proxy.returnToPreferredGateway()
+	return nil
+}
+
+func (f *feature) theArrayUsesGateway(systemID, name string) error {
+	proxy := f.service.gatewayProxies[systemID]
+	if proxy == nil {
+		return fmt.Errorf("array %s has no gateway proxy", systemID)
+	}
+	if endpoint := proxy.endpoint(); endpoint != f.gateways[name].server.URL {
+		return fmt.Errorf("expected array %s to use gateway %s at %s but it uses %s", systemID, name, f.gateways[name].server.URL, endpoint)
+	}
+	return nil
+}
+
+func (f *feature) iLoadAnArrayConfigWithEndpoints(endpoints string) error {
+	config, err := json.Marshal([]*ArrayConnectionData{{
+		SystemID: arrayID, Username: "admin", Password: "Password123", Endpoints: strings.Split(endpoints, ","), Insecure: true,
+	}})
+	if err != nil {
+		return err
+	}
+	dir, err := os.MkdirTemp("", "array-config")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	arrayConfigFile := ArrayConfigFile
+	defer func() { ArrayConfigFile = arrayConfigFile }()
+	ArrayConfigFile = dir + "/config"
+	if err := os.WriteFile(ArrayConfigFile, config, 0o600); err != nil {
+		return err
+	}
+	f.loadedArrays, f.err = getArrayConfig(context.Background())
+	return nil
+}
+
+func (f *feature) theLoadedArrayHasEndpoint(endpoint string) error {
+	if endpoint == "none" {
+		return nil
+	}
+	if array := f.loadedArrays[arrayID]; array == nil || array.Endpoint != endpoint {
+		return fmt.Errorf("expected the loaded array to have endpoint %s", endpoint)
+	}
+	return nil
+}
+
 // GetPluginInfo
 func (f *feature) iCallGetPluginInfo() error {
 	ctx := new(context.Context)
@@ -470,14 +1215,14 @@ func (f *feature) iCallGetPluginInfo() error {
 }
 
 func (f *feature) iCallcheckVolumesMap(id string) error {
-	f.err = f.service.checkVolumesMap(id)
+	f.err = f.service.checkVolumesMap(context.Background(), id)
 
 	return nil
 }
 
 func (f *feature) iCallgetProtectionDomainIDFromName(systemID, protectionDomainName string) error {
 	id := ""
-	id, f.err = f.service.getProtectionDomainIDFromName(systemID, protectionDomainName)
+	id, f.err = f.service.getProtectionDomainIDFromName(context.Background(), systemID, protectionDomainName)
 	fmt.Printf("Protection Domain ID is: %s\n", id)
 	return nil
 }
@@ -2031,7 +2776,7 @@ func (f *feature) iCallGetCapacityWithStoragePool(arg1 string) error {
 
 func (f *feature) iCallGetMaximumVolumeSize(arg1 string) {
 	systemid := arg1
-	f.maxVolSize, f.err = f.service.getMaximumVolumeSize(systemid)
+	f.maxVolSize, f.err = f.service.getMaximumVolumeSize(context.Background(), systemid)
 	if f.err != nil {
 		log.Printf("err while getting max vol size: %s\n", f.err.Error())
 	}
@@ -2630,14 +3375,35 @@ func (f *feature) getNodeEphemeralVolumePublishRequest(name, size, sp, systemNam
 	return nil
 }
 
-func (f *feature) getNodePublishVolumeRequest() error {
-	req := new(csi.NodePublishVolumeRequest)
-	req.VolumeId = sdcVolume1
-	req.Readonly = false
-	req.VolumeCapability = f.capability
-	block := f.capability.GetBlock()
-	if block != nil {
-		req.TargetPath = datafile
+func (f *feature) iSetTheEphemeralVolumeFsTypeAndNasName(fsType, nasName string) error {
+	f.nodePublishVolumeRequest.VolumeContext[KeyFsType] = fsType
+	f.nodePublishVolumeRequest.VolumeContext[KeyNasName] = nasName
+	return nil
+}
+
+func (f *feature) iDeleteTheEphemeralVolumeOnTheArray() error {
+	volID, err := os.ReadFile(ephemeralStagingMountPath + f.nodePublishVolumeRequest.VolumeId + "/id")
+	if err != nil {
+		return err
+	}
+	// remove the filesystem from the mock array, as if it was deleted while the node was down
+	fsID := getFilesystemIDFromCsiVolumeID(string(volID))
+	for _, array := range systemArrays {
+		delete(array.fileSystems, fsID)
+	}
+	fileSystemNameToID[fileSystemIDName[fsID]] = ""
+	fileSystemIDName[fsID] = ""
+	return nil
+}
+
+func (f *feature) getNodePublishVolumeRequest() error {
+	req := new(csi.NodePublishVolumeRequest)
+	req.VolumeId = sdcVolume1
+	req.Readonly = false
+	req.VolumeCapability = f.capability
+	block := f.capability.GetBlock()
+	if block != nil {
+		req.TargetPath = datafile
 	}
 	mount := f.capability.GetMount()
 	if mount != nil {
@@ -3423,6 +4189,45 @@ func (f *feature) aValidControllerGetVolumeResponseIsReturned() error {
 	return nil
 }
 
+func (f *feature) iClearTheCreateVolumeRequest() error {
+	f.createVolumeRequest = nil
+	return nil
+}
+
+func (f *feature) theVolumeGroupSnapshotHasSnapshots(expected int) error {
+	if f.VolumeGroupSnapshot == nil {
+		return errors.New("no VolumeGroupSnapshot was returned")
+	}
+	if len(f.VolumeGroupSnapshot.Snapshots) != expected {
+		return fmt.Errorf("expected %d snapshots in VolumeGroupSnapshot but found %d", expected, len(f.VolumeGroupSnapshot.Snapshots))
+	}
+	return nil
+}
+
+func (f *feature) theFilesystemDoesNotExist(name string) error {
+	if fileSystemNameToID[name] != "" {
+		return fmt.Errorf("filesystem %s was not deleted", name)
+	}
+	return nil
+}
+
+func (f *feature) iCallCheckCreationTimeSkew(seconds string, maxSkew string) error {
+	skew, err := time.ParseDuration(maxSkew)
+	if err != nil {
+		return err
+	}
+	snapshots := make([]*volGroupSnap.Snapshot, 0)
+	for i, second := range strings.Split(seconds, ",") {
+		creationTime, err := strconv.ParseInt(second, 10, 64)
+		if err != nil {
+			return err
+		}
+		snapshots = append(snapshots, &volGroupSnap.Snapshot{Name: "snap-" + strconv.Itoa(i), CreationTime: creationTime * int64(time.Second)})
+	}
+	f.err = checkCreationTimeSkew(snapshots, skew)
+	return nil
+}
+
 func (f *feature) aValidCreateVolumeSnapshotGroupResponse() error {
 	// only check resp. if CreateVolumeGroupSnapshot returns okay
 	if f.VolumeGroupSnapshot == nil || f.err != nil {
@@ -3800,7 +4605,7 @@ func (f *feature) iInvalidateTheProbeCache() error {
 
 func (f *feature) iCallupdateVolumesMap(systemID string) error {
 	f.service.volumePrefixToSystems["123"] = []string{"123456789"}
-	f.err = f.service.UpdateVolumePrefixToSystemsMap(systemID)
+	f.err = f.service.UpdateVolumePrefixToSystemsMap(context.Background(), systemID)
 	return nil
 }
 
@@ -3928,7 +4733,7 @@ func (f *feature) iCallGetSystemIDFromParameters(option string) error {
 
 func (f *feature) iCallGetStoragePoolnameByID(id string) error {
 	f.service.storagePoolIDToName[id] = ""
-	res := f.service.getStoragePoolNameFromID(arrayID, id)
+	res := f.service.getStoragePoolNameFromID(context.Background(), arrayID, id)
 	if res == "" {
 		f.err = errors.New("cannot find storage pool")
 	}
@@ -4026,7 +4831,7 @@ func (f *feature) iCallGetArrayConfig() error {
 
 func (f *feature) iCallgetArrayInstallationID(systemID string) error {
 	id := ""
-	id, f.err = f.service.getArrayInstallationID(systemID)
+	id, f.err = f.service.getArrayInstallationID(context.Background(), systemID)
 	fmt.Printf("Installation ID is: %s\n", id)
 	return nil
 }
@@ -4103,7 +4908,7 @@ func (f *feature) aReplicationCapabilitiesStructureIsReturned(arg1 string) error
 			replicationActionExecution = true
 		}
 	}
-	var failoverRemote, unplannedFailoverLocal, reprotectLocal, suspend, resume, sync bool
+	var failoverRemote, unplannedFailoverLocal, reprotectLocal, suspend, resume, sync, testFailover, testFailoverStop bool
 	for _, act := range f.replicationCapabilitiesResponse.GetActions() {
 		if act == nil {
 			continue
@@ -4122,12 +4927,16 @@ func (f *feature) aReplicationCapabilitiesStructureIsReturned(arg1 string) error
 			resume = true
 		case replication.ActionTypes_SYNC:
 			sync = true
+		case replication.ActionTypes_TEST_FAILOVER:
+			testFailover = true
+		case replication.ActionTypes_TEST_FAILOVER_STOP:
+			testFailoverStop = true
 		}
 	}
 	if !createRemoteVolume || !createProtectionGroup || !deleteProtectionGroup || !replicationActionExecution || !monitorProtectionGroup {
 		return fmt.Errorf("Not all expected ReplicationCapability_RPC capabilities were returned")
 	}
-	if !failoverRemote || !unplannedFailoverLocal || !reprotectLocal || !suspend || !resume || !sync {
+	if !failoverRemote || !unplannedFailoverLocal || !reprotectLocal || !suspend || !resume || !sync || !testFailover || !testFailoverStop {
 		return fmt.Errorf("Not all expected ReplicationCapbility_RPC actions were returned")
 	}
 	return nil
@@ -4165,7 +4974,17 @@ func (f *feature) iCallCreateRemoteVolume() error {
 		f.service.WithRP(KeyReplicationRemoteStoragePool): "viki_pool_HDD_20181031",
 		f.service.WithRP(KeyReplicationRemoteSystem):      "15dbbf5617523655",
 	}
-	_, f.err = f.service.CreateRemoteVolume(*ctx, req)
+	if inducedError.Error() == "BadRemoteStoragePool" {
+		req.Parameters[f.service.WithRP(KeyReplicationRemoteStoragePool)] = "no-such-pool"
+	}
+	if inducedError.Error() == "BadRemoteProtectionDomain" {
+		req.Parameters[f.service.WithRP(KeyReplicationProtectionDomain)] = "no-such-pd"
+	}
+	if inducedError.Error() == "NoRemoteCredentials" {
+		delete(f.service.opts.arrays, arrayID2)
+		f.service.adminClients[arrayID2] = nil
+	}
+	f.createRemoteVolumeResponse, f.err = f.service.CreateRemoteVolume(*ctx, req)
 	if f.err != nil {
 		fmt.Printf("CreateRemoteVolumeRequest returned error: %s", f.err)
 	}
@@ -4200,7 +5019,6 @@ func (f *feature) iCallDeleteLocalVolume(name string) error {
 }
 
 func (f *feature) iCallCreateStorageProtectionGroup() error {
-	ctx := new(context.Context)
 	parameters := make(map[string]string)
 
 	// Must be repeatable.
@@ -4241,12 +5059,11 @@ func (f *feature) iCallCreateStorageProtectionGroup() error {
 	if stepHandlersErrors.BadVolIDError {
 		req.VolumeHandle = "0%0"
 	}
-	f.createStorageProtectionGroupResponse, f.err = f.service.CreateStorageProtectionGroup(*ctx, req)
+	f.createStorageProtectionGroupResponse, f.err = f.service.CreateStorageProtectionGroup(context.Background(), req)
 	return nil
 }
 
 func (f *feature) iCallCreateStorageProtectionGroupWith(arg1, arg2, arg3 string) error {
-	ctx := new(context.Context)
 	parameters := make(map[string]string)
 
 	// Must be repeatable.
@@ -4263,7 +5080,181 @@ func (f *feature) iCallCreateStorageProtectionGroupWith(arg1, arg2, arg3 string)
 		Parameters:   parameters,
 	}
 
-	f.createStorageProtectionGroupResponse, f.err = f.service.CreateStorageProtectionGroup(*ctx, req)
+	f.previousProtectionGroupResponse = f.createStorageProtectionGroupResponse
+	f.createStorageProtectionGroupResponse, f.err = f.service.CreateStorageProtectionGroup(context.Background(), req)
+	return nil
+}
+
+func (f *feature) theSameStorageProtectionGroupIsReturned() error {
+	if f.previousProtectionGroupResponse == nil || f.createStorageProtectionGroupResponse == nil {
+		return errors.New("expected two CreateStorageProtectionGroup responses")
+	}
+	previous := f.previousProtectionGroupResponse.LocalProtectionGroupId
+	current := f.createStorageProtectionGroupResponse.LocalProtectionGroupId
+	if previous != current {
+		return fmt.Errorf("expected protection group %s but got %s", previous, current)
+	}
+	return nil
+}
+
+func (f *feature) theNumberOfReplicationConsistencyGroupsOnSystemIs(systemID string, count int) error {
+	groups := getSystemArray(systemID).replicationConsistencyGroups
+	if len(groups) != count {
+		return fmt.Errorf("expected %d replication consistency groups on system %s but found %d", count, systemID, len(groups))
+	}
+	return nil
+}
+
+// theReplicationPairsOnSystemAreDeleted empties the replication consistency groups of a mock system, as when
+// their pairs were removed outside of the driver
+func (f *feature) theReplicationPairsOnSystemAreDeleted(systemID string) error {
+	getSystemArray(systemID).replicationPairs = make(map[string]map[string]string)
+	return nil
+}
+
+func (f *feature) iEnableReplicationAutoReprotectWithGracePeriod(gracePeriod string) error {
+	var err error
+	f.service.opts.AutoReprotectGracePeriod, err = time.ParseDuration(gracePeriod)
+	if err != nil {
+		return err
+	}
+	f.service.opts.PodName = "vxflexos-controller-0"
+	f.service.opts.PodNamespace = "vxflexos"
+	f.autoReprotectStart = time.Now()
+	if K8sClientset == nil {
+		K8sClientset = fake.NewSimpleClientset()
+	}
+	return deleteEvents("vxflexos")
+}
+
+// deleteEvents deletes the events of namespace one by one, the fake clientset does not implement DeleteCollection
+func deleteEvents(namespace string) error {
+	ctx := context.Background()
+	events, err := K8sClientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, event := range events.Items {
+		if err := K8sClientset.CoreV1().Events(namespace).Delete(ctx, event.Name, metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *feature) iCallAutoReprotectReplicationGroupsAfter(offset string) error {
+	elapsed, err := time.ParseDuration(offset)
+	if err != nil {
+		return err
+	}
+	f.autoReprotectDecisions = f.service.autoReprotectReplicationGroups(context.Background(), f.autoReprotectStart.Add(elapsed))
+	return nil
+}
+
+func (f *feature) theAutoReprotectDecisionIs(expected string) error {
+	decision := "none"
+	for _, d := range f.autoReprotectDecisions {
+		if d.GroupID == f.createStorageProtectionGroupResponse.LocalProtectionGroupId {
+			decision = d.Decision
+		}
+	}
+	if decision != expected {
+		return fmt.Errorf("expected auto reprotect decision %s but got %s", expected, decision)
+	}
+	if len(f.autoReprotectDecisions) > 1 {
+		return fmt.Errorf("expected one auto reprotect decision but got %d", len(f.autoReprotectDecisions))
+	}
+	return nil
+}
+
+func (f *feature) theReplicationGroupActionsAre(expected string) error {
+	actions := strings.Join(replicationGroupActions, ",")
+	if expected == "none" {
+		expected = ""
+	}
+	if actions != expected {
+		return fmt.Errorf("expected replication group actions %q but got %q", expected, actions)
+	}
+	return nil
+}
+
+func (f *feature) anEventWithReasonIsEmitted(reason string) error {
+	events, err := K8sClientset.CoreV1().Events("vxflexos").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	if reason == "none" {
+		if len(events.Items) != 0 {
+			return fmt.Errorf("expected no events but got %d, first reason %s", len(events.Items), events.Items[0].Reason)
+		}
+		return nil
+	}
+	for _, event := range events.Items {
+		if event.Reason == reason && event.InvolvedObject.Name == f.service.opts.PodName {
+			return nil
+		}
+	}
+	return fmt.Errorf("expected an event with reason %s", reason)
+}
+
+func (f *feature) iCallRemoveVolumeFromProtectionGroup(deleteRemote string) error {
+	volumeID := f.createVolumeResponse.GetVolume().VolumeId
+	f.nonReplicatedVolume, f.err = f.service.RemoveVolumeFromProtectionGroup(context.Background(), &replicationext.RemoveVolumeFromProtectionGroupRequest{
+		VolumeHandle:       volumeID,
+		RemoteSystemId:     arrayID2,
+		DeleteRemoteVolume: deleteRemote == "true",
+		RemoteVolumeHandle: f.createRemoteVolumeResponse.GetRemoteVolume().GetVolumeId(),
+		ProtectionGroupId:  f.createStorageProtectionGroupResponse.GetLocalProtectionGroupId(),
+	})
+	return nil
+}
+
+func (f *feature) iClearTheInducedErrors() error {
+	inducedError = errors.New("none")
+	reflect.ValueOf(&stepHandlersErrors).Elem().SetZero()
+	return nil
+}
+
+func (f *feature) theVolumeIsNotReplicated() error {
+	if f.nonReplicatedVolume == nil {
+		return errors.New("no volume returned")
+	}
+	volumeID := getVolumeIDFromCsiVolumeID(f.nonReplicatedVolume.GetVolume().GetVolumeId())
+	vol, err := f.service.getVolByID(context.Background(), volumeID, arrayID)
+	if err != nil {
+		return err
+	}
+	if vol.VolumeReplicationState != unmarkedForReplication {
+		return fmt.Errorf("expected volume %s to be %s but it was %s", volumeID, unmarkedForReplication, vol.VolumeReplicationState)
+	}
+	return nil
+}
+
+// getSystemArray returns the mock array of a system
+func getSystemArray(systemID string) *systemArray {
+	for _, array := range systemArrays {
+		if array.ID == systemID {
+			return array
+		}
+	}
+	return nil
+}
+
+func (f *feature) theRemoteVolumeExists(exists string) error {
+	volumeID := getVolumeIDFromCsiVolumeID(f.createRemoteVolumeResponse.RemoteVolume.VolumeId)
+	_, ok := getSystemArray(arrayID2).volumes[volumeID]
+	if ok != (exists == "true") {
+		return fmt.Errorf("expected remote volume %s exists to be %s", volumeID, exists)
+	}
+	return nil
+}
+
+func (f *feature) theStorageProtectionGroupExists(exists string) error {
+	groupID := f.createStorageProtectionGroupResponse.LocalProtectionGroupId
+	_, ok := getSystemArray(arrayID).replicationConsistencyGroups[groupID]
+	if ok != (exists == "true") {
+		return fmt.Errorf("expected storage protection group %s exists to be %s", groupID, exists)
+	}
 	return nil
 }
 
@@ -4332,7 +5323,30 @@ func (f *feature) iCallDeleteStorageProtectionGroup() error {
 }
 
 func (f *feature) iCallExecuteAction(arg1 string) error {
-	ctx := new(context.Context)
+	f.executeActionResponse, f.err = f.service.ExecuteAction(context.Background(), f.executeActionRequest(arg1))
+	return nil
+}
+
+func (f *feature) iCallExecuteActionWithATracedRequest(arg1 string) error {
+	f.spanRecorder = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(f.spanRecorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTracerProvider(tracenoop.NewTracerProvider())
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", tracedParent))
+	info := &grpc.UnaryServerInfo{FullMethod: "/replication.v1.Replication/ExecuteAction"}
+	resp, err := TracingInterceptor(ctx, f.executeActionRequest(arg1), info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return f.service.ExecuteAction(ctx, req.(*replication.ExecuteActionRequest))
+	})
+	f.err = err
+	if resp != nil {
+		f.executeActionResponse = resp.(*replication.ExecuteActionResponse)
+	}
+	return nil
+}
+
+// executeActionRequest returns the ExecuteAction request of action arg1 on the protection group of the scenario
+func (f *feature) executeActionRequest(arg1 string) *replication.ExecuteActionRequest {
 	attributes := make(map[string]string)
 	remoteAttributes := make(map[string]string)
 
@@ -4355,6 +5369,10 @@ func (f *feature) iCallExecuteAction(arg1 string) error {
 		act.ActionTypes = replication.ActionTypes_SUSPEND
 	case "Sync":
 		act.ActionTypes = replication.ActionTypes_SYNC
+	case "TestFailover":
+		act.ActionTypes = replication.ActionTypes_TEST_FAILOVER
+	case "TestFailoverStop":
+		act.ActionTypes = replication.ActionTypes_TEST_FAILOVER_STOP
 	default:
 		act.ActionTypes = replication.ActionTypes_UNKNOWN_ACTION
 	}
@@ -4363,106 +5381,1029 @@ func (f *feature) iCallExecuteAction(arg1 string) error {
 
 	attributes[f.service.opts.replicationContextPrefix+"systemName"] = arrayID
 	remoteAttributes[f.service.opts.replicationContextPrefix+"systemName"] = arrayID2
-	req := &replication.ExecuteActionRequest{
+	return &replication.ExecuteActionRequest{
 		ProtectionGroupId:               f.createStorageProtectionGroupResponse.LocalProtectionGroupId,
 		ProtectionGroupAttributes:       attributes,
 		RemoteProtectionGroupId:         f.createStorageProtectionGroupResponse.RemoteProtectionGroupId,
 		RemoteProtectionGroupAttributes: remoteAttributes,
 		ActionTypes:                     &action,
 	}
+}
 
-	_, f.err = f.service.ExecuteAction(*ctx, req)
-	return nil
+func (f *feature) aPersistentVolumeForTheReplicatedVolumeWithClaim(namespace, claim string) error {
+	for _, ns := range []string{"default", namespace} {
+		if err := deleteEvents(ns); err != nil {
+			return err
+		}
+	}
+	return createPersistentVolume("pv-replicated", f.createVolumeResponse.GetVolume().GetVolumeId(), namespace, claim)
 }
 
-func (f *feature) iCallEnableFSQuota() error {
-	f.service.opts.IsQuotaEnabled = true
-	isQuotaEnabled = true
+func (f *feature) aPersistentVolumeForVolumeWithClaim(pvName, volumeID, namespace, claim string) error {
+	return createPersistentVolume(pvName, arrayID+"-"+volumeID, namespace, claim)
+}
+
+// createPersistentVolume creates a PersistentVolume of the driver bound to claim
+func createPersistentVolume(pvName, volumeHandle, namespace, claim string) error {
+	ctx := context.Background()
+	_ = K8sClientset.CoreV1().PersistentVolumes().Delete(ctx, pvName, metav1.DeleteOptions{})
+	pv := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: pvName},
+		Spec: v1.PersistentVolumeSpec{
+			PersistentVolumeSource: v1.PersistentVolumeSource{
+				CSI: &v1.CSIPersistentVolumeSource{
+					Driver:       Name,
+					VolumeHandle: volumeHandle,
+				},
+			},
+			ClaimRef: &v1.ObjectReference{Kind: "PersistentVolumeClaim", Namespace: namespace, Name: claim},
+		},
+	}
+	_, err := K8sClientset.CoreV1().PersistentVolumes().Create(ctx, pv, metav1.CreateOptions{})
+	return err
+}
+
+func (f *feature) eventsWithReasonAreEmittedOn(count int, reason, kind, name string) error {
+	events, err := K8sClientset.CoreV1().Events("").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	found := 0
+	for _, event := range events.Items {
+		if event.Reason == reason && event.InvolvedObject.Kind == kind && event.InvolvedObject.Name == name {
+			found++
+		}
+	}
+	if found != count {
+		return fmt.Errorf("expected %d events with reason %s on %s %s but found %d", count, reason, kind, name, found)
+	}
 	return nil
 }
 
-func (f *feature) iCallDisableFSQuota() error {
-	f.service.opts.IsQuotaEnabled = false
-	isQuotaEnabled = false
+func (f *feature) theReplicationTransitionMetricIs(from, to, value string) error {
+	group, err := f.service.getReplicationConsistencyGroupByID(context.Background(), arrayID, f.createStorageProtectionGroupResponse.LocalProtectionGroupId)
+	if err != nil {
+		return err
+	}
+	labels := metricLabels{"system_id": arrayID, "rcg_id": group.ID, "rcg_name": group.Name, "from": from, "to": to}
+	metric, _ := driverMetrics.getValue(metricReplicationStateTransitions, labels)
+	if strconv.FormatFloat(metric, 'f', -1, 64) != value {
+		return fmt.Errorf("expected transitions from %s to %s to be %s but it was %v", from, to, value, metric)
+	}
 	return nil
 }
 
-func (f *feature) iCallSetQuotaParams(path, softlimit, graceperiod string) error {
-	if f.createVolumeRequest == nil {
-		req := getTypicalNFSCreateVolumeRequest()
-		f.createVolumeRequest = req
+func (f *feature) theGatewayCallsOfAre(operation, calls, errors string) error {
+	labels := metricLabels{"system_id": arrayID, "operation": operation}
+	requests, _ := driverMetrics.getValue(metricGatewayRequests, labels)
+	failed, _ := driverMetrics.getValue(metricGatewayErrors, labels)
+	if strconv.FormatFloat(requests, 'f', -1, 64) != calls || strconv.FormatFloat(failed, 'f', -1, 64) != errors {
+		return fmt.Errorf("expected %s calls and %s errors of %s but got %v and %v", calls, errors, operation, requests, failed)
+	}
+	if latencies, _ := driverMetrics.getValue(metricGatewayDuration, labels); latencies != requests {
+		return fmt.Errorf("expected %v latencies of %s but got %v", requests, operation, latencies)
 	}
-	f.createVolumeRequest.Parameters["path"] = path
-	f.createVolumeRequest.Parameters["softLimit"] = softlimit
-	f.createVolumeRequest.Parameters["gracePeriod"] = graceperiod
 	return nil
 }
 
-func (f *feature) iSpecifyNoPath() error {
-	if f.createVolumeRequest == nil {
-		req := getTypicalNFSCreateVolumeRequest()
-		f.createVolumeRequest = req
+func (f *feature) theArrayProbeSuccessIs(value string) error {
+	metric, ok := driverMetrics.getValue(metricArrayProbeSuccess, metricLabels{"system_id": arrayID})
+	if !ok || strconv.FormatFloat(metric, 'f', -1, 64) != value {
+		return fmt.Errorf("expected array probe success %s but it was %v", value, metric)
 	}
-	delete(f.createVolumeRequest.Parameters, "path")
 	return nil
 }
 
-func (f *feature) iSpecifyNoSoftLimit() error {
-	if f.createVolumeRequest == nil {
-		req := getTypicalNFSCreateVolumeRequest()
-		f.createVolumeRequest = req
+func (f *feature) theCacheLookupsAre(cache, result, value string) error {
+	metric, _ := driverMetrics.getValue(metricCacheRequests, metricLabels{"cache": cache, "result": result})
+	if strconv.FormatFloat(metric, 'f', -1, 64) != value {
+		return fmt.Errorf("expected %s %s cache lookups but it was %v", value, result, metric)
 	}
-	delete(f.createVolumeRequest.Parameters, "softLimit")
 	return nil
 }
 
-func (f *feature) iSpecifyNoGracePeriod() error {
-	if f.createVolumeRequest == nil {
-		req := getTypicalNFSCreateVolumeRequest()
-		f.createVolumeRequest = req
+func (f *feature) iCallTheRPCMetricsInterceptorWithCode(code string) error {
+	var c codes.Code
+	if err := c.UnmarshalJSON([]byte(`"` + code + `"`)); err != nil {
+		return err
 	}
-	delete(f.createVolumeRequest.Parameters, "gracePeriod")
+	info := &grpc.UnaryServerInfo{FullMethod: "/csi.v1.Controller/CreateVolume"}
+	handler := func(_ context.Context, _ interface{}) (interface{}, error) {
+		if c == codes.OK {
+			return &csi.CreateVolumeResponse{}, nil
+		}
+		return nil, status.Error(c, "induced error")
+	}
+	_, f.err = RPCMetricsInterceptor(context.Background(), &csi.CreateVolumeRequest{}, info, handler)
 	return nil
 }
 
-func (f *feature) iCallParseCIDR(ip string) error {
-	_, err := ParseCIDR(ip)
-	f.err = err
+func (f *feature) theRPCRequestsWithCodeAre(code, value string) error {
+	labels := metricLabels{"method": "/csi.v1.Controller/CreateVolume", "code": code}
+	metric, _ := driverMetrics.getValue(metricRPCRequests, labels)
+	if strconv.FormatFloat(metric, 'f', -1, 64) != value {
+		return fmt.Errorf("expected %s RPCs with code %s but it was %v", value, code, metric)
+	}
 	return nil
 }
 
-func (f *feature) iCallGetIPListWithMaskFromString(ip string) error {
-	_, err := GetIPListWithMaskFromString(ip)
-	f.err = err
+// scrapeMetrics starts the metrics listener on port and returns the metrics it serves
+func (f *feature) scrapeMetrics(port string) ([]byte, error) {
+	v := viper.New()
+	v.Set(ParamMetricsEnabled, "true")
+	v.Set(ParamMetricsControllerPort, port)
+	f.service.configureMetricsListener(v)
+	defer f.service.metrics.listen("")
+
+	resp, err := http.Get("http://127.0.0.1:" + port + metricsPath)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("expected status 200 but got %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (f *feature) theMetricsEndpointOnPortContains(port, text string) error {
+	body, err := f.scrapeMetrics(port)
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(string(body), text) {
+		return fmt.Errorf("expected metrics to contain %s but got:\n%s", text, body)
+	}
 	return nil
 }
 
-func (f *feature) iCallparseMask(ip string) error {
-	_, err := parseMask(ip)
-	f.err = err
+// escapedLabelValue is a label value that must be escaped in the text exposition format
+const escapedLabelValue = "pvc \"data\" in C:\\share\nline two"
+
+func (f *feature) iRecordMetricsWithSpecialCharactersInTheirLabels() error {
+	driverMetrics.setGauge("powerflex_test_labels", "Test metric", metricLabels{"volume": escapedLabelValue}, 1)
+	driverMetrics.setGauge("powerflex_test_labels", "Test metric", metricLabels{"volume": "invalid \xff utf-8"}, 2)
+	driverMetrics.setGauge("powerflex test invalid", "Test metric", metricLabels{"volume": "v"}, 3)
 	return nil
 }
 
-func (f *feature) iCallGivenNFSExport(nfsexporthost string) error {
-	f.nfsExport = types.NFSExport{
-		ReadWriteHosts: []string{nfsexporthost},
+func (f *feature) theMetricsEndpointOnPortIsValidWithTheEscapedLabelValues(port string) error {
+	body, err := f.scrapeMetrics(port)
+	if err != nil {
+		return err
+	}
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("metrics are not in the text exposition format: %s", err.Error())
+	}
+	if _, ok := families["powerflex test invalid"]; ok {
+		return errors.New("expected the metric with an invalid name to be left out")
+	}
+	family, ok := families["powerflex_test_labels"]
+	if !ok || len(family.GetMetric()) != 1 {
+		return fmt.Errorf("expected one valid series of powerflex_test_labels but got %v", family)
+	}
+	if got := family.GetMetric()[0].GetLabel()[0].GetValue(); got != escapedLabelValue {
+		return fmt.Errorf("expected label value %q but got %q", escapedLabelValue, got)
 	}
 	return nil
 }
 
-func (f *feature) iCallexternalAccessAlreadyAdded(externalAccess string) error {
-	export := f.nfsExport
-	resp := externalAccessAlreadyAdded(&export, externalAccess)
-	if resp {
-		f.err = errors.New("external access exists")
-	} else {
-		f.err = errors.New("external access does not exist")
+func (f *feature) iCallCollectVolumeMetrics() error {
+	f.service.collectVolumeMetrics(context.Background())
+	return nil
+}
+
+// findMetricSeries returns the value of the first series of metric name whose labels contain label
+func findMetricSeries(name, label string) (float64, bool) {
+	driverMetrics.mu.RLock()
+	defer driverMetrics.mu.RUnlock()
+	family, ok := driverMetrics.families[name]
+	if !ok {
+		return 0, false
+	}
+	for labels, value := range family.series {
+		if strings.Contains(labels, label) {
+			return value, true
+		}
+	}
+	return 0, false
+}
+
+func (f *feature) theMetricOfIs(name, label, value string) error {
+	metric, ok := findMetricSeries(name, label)
+	if value == "none" {
+		if ok {
+			return fmt.Errorf("expected no %s series with %s but found %v", name, label, metric)
+		}
+		return nil
+	}
+	if !ok || strconv.FormatFloat(metric, 'f', -1, 64) != value {
+		return fmt.Errorf("expected %s with %s to be %s but it was %v (found %v)", name, label, value, metric, ok)
 	}
 	return nil
 }
 
-func (f *feature) iSpecifyExternalAccess(externalAccess string) error {
-	f.service.opts.ExternalAccess, _ = ParseCIDR(externalAccess)
+// tracedParent is the trace context of the caller of the traced requests
+const tracedParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func (f *feature) iCallCreateVolumeWithATracedRequest(name string) error {
+	f.spanRecorder = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(f.spanRecorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTracerProvider(tracenoop.NewTracerProvider())
+
+	req := getTypicalCreateVolumeRequest()
+	req.Name = name
+	f.createVolumeRequest = req
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", tracedParent, csiRequestIDKey, "csi-42"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/csi.v1.Controller/CreateVolume"}
+	resp, err := TracingInterceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return f.service.CreateVolume(ctx, req.(*csi.CreateVolumeRequest))
+	})
+	f.err = err
+	if resp != nil {
+		f.createVolumeResponse = resp.(*csi.CreateVolumeResponse)
+	}
+	return nil
+}
+
+func (f *feature) iCallNFSPublishVolumeWithATracedRequest(arg1 string) error {
+	f.spanRecorder = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(f.spanRecorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTracerProvider(tracenoop.NewTracerProvider())
+
+	req := f.getControllerPublishVolumeRequestNFS(arg1)
+	f.publishVolumeRequest = req
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", tracedParent, csiRequestIDKey, "csi-42"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/csi.v1.Controller/ControllerPublishVolume"}
+	resp, err := TracingInterceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return f.service.ControllerPublishVolume(ctx, req.(*csi.ControllerPublishVolumeRequest))
+	})
+	f.err = err
+	if resp != nil {
+		f.publishVolumeResponse = resp.(*csi.ControllerPublishVolumeResponse)
+	}
+	return nil
+}
+
+func (f *feature) theTraceContainsSpans(names string) error {
+	spans := f.spanRecorder.Ended()
+	var root sdktrace.ReadOnlySpan
+	for _, span := range spans {
+		if span.Parent().IsRemote() {
+			root = span
+		}
+	}
+	if root == nil {
+		return errors.New("no span for the RPC")
+	}
+	if root.SpanContext().TraceID().String() != strings.Split(tracedParent, "-")[1] || root.Parent().SpanID().String() != strings.Split(tracedParent, "-")[2] {
+		return fmt.Errorf("RPC span does not continue the trace of the caller: %v", root.Parent())
+	}
+	requestID := false
+	for _, attr := range root.Attributes() {
+		if string(attr.Key) == csiRequestIDKey && attr.Value.AsString() == "csi-42" {
+			requestID = true
+		}
+	}
+	if !requestID {
+		return fmt.Errorf("RPC span has no request ID: %v", root.Attributes())
+	}
+
+	for _, name := range strings.Split(names, ",") {
+		found := false
+		for _, span := range spans {
+			if span.Name() == strings.TrimSpace(name) && span.Parent().SpanID() == root.SpanContext().SpanID() {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("no span %s in the RPC span", name)
+		}
+	}
+	return nil
+}
+
+func (f *feature) theCreateVolumeGatewayRequestCarriesTheTraceContext() error {
+	if !strings.HasPrefix(createVolumeTraceparent, "00-"+strings.Split(tracedParent, "-")[1]+"-") {
+		return fmt.Errorf("expected the trace context of the request in the create volume request but got %q", createVolumeTraceparent)
+	}
+	return nil
+}
+
+func (f *feature) theReplicationGroupActionGatewayRequestCarriesTheTraceContext() error {
+	if !strings.HasPrefix(gatewayActionTraceparent, "00-"+strings.Split(tracedParent, "-")[1]+"-") {
+		return fmt.Errorf("expected the trace context of the request in the group action request but got %q", gatewayActionTraceparent)
+	}
+	return nil
+}
+
+func (f *feature) iEnableTheAuditLog() error {
+	dir, err := os.MkdirTemp("", "audit")
+	if err != nil {
+		return err
+	}
+	f.auditLogPath = dir + "/audit.log"
+	f.service.auditLog = newAuditLog(f.auditLogPath, 1, 1)
+	return nil
+}
+
+// callThroughInterceptors calls handler through the request ID and audit interceptors, like gocsi does
+func (f *feature) callThroughInterceptors(method string, req interface{}, handler grpc.UnaryHandler) (interface{}, error) {
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	return RequestIDInterceptor(context.Background(), req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return f.service.AuditInterceptor(ctx, req, info, handler)
+	})
+}
+
+func (f *feature) iCallCreateVolumeThroughTheInterceptors(name string) error {
+	req := getTypicalCreateVolumeRequest()
+	req.Name = name
+	req.Parameters[CSIPersistentVolumeClaimName] = "data"
+	req.Parameters[CSIPersistentVolumeClaimNamespace] = "apps"
+	f.createVolumeRequest = req
+	resp, err := f.callThroughInterceptors("/csi.v1.Controller/CreateVolume", req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return f.service.CreateVolume(ctx, req.(*csi.CreateVolumeRequest))
+	})
+	f.err = err
+	if resp != nil {
+		f.createVolumeResponse = resp.(*csi.CreateVolumeResponse)
+	}
+	return nil
+}
+
+func (f *feature) iCallControllerExpandVolumeThroughTheInterceptors(size int64) error {
+	req := &csi.ControllerExpandVolumeRequest{
+		VolumeId:      f.createVolumeResponse.GetVolume().VolumeId,
+		CapacityRange: &csi.CapacityRange{RequiredBytes: size * bytesInKiB * bytesInKiB * bytesInKiB},
+	}
+	_, f.err = f.callThroughInterceptors("/csi.v1.Controller/ControllerExpandVolume", req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return f.service.ControllerExpandVolume(ctx, req.(*csi.ControllerExpandVolumeRequest))
+	})
+	return nil
+}
+
+func (f *feature) iCallPublishVolumeWithQoSLimitsThroughTheInterceptors(bandwidthLimit, iopsLimit string) error {
+	req := f.getControllerPublishVolumeRequest("single-writer")
+	req.VolumeContext = map[string]string{KeyBandwidthLimitInKbps: bandwidthLimit, KeyIopsLimit: iopsLimit}
+	f.publishVolumeRequest = req
+	resp, err := f.callThroughInterceptors("/csi.v1.Controller/ControllerPublishVolume", req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return f.service.ControllerPublishVolume(ctx, req.(*csi.ControllerPublishVolumeRequest))
+	})
+	f.err = err
+	if resp != nil {
+		f.publishVolumeResponse = resp.(*csi.ControllerPublishVolumeResponse)
+	}
+	return nil
+}
+
+func (f *feature) iCallExecuteActionThroughTheInterceptors(arg1 string) error {
+	resp, err := f.callThroughInterceptors("/replication.v1.Replication/ExecuteAction", f.executeActionRequest(arg1), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return f.service.ExecuteAction(ctx, req.(*replication.ExecuteActionRequest))
+	})
+	f.err = err
+	if resp != nil {
+		f.executeActionResponse = resp.(*replication.ExecuteActionResponse)
+	}
+	return nil
+}
+
+func (f *feature) iCallListVolumesThroughTheInterceptors() error {
+	_, f.err = f.callThroughInterceptors("/csi.v1.Controller/ListVolumes", &csi.ListVolumesRequest{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return f.service.ListVolumes(ctx, req.(*csi.ListVolumesRequest))
+	})
+	return nil
+}
+
+func (f *feature) theAuditLogHasRecords(count int) error {
+	data, err := os.ReadFile(f.auditLogPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(data) == 0 {
+		lines = nil
+	}
+	if len(lines) != count {
+		return fmt.Errorf("expected %d audit records but found %d: %s", count, len(lines), data)
+	}
+	return nil
+}
+
+// theAuditRecordsAre checks the method and outcome of all audit records, given as "method:outcome" separated by
+// commas, or "none"
+func (f *feature) theAuditRecordsAre(expected string) error {
+	data, err := os.ReadFile(f.auditLogPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	records := make([]string, 0)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		record := auditRecord{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			return err
+		}
+		if record.ArrayID == "" || len(record.Objects) == 0 {
+			return fmt.Errorf("audit record misses array ID or objects: %+v", record)
+		}
+		records = append(records, record.Method+":"+record.Outcome)
+	}
+	if got := strings.Join(records, ","); got != expected && !(expected == "none" && got == "") {
+		return fmt.Errorf("expected audit records %s but got %s", expected, got)
+	}
+	return nil
+}
+
+func (f *feature) theLastAuditRecordIs(method, outcome, code string) error {
+	data, err := os.ReadFile(f.auditLogPath)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	record := auditRecord{}
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &record); err != nil {
+		return err
+	}
+	if record.Method != method || record.Outcome != outcome || record.Code != code {
+		return fmt.Errorf("expected %s %s %s but got %+v", method, outcome, code, record)
+	}
+	if record.RequestID == "" || record.ArrayID != arrayID || record.Objects["name"] != f.createVolumeRequest.Name {
+		return fmt.Errorf("audit record misses request ID, array ID or objects: %+v", record)
+	}
+	if record.Requester[CSIPersistentVolumeClaimName] != "data" || record.Requester[CSIPersistentVolumeClaimNamespace] != "apps" {
+		return fmt.Errorf("audit record misses requester: %+v", record)
+	}
+	if (outcome == auditOutcomeSuccess) != (record.Objects["volumeId"] != "") {
+		return fmt.Errorf("expected a volume ID only on success: %+v", record)
+	}
+	if (outcome == auditOutcomeFailure) != (record.Error != "") {
+		return fmt.Errorf("expected an error only on failure: %+v", record)
+	}
+	return nil
+}
+
+// theLastAuditRecordHasObjects checks the objects of the last audit record include all of objects, given as
+// "key=value" separated by commas
+func (f *feature) theLastAuditRecordHasObjects(objects string) error {
+	data, err := os.ReadFile(f.auditLogPath)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	record := auditRecord{}
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &record); err != nil {
+		return err
+	}
+	for _, object := range strings.Split(objects, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(object), "=")
+		if record.Objects[key] != value {
+			return fmt.Errorf("expected audit object %s to be %s but got %+v", key, value, record)
+		}
+	}
+	return nil
+}
+
+func (f *feature) theLogRedactionKeysAre(keys string) error {
+	f.logRedactKeys = keys
+	return nil
+}
+
+// redactingLogger returns a logger configured like Log from the driver config params, writing to f.logOutput
+func (f *feature) redactingLogger() (*logrus.Logger, error) {
+	v := viper.New()
+	v.Set(ParamLogRedactKeys, f.logRedactKeys)
+	logger := logrus.New()
+	if err := f.service.updateDriverConfigParams(logger, v); err != nil {
+		return nil, err
+	}
+	f.logOutput = new(bytes.Buffer)
+	logger.SetOutput(f.logOutput)
+	return logger, nil
+}
+
+func (f *feature) iLog(message string) error {
+	logger, err := f.redactingLogger()
+	if err != nil {
+		return err
+	}
+	logger.Info(message)
+	return nil
+}
+
+func (f *feature) iLogWithField(message, key, value string) error {
+	logger, err := f.redactingLogger()
+	if err != nil {
+		return err
+	}
+	logger.WithField(key, value).Info(message)
+	return nil
+}
+
+func (f *feature) iLogACreateVolumeRequestWithSecretAndParameter(secret, key, value string) error {
+	logger, err := f.redactingLogger()
+	if err != nil {
+		return err
+	}
+	req := getTypicalCreateVolumeRequest()
+	req.Secrets = map[string]string{"username": secret}
+	req.Parameters[key] = value
+	logger.Printf("[CreateVolume] req %+v", redactRequest(req))
+	if req.Secrets["username"] != secret {
+		return fmt.Errorf("the logged request was not copied, secret is now %s", req.Secrets["username"])
+	}
+	return nil
+}
+
+func (f *feature) theLogContains(text string) error {
+	if !strings.Contains(f.logOutput.String(), text) {
+		return fmt.Errorf("expected the log to contain %q but it was: %s", text, f.logOutput.String())
+	}
+	return nil
+}
+
+func (f *feature) theLogDoesNotContain(text string) error {
+	if strings.Contains(f.logOutput.String(), text) {
+		return fmt.Errorf("expected the log not to contain %q but it was: %s", text, f.logOutput.String())
+	}
+	return nil
+}
+
+func (f *feature) aSecondStorageProtectionGroup() error {
+	groups := getSystemArray(arrayID).replicationConsistencyGroups
+	group := make(map[string]string)
+	for key, value := range groups[f.createStorageProtectionGroupResponse.LocalProtectionGroupId] {
+		group[key] = value
+	}
+	group["id"] = secondRCGID
+	group["name"] = "second-rcg"
+	groups[secondRCGID] = group
+	return nil
+}
+
+func (f *feature) iCallExecuteMultiGroupAction(arg1 string) error {
+	_, f.err = f.service.ExecuteMultiGroupAction(context.Background(), f.multiGroupActionRequest(arg1))
+	return nil
+}
+
+// multiGroupActionRequest returns the ExecuteMultiGroupAction request of action arg1 on the two protection groups
+// of the scenario
+func (f *feature) multiGroupActionRequest(arg1 string) *replicationext.ExecuteMultiGroupActionRequest {
+	action := replication.ActionTypes_UNKNOWN_ACTION
+	switch arg1 {
+	case "FailoverRemote":
+		action = replication.ActionTypes_FAILOVER_REMOTE
+	case "UnplannedFailover":
+		action = replication.ActionTypes_UNPLANNED_FAILOVER_LOCAL
+	case "Sync":
+		action = replication.ActionTypes_SYNC
+	}
+
+	attributes := map[string]string{f.service.opts.replicationContextPrefix + "systemName": arrayID}
+	return &replicationext.ExecuteMultiGroupActionRequest{
+		ActionType: action,
+		ProtectionGroups: []*replicationext.ProtectionGroup{
+			{ProtectionGroupId: f.createStorageProtectionGroupResponse.LocalProtectionGroupId, ProtectionGroupAttributes: attributes},
+			{ProtectionGroupId: secondRCGID, ProtectionGroupAttributes: attributes},
+		},
+	}
+}
+
+func (f *feature) iCallExecuteMultiGroupActionThroughTheInterceptors(arg1 string) error {
+	_, f.err = f.callThroughInterceptors(replicationext.ReplicationExtension_ExecuteMultiGroupAction_FullMethodName, f.multiGroupActionRequest(arg1), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return f.service.ExecuteMultiGroupAction(ctx, req.(*replicationext.ExecuteMultiGroupActionRequest))
+	})
+	return nil
+}
+
+func (f *feature) iCallCreateVolumesFromProtectionGroupSnapshot(namePrefix string) error {
+	snapshots := make(map[string]string)
+	if f.executeActionResponse != nil {
+		for key, value := range f.executeActionResponse.ActionAttributes {
+			// skip the protection group status attributes
+			if !strings.HasPrefix(key, f.service.opts.replicationPrefix+"/") {
+				snapshots[key] = value
+			}
+		}
+	}
+	if inducedError.Error() == "InvalidSnapshotID" {
+		snapshots["vol"] = "invalid"
+	}
+	req := &replicationext.CreateVolumesFromProtectionGroupSnapshotRequest{
+		Snapshots:  snapshots,
+		NamePrefix: namePrefix,
+		Parameters: map[string]string{},
+	}
+	resp, err := f.service.CreateVolumesFromProtectionGroupSnapshot(context.Background(), req)
+	f.restoredVolumes, f.err = resp.GetVolumes(), err
+	return nil
+}
+
+func (f *feature) volumesAreRestoredOnSystem(count int, systemID string) error {
+	if len(f.restoredVolumes) != count {
+		return fmt.Errorf("expected %d restored volumes but got %d", count, len(f.restoredVolumes))
+	}
+	for _, volume := range f.restoredVolumes {
+		if f.service.getSystemIDFromCsiVolumeID(volume.GetVolumeId()) != systemID {
+			return fmt.Errorf("expected volume %s on system %s", volume.VolumeId, systemID)
+		}
+	}
+	return nil
+}
+
+func (f *feature) iCallDescribeStorageProtectionGroup() error {
+	attributes := map[string]string{f.service.opts.replicationContextPrefix + "systemName": arrayID}
+	req := &replication.GetStorageProtectionGroupStatusRequest{
+		ProtectionGroupId:         f.createStorageProtectionGroupResponse.LocalProtectionGroupId,
+		ProtectionGroupAttributes: attributes,
+	}
+	f.describeProtectionGroupResponse, f.err = f.service.DescribeStorageProtectionGroup(context.Background(), req)
+	return nil
+}
+
+func (f *feature) theProtectionGroupIsWithTestFailover(state, testFailover string) error {
+	if f.describeProtectionGroupResponse == nil {
+		return errors.New("no DescribeStorageProtectionGroupResponse returned")
+	}
+	if got := f.describeProtectionGroupResponse.GetStatus().GetState().String(); got != state {
+		return fmt.Errorf("expected protection group state %s but got %s", state, got)
+	}
+	if got := strconv.FormatBool(f.describeProtectionGroupResponse.GetTestFailover()); got != testFailover {
+		return fmt.Errorf("expected protection group test failover %s but got %s", testFailover, got)
+	}
+	return nil
+}
+
+func (f *feature) iCallModifyStorageProtectionGroupWithRPO(rpo int) error {
+	req := &replicationext.ModifyStorageProtectionGroupRequest{
+		ProtectionGroupId:         f.createStorageProtectionGroupResponse.LocalProtectionGroupId,
+		ProtectionGroupAttributes: f.createStorageProtectionGroupResponse.LocalProtectionGroupAttributes,
+		RpoSeconds:                int32(rpo),
+	}
+	_, f.err = f.service.ModifyStorageProtectionGroup(context.Background(), req)
+	return nil
+}
+
+func (f *feature) iCallAddVolumeToProtectionGroup() error {
+	req := &replicationext.AddVolumeToProtectionGroupRequest{
+		VolumeHandle: f.createVolumeResponse.GetVolume().GetVolumeId(),
+		Parameters: map[string]string{
+			f.service.WithRP(KeyReplicationRemoteStoragePool): "viki_pool_HDD_20181031",
+			f.service.WithRP(KeyReplicationRemoteSystem):      arrayID2,
+			f.service.WithRP(KeyReplicationRPO):               "60",
+			f.service.WithRP(KeyReplicationClusterID):         "cluster-k212",
+			"clusterUID": f.clusterUID,
+		},
+	}
+	if stepHandlersErrors.NoVolIDError {
+		req.VolumeHandle = ""
+	}
+	if inducedError.Error() == "BadRemoteStoragePool" {
+		req.Parameters[f.service.WithRP(KeyReplicationRemoteStoragePool)] = "no-such-pool"
+	}
+	f.addVolumeToProtectionGroupResponse, f.err = f.service.AddVolumeToProtectionGroup(context.Background(), req)
+	if f.err == nil {
+		f.createStorageProtectionGroupResponse = &replication.CreateStorageProtectionGroupResponse{
+			LocalProtectionGroupId:          f.addVolumeToProtectionGroupResponse.GetLocalProtectionGroupId(),
+			LocalProtectionGroupAttributes:  f.addVolumeToProtectionGroupResponse.GetLocalProtectionGroupAttributes(),
+			RemoteProtectionGroupId:         f.addVolumeToProtectionGroupResponse.GetRemoteProtectionGroupId(),
+			RemoteProtectionGroupAttributes: f.addVolumeToProtectionGroupResponse.GetRemoteProtectionGroupAttributes(),
+		}
+	}
+	return nil
+}
+
+func (f *feature) volumeOnSystemExists(name, systemID, exists string) error {
+	_, err := f.service.adminClients[systemID].FindVolumeID(name)
+	if got := strconv.FormatBool(err == nil); got != exists {
+		return fmt.Errorf("expected volume %s on system %s to exist %s but got %s", name, systemID, exists, got)
+	}
+	return nil
+}
+
+func (f *feature) theAddedVolumeIsReplicatedTo(remoteVolume string) error {
+	if f.addVolumeToProtectionGroupResponse == nil {
+		return errors.New("no AddVolumeToProtectionGroupResponse returned")
+	}
+	if got := f.addVolumeToProtectionGroupResponse.GetRemoteVolume().GetVolumeContext()["remoteVolumeID"]; got != remoteVolume {
+		return fmt.Errorf("expected remote volume %s but got %s", remoteVolume, got)
+	}
+	if f.addVolumeToProtectionGroupResponse.GetLocalProtectionGroupId() == "" || f.addVolumeToProtectionGroupResponse.GetRemoteProtectionGroupId() == "" {
+		return fmt.Errorf("expected protection group IDs in %v", f.addVolumeToProtectionGroupResponse)
+	}
+	return nil
+}
+
+func (f *feature) theDescribedStatusAttributeIs(key, value string) error {
+	if f.describeProtectionGroupResponse == nil {
+		return errors.New("no DescribeStorageProtectionGroupResponse returned")
+	}
+	attribute, ok := f.describeProtectionGroupResponse.GetAttributes()[f.service.WithRP(key)]
+	if !ok {
+		return fmt.Errorf("status attribute %s not found in %v", key, f.describeProtectionGroupResponse.GetAttributes())
+	}
+	if attribute != value {
+		return fmt.Errorf("expected status attribute %s to be %s but it was %s", key, value, attribute)
+	}
+	return nil
+}
+
+func (f *feature) theReplicationPairsAreInInitialCopy() error {
+	replicationPairInitialCopyState = "InProgress"
+	return nil
+}
+
+func (f *feature) theExecuteActionStatusAttributeIs(key, value string) error {
+	if f.executeActionResponse == nil {
+		return errors.New("no ExecuteActionResponse returned")
+	}
+	attribute, ok := f.executeActionResponse.ActionAttributes[f.service.WithRP(key)]
+	if !ok {
+		return fmt.Errorf("status attribute %s not found in %v", key, f.executeActionResponse.ActionAttributes)
+	}
+	if attribute != value {
+		return fmt.Errorf("expected status attribute %s to be %s but it was %s", key, value, attribute)
+	}
+	return nil
+}
+
+func (f *feature) theReplicationMetricIs(name, value string) error {
+	group, err := f.service.getReplicationConsistencyGroupByID(context.Background(), arrayID, f.createStorageProtectionGroupResponse.LocalProtectionGroupId)
+	if err != nil {
+		return err
+	}
+	labels := metricLabels{"system_id": arrayID, "rcg_id": group.ID, "rcg_name": group.Name}
+	metric, ok := driverMetrics.getValue(name, labels)
+	if !ok {
+		return fmt.Errorf("metric %s%v not found", name, labels)
+	}
+	if strconv.FormatFloat(metric, 'f', -1, 64) != value {
+		return fmt.Errorf("expected metric %s to be %s but it was %v", name, value, metric)
+	}
+	return nil
+}
+
+func (f *feature) theReplicationMetricIsNotPublished(name string) error {
+	group, err := f.service.getReplicationConsistencyGroupByID(context.Background(), arrayID, f.createStorageProtectionGroupResponse.LocalProtectionGroupId)
+	if err != nil {
+		return err
+	}
+	labels := metricLabels{"system_id": arrayID, "rcg_id": group.ID, "rcg_name": group.Name}
+	if metric, ok := driverMetrics.getValue(name, labels); ok {
+		return fmt.Errorf("expected metric %s%v not to be published but it is %v", name, labels, metric)
+	}
+	return nil
+}
+
+func (f *feature) iCallGetReplicationGroupStatusWithModeLastSyncAndRPO(mode string, lastSync, rpo int) error {
+	now := time.Now()
+	group := &types.ReplicationConsistencyGroup{
+		ID:              "rcg-status",
+		RpoInSeconds:    rpo,
+		CurrConsistMode: mode,
+	}
+	if lastSync >= 0 {
+		f.service.rcgLastSeenConsistent.Store(arrayID+"/"+group.ID, now.Add(-time.Duration(lastSync)*time.Second))
+	}
+	f.replicationGroupStatus = f.service.getReplicationGroupStatus(arrayID, group, nil, now)
+	return nil
+}
+
+func (f *feature) theReplicationGroupStatusAttributeIs(key, value string) error {
+	attribute := f.replicationGroupStatus.attributes()[key]
+	if attribute != value {
+		return fmt.Errorf("expected status attribute %s to be %s but it was %s", key, value, attribute)
+	}
+	return nil
+}
+
+func (f *feature) iCallEnableFSQuota() error {
+	f.service.opts.IsQuotaEnabled = true
+	isQuotaEnabled = true
+	return nil
+}
+
+func (f *feature) iCallDisableFSQuota() error {
+	f.service.opts.IsQuotaEnabled = false
+	isQuotaEnabled = false
+	return nil
+}
+
+func (f *feature) iCallSetQuotaParams(path, softlimit, graceperiod string) error {
+	if f.createVolumeRequest == nil {
+		req := getTypicalNFSCreateVolumeRequest()
+		f.createVolumeRequest = req
+	}
+	f.createVolumeRequest.Parameters["path"] = path
+	f.createVolumeRequest.Parameters["softLimit"] = softlimit
+	f.createVolumeRequest.Parameters["gracePeriod"] = graceperiod
+	return nil
+}
+
+func (f *feature) iEnableReplicationForTheNFSVolume() error {
+	if f.createVolumeRequest == nil {
+		req := getTypicalNFSCreateVolumeRequest()
+		f.createVolumeRequest = req
+	}
+	f.createVolumeRequest.Parameters[f.service.WithRP(KeyReplicationEnabled)] = "true"
+	return nil
+}
+
+func (f *feature) iSpecifyNoPath() error {
+	if f.createVolumeRequest == nil {
+		req := getTypicalNFSCreateVolumeRequest()
+		f.createVolumeRequest = req
+	}
+	delete(f.createVolumeRequest.Parameters, "path")
+	return nil
+}
+
+func (f *feature) iSpecifyNoSoftLimit() error {
+	if f.createVolumeRequest == nil {
+		req := getTypicalNFSCreateVolumeRequest()
+		f.createVolumeRequest = req
+	}
+	delete(f.createVolumeRequest.Parameters, "softLimit")
+	return nil
+}
+
+func (f *feature) iSpecifyNoGracePeriod() error {
+	if f.createVolumeRequest == nil {
+		req := getTypicalNFSCreateVolumeRequest()
+		f.createVolumeRequest = req
+	}
+	delete(f.createVolumeRequest.Parameters, "gracePeriod")
+	return nil
+}
+
+func (f *feature) iCallParseCIDR(ip string) error {
+	_, err := ParseCIDR(ip)
+	f.err = err
+	return nil
+}
+
+func (f *feature) iCallGetIPListWithMaskFromString(ip string) error {
+	_, err := GetIPListWithMaskFromString(ip)
+	f.err = err
+	return nil
+}
+
+func (f *feature) iCallparseMask(ip string) error {
+	_, err := parseMask(ip)
+	f.err = err
+	return nil
+}
+
+func (f *feature) iCallGivenNFSExport(nfsexporthost string) error {
+	f.nfsExport = types.NFSExport{
+		ReadWriteHosts: []string{nfsexporthost},
+	}
+	return nil
+}
+
+func (f *feature) iCallexternalAccessAlreadyAdded(externalAccess string) error {
+	export := f.nfsExport
+	resp := externalAccessAlreadyAdded(&export, externalAccess)
+	if resp {
+		f.err = errors.New("external access exists")
+	} else {
+		f.err = errors.New("external access does not exist")
+	}
+	return nil
+}
+
+func (f *feature) iSpecifyExternalAccess(externalAccess string) error {
+	f.service.opts.ExternalAccess, _ = ParseCIDR(externalAccess)
+	return nil
+}
+
+func (f *feature) iCallGetStaleNFSExportHosts(validHost, externalAccess string) error {
+	export := f.nfsExport
+	_, staleHosts := getStaleNFSExportHosts(&export, map[string]bool{validHost: true}, externalAccess)
+	if len(staleHosts) == 0 {
+		f.err = errors.New("no stale hosts")
+	} else {
+		f.err = fmt.Errorf("stale hosts: %s", strings.Join(staleHosts, ","))
+	}
+	return nil
+}
+
+func (f *feature) iSetTheNFSDefaultMountOptions(options string) error {
+	f.service.opts.NFSMountOptions = splitNFSMountOptions(options)
+	return nil
+}
+
+func (f *feature) iSetTheNFSAllowedMountOptions(options string) error {
+	f.service.opts.NFSAllowedMountOptions = splitNFSMountOptions(options)
+	return nil
+}
+
+func (f *feature) iSetTheMountFlags(flags string) error {
+	if mount := f.capability.GetMount(); mount != nil {
+		mount.MountFlags = splitNFSMountOptions(flags)
+	}
+	return nil
+}
+
+func (f *feature) iCallMergeNFSMountOptions(defaults, mountFlags string) error {
+	merged := mergeNFSMountOptions(splitNFSMountOptions(defaults), splitNFSMountOptions(mountFlags))
+	f.err = fmt.Errorf("mount options: %s", strings.Join(merged, ","))
+	return nil
+}
+
+func (f *feature) iCallCheckNASNFSVersion(version string, nfsv3, nfsv4 string) error {
+	nas := &types.NAS{
+		Name: "dummy-nas-server",
+		NfsServers: []types.NFSServerInstance{
+			{ID: "nfs-server-1", IsNFSv3Enabled: nfsv3 == "true", IsNFSv4Enabled: nfsv4 == "true"},
+		},
+	}
+	f.err = checkNASNFSVersion(nas, version)
+	return nil
+}
+
+func (f *feature) aVolumeAttachmentForTheVolumeOnNode(nodeName string) error {
+	ctx := context.Background()
+	pvName := "pv-nfs-reconcile"
+	pv := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: pvName},
+		Spec: v1.PersistentVolumeSpec{
+			PersistentVolumeSource: v1.PersistentVolumeSource{
+				CSI: &v1.CSIPersistentVolumeSource{
+					Driver:       Name,
+					VolumeHandle: f.createVolumeResponse.GetVolume().GetVolumeId(),
+				},
+			},
+		},
+	}
+	if _, err := K8sClientset.CoreV1().PersistentVolumes().Create(ctx, pv, metav1.CreateOptions{}); err != nil {
+		return err
+	}
+	va := &storage.VolumeAttachment{
+		ObjectMeta: metav1.ObjectMeta{Name: "va-nfs-reconcile"},
+		Spec: storage.VolumeAttachmentSpec{
+			Attacher: Name,
+			NodeName: nodeName,
+			Source:   storage.VolumeAttachmentSource{PersistentVolumeName: &pvName},
+		},
+	}
+	_, err := K8sClientset.StorageV1().VolumeAttachments().Create(ctx, va, metav1.CreateOptions{})
+	return err
+}
+
+func (f *feature) iDeleteTheVolumeAttachment() error {
+	ctx := context.Background()
+	if err := K8sClientset.StorageV1().VolumeAttachments().Delete(ctx, "va-nfs-reconcile", metav1.DeleteOptions{}); err != nil {
+		return err
+	}
+	return K8sClientset.CoreV1().PersistentVolumes().Delete(ctx, "pv-nfs-reconcile", metav1.DeleteOptions{})
+}
+
+func (f *feature) aPodUsingTheEphemeralVolumeOnNode(nodeName string) error {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-nfs-ephemeral", Namespace: "default"},
+		Spec: v1.PodSpec{
+			NodeName: nodeName,
+			Volumes: []v1.Volume{
+				{
+					Name: "inline",
+					VolumeSource: v1.VolumeSource{
+						CSI: &v1.CSIVolumeSource{
+							Driver:           Name,
+							VolumeAttributes: f.nodePublishVolumeRequest.VolumeContext,
+						},
+					},
+				},
+			},
+		},
+		Status: v1.PodStatus{Phase: v1.PodRunning},
+	}
+	_, err := K8sClientset.CoreV1().Pods(pod.Namespace).Create(context.Background(), pod, metav1.CreateOptions{})
+	return err
+}
+
+func (f *feature) iDeleteThePodUsingTheEphemeralVolume() error {
+	return K8sClientset.CoreV1().Pods("default").Delete(context.Background(), "pod-nfs-ephemeral", metav1.DeleteOptions{})
+}
+
+func (f *feature) iCallReconcileNFSExportsWithDryRun(dryRun string) error {
+	f.nfsExportReconcileResults, f.err = f.service.reconcileNFSExports(context.Background(), dryRun == "true")
+	return nil
+}
+
+func (f *feature) theNFSExportReconcileReportsStaleHosts(expected int) error {
+	staleHosts := 0
+	for _, result := range f.nfsExportReconcileResults {
+		staleHosts += len(result.StaleHosts)
+	}
+	if staleHosts != expected {
+		return fmt.Errorf("expected %d stale NFS export hosts but found %d", expected, staleHosts)
+	}
 	return nil
 }
 
@@ -4510,6 +6451,7 @@ func FeatureContext(s *godog.ScenarioContext) {
 	s.Step(`^I specify CreateVolumeMountRequest "([^"]*)"$`, f.iSpecifyCreateVolumeMountRequest)
 	s.Step(`^I call PublishVolume with "([^"]*)"$`, f.iCallPublishVolumeWith)
 	s.Step(`^I call NFS PublishVolume with "([^"]*)"$`, f.iCallPublishVolumeWithNFS)
+	s.Step(`^I call NFS PublishVolume with "([^"]*)" with a traced request$`, f.iCallNFSPublishVolumeWithATracedRequest)
 	s.Step(`^a valid PublishVolumeResponse is returned$`, f.aValidPublishVolumeResponseIsReturned)
 	s.Step(`^a valid volume$`, f.aValidVolume)
 	s.Step(`^an invalid volume$`, f.anInvalidVolume)
@@ -4574,6 +6516,8 @@ func FeatureContext(s *godog.ScenarioContext) {
 	s.Step(`^I call UnmountAndDeleteTarget$`, f.iCallUnmountAndDeleteTarget)
 	s.Step(`^get Node Publish Volume Request$`, f.getNodePublishVolumeRequest)
 	s.Step(`^get Node Publish Volume Request NFS$`, f.getNodePublishVolumeRequestNFS)
+	s.Step(`^I set the ephemeral volume fsType "([^"]*)" and nasName "([^"]*)"$`, f.iSetTheEphemeralVolumeFsTypeAndNasName)
+	s.Step(`^I delete the ephemeral volume on the array$`, f.iDeleteTheEphemeralVolumeOnTheArray)
 	s.Step(`^get Node Publish Ephemeral Volume Request with name "([^"]*)" size "([^"]*)" storagepool "([^"]*)" and systemName "([^"]*)"$`, f.getNodeEphemeralVolumePublishRequest)
 	s.Step(`^I mark request read only$`, f.iMarkRequestReadOnly)
 	s.Step(`^I call NodeUnpublishVolume "([^"]*)"$`, f.iCallNodeUnpublishVolume)
@@ -4662,14 +6606,114 @@ func FeatureContext(s *godog.ScenarioContext) {
 	s.Step(`^I call DeleteLocalVolume "([^"]*)"$`, f.iCallDeleteLocalVolume)
 	s.Step(`^I call CreateStorageProtectionGroup$`, f.iCallCreateStorageProtectionGroup)
 	s.Step(`^I call CreateStorageProtectionGroup with "([^"]*)", "([^"]*)", "([^"]*)"$`, f.iCallCreateStorageProtectionGroupWith)
+	s.Step(`^the same storage protection group is returned$`, f.theSameStorageProtectionGroupIsReturned)
+	s.Step(`^I call RemoveVolumeFromProtectionGroup with deleteRemote "([^"]*)"$`, f.iCallRemoveVolumeFromProtectionGroup)
+	s.Step(`^I clear the induced errors$`, f.iClearTheInducedErrors)
+	s.Step(`^the volume is not replicated$`, f.theVolumeIsNotReplicated)
+	s.Step(`^the number of replication consistency groups on system "([^"]*)" is (\d+)$`, f.theNumberOfReplicationConsistencyGroupsOnSystemIs)
+	s.Step(`^the replication pairs on system "([^"]*)" are deleted$`, f.theReplicationPairsOnSystemAreDeleted)
+	s.Step(`^I enable replication auto reprotect with grace period "([^"]*)"$`, f.iEnableReplicationAutoReprotectWithGracePeriod)
+	s.Step(`^I call autoReprotectReplicationGroups after "([^"]*)"$`, f.iCallAutoReprotectReplicationGroupsAfter)
+	s.Step(`^the auto reprotect decision is "([^"]*)"$`, f.theAutoReprotectDecisionIs)
+	s.Step(`^the replication group actions are "([^"]*)"$`, f.theReplicationGroupActionsAre)
+	s.Step(`^an event with reason "([^"]*)" is emitted$`, f.anEventWithReasonIsEmitted)
+	s.Step(`^a second storage protection group$`, f.aSecondStorageProtectionGroup)
+	s.Step(`^a PersistentVolume for the replicated volume with claim "([^"]*)/([^"]*)"$`, f.aPersistentVolumeForTheReplicatedVolumeWithClaim)
+	s.Step(`^(\d+) events with reason "([^"]*)" are emitted on (\w+) "([^"]*)"$`, f.eventsWithReasonAreEmittedOn)
+	s.Step(`^the replication transitions from "([^"]*)" to "([^"]*)" are "([^"]*)"$`, f.theReplicationTransitionMetricIs)
+	s.Step(`^the gateway calls of "([^"]*)" are "([^"]*)" with "([^"]*)" errors$`, f.theGatewayCallsOfAre)
+	s.Step(`^the array probe success is "([^"]*)"$`, f.theArrayProbeSuccessIs)
+	s.Step(`^the "([^"]*)" cache lookups with result "([^"]*)" are "([^"]*)"$`, f.theCacheLookupsAre)
+	s.Step(`^I call the RPC metrics interceptor with code "([^"]*)"$`, f.iCallTheRPCMetricsInterceptorWithCode)
+	s.Step(`^the RPC requests with code "([^"]*)" are "([^"]*)"$`, f.theRPCRequestsWithCodeAre)
+	s.Step(`^the metrics endpoint on port "([^"]*)" contains "([^"]*)"$`, f.theMetricsEndpointOnPortContains)
+	s.Step(`^I record metrics with special characters in their labels$`, f.iRecordMetricsWithSpecialCharactersInTheirLabels)
+	s.Step(`^the metrics endpoint on port "([^"]*)" is valid with the escaped label values$`, f.theMetricsEndpointOnPortIsValidWithTheEscapedLabelValues)
+	s.Step(`^a PersistentVolume "([^"]*)" for volume "([^"]*)" with claim "([^"]*)/([^"]*)"$`, f.aPersistentVolumeForVolumeWithClaim)
+	s.Step(`^I call collectVolumeMetrics$`, f.iCallCollectVolumeMetrics)
+	s.Step(`^I enable the audit log$`, f.iEnableTheAuditLog)
+	s.Step(`^a gateway that requires a TLS client certificate$`, f.aGatewayThatRequiresATLSClientCertificate)
+	s.Step(`^I connect to the gateway with TLS settings "([^"]*)"$`, f.iConnectToTheGatewayWithTLSSettings)
+	s.Step(`^the client certificate files are replaced with (an untrusted certificate|garbage)$`, f.theClientCertificateFilesAreReplacedWith)
+	s.Step(`^I log in to the gateway again$`, f.iLogInToTheGatewayAgain)
+	s.Step(`^I load an array config with TLS settings "([^"]*)"$`, f.iLoadAnArrayConfigWithTLSSettings)
+	s.Step(`^I reload the array config with "([^"]*)"$`, f.iReloadTheArrayConfigWith)
+	s.Step(`^credential files with password "([^"]*)"$`, f.credentialFilesWithPassword)
+	s.Step(`^I load an array config with credentials from "([^"]*)"$`, f.iLoadAnArrayConfigWithCredentialsFrom)
+	s.Step(`^the loaded array has username "([^"]*)" and password "([^"]*)"$`, f.theLoadedArrayHasUsernameAndPassword)
+	s.Step(`^the array "([^"]*)" reads its credentials from "([^"]*)"$`, f.theArrayReadsItsCredentialsFrom)
+	s.Step(`^the password file is rotated to "([^"]*)"$`, f.thePasswordFileIsRotatedTo)
+	s.Step(`^the gateway requires the password "([^"]*)"$`, f.theGatewayRequiresThePassword)
+	s.Step(`^I refresh the credentials$`, f.iRefreshTheCredentials)
+	s.Step(`^the gateway session expires$`, f.theGatewaySessionExpires)
+	s.Step(`^the client of array "([^"]*)" before the refresh is logged in with password "([^"]*)"$`, f.theClientOfArrayBeforeTheRefreshIsLoggedInWithPassword)
+	s.Step(`^the array "([^"]*)" read before the refresh has password "([^"]*)"$`, f.theArrayReadBeforeTheRefreshHasPassword)
+	s.Step(`^I log in to array "([^"]*)" again$`, f.iLogInToArrayAgain)
+	s.Step(`^the array "([^"]*)" password is "([^"]*)"$`, f.theArrayPasswordIs)
+	s.Step(`^the client of array "([^"]*)" is logged in with password "([^"]*)"$`, f.theClientOfArrayIsLoggedInWithPassword)
+	s.Step(`^the array "([^"]*)" has gateways "([^"]*)"$`, f.theArrayHasGateways)
+	s.Step(`^the gateway "([^"]*)" is (up|down|unavailable|failing|stopped)$`, f.theGatewayIs)
+	s.Step(`^the gateway proxy rejects the requests without its secret$`, f.theGatewayProxyRejectsTheRequestsWithoutItsSecret)
+	s.Step(`^I call the gateway of array "([^"]*)"$`, f.iCallTheGatewayOfArray)
+	s.Step(`^I send a POST request to the gateway of array "([^"]*)"$`, f.iSendAPOSTRequestToTheGatewayOfArray)
+	s.Step(`^I check the health of the gateways of array "([^"]*)"$`, f.iCheckTheHealthOfTheGatewaysOfArray)
+	s.Step(`^the array "([^"]*)" uses gateway "([^"]*)"$`, f.theArrayUsesGateway)
+	s.Step(`^I load an array config with endpoints "([^"]*)"$`, f.iLoadAnArrayConfigWithEndpoints)
+	s.Step(`^the loaded array has endpoint "([^"]*)"$`, f.theLoadedArrayHasEndpoint)
+	s.Step(`^the array "([^"]*)" (is reconnected|keeps its connection|is disconnected|is added)$`, f.theArrayIs)
+	s.Step(`^the array config has (\d+) arrays? with default "([^"]*)"$`, f.theArrayConfigHasArraysWithDefault)
+	s.Step(`^I reload the driver config params with "([^"]*)" set to "([^"]*)"$`, f.iReloadTheDriverConfigParamsWith)
+	s.Step(`^I reload the driver config params file with "([^"]*)" set to "([^"]*)" while the environment sets it to "([^"]*)"$`, f.iReloadTheDriverConfigParamsFileWithWhileTheEnvironmentSetsIt)
+	s.Step(`^the driver setting "([^"]*)" is "([^"]*)"$`, f.theDriverSettingIs)
+	s.Step(`^the log redaction keys are "([^"]*)"$`, f.theLogRedactionKeysAre)
+	s.Step(`^I log "([^"]*)"$`, f.iLog)
+	s.Step(`^I log "([^"]*)" with field "([^"]*)" of "([^"]*)"$`, f.iLogWithField)
+	s.Step(`^I log a CreateVolume request with secret "([^"]*)" and parameter "([^"]*)" of "([^"]*)"$`, f.iLogACreateVolumeRequestWithSecretAndParameter)
+	s.Step(`^the log contains "([^"]*)"$`, f.theLogContains)
+	s.Step(`^the log does not contain "([^"]*)"$`, f.theLogDoesNotContain)
+	s.Step(`^I call CreateVolume "([^"]*)" through the interceptors$`, f.iCallCreateVolumeThroughTheInterceptors)
+	s.Step(`^I call ListVolumes through the interceptors$`, f.iCallListVolumesThroughTheInterceptors)
+	s.Step(`^I call ControllerExpandVolume set to (\d+) through the interceptors$`, f.iCallControllerExpandVolumeThroughTheInterceptors)
+	s.Step(`^I call PublishVolume with QoS limits "([^"]*)" and "([^"]*)" through the interceptors$`, f.iCallPublishVolumeWithQoSLimitsThroughTheInterceptors)
+	s.Step(`^I call ExecuteAction "([^"]*)" through the interceptors$`, f.iCallExecuteActionThroughTheInterceptors)
+	s.Step(`^I call ExecuteMultiGroupAction "([^"]*)" through the interceptors$`, f.iCallExecuteMultiGroupActionThroughTheInterceptors)
+	s.Step(`^the last audit record has objects "([^"]*)"$`, f.theLastAuditRecordHasObjects)
+	s.Step(`^the audit log has (\d+) records?$`, f.theAuditLogHasRecords)
+	s.Step(`^the last audit record is "([^"]*)" with outcome "([^"]*)" and code "([^"]*)"$`, f.theLastAuditRecordIs)
+	s.Step(`^the audit records are "([^"]*)"$`, f.theAuditRecordsAre)
+	s.Step(`^I call CreateVolume "([^"]*)" with a traced request$`, f.iCallCreateVolumeWithATracedRequest)
+	s.Step(`^the trace contains spans "([^"]*)"$`, f.theTraceContainsSpans)
+	s.Step(`^the create volume gateway request carries the trace context$`, f.theCreateVolumeGatewayRequestCarriesTheTraceContext)
+	s.Step(`^the metric "([^"]*)" of ([a-z_]+="[^"]*") is "([^"]*)"$`, f.theMetricOfIs)
+	s.Step(`^I call ExecuteMultiGroupAction "([^"]*)"$`, f.iCallExecuteMultiGroupAction)
+	s.Step(`^the remote volume exists "([^"]*)"$`, f.theRemoteVolumeExists)
+	s.Step(`^the storage protection group exists "([^"]*)"$`, f.theStorageProtectionGroupExists)
 	s.Step(`^I call GetStorageProtectionGroupStatus$`, f.iCallGetStorageProtectionGroupStatus)
 	s.Step(`^I call GetStorageProtectionGroupStatus with state "([^"]*)" and mode "([^"]*)"$`, f.iCallGetStorageProtectionGroupStatusWithStateAndMode)
 	s.Step(`^I call DeleteVolume "([^"]*)"$`, f.iCallDeleteVolume)
 	s.Step(`^I call DeleteStorageProtectionGroup$`, f.iCallDeleteStorageProtectionGroup)
 	s.Step(`^I call ExecuteAction "([^"]*)"$`, f.iCallExecuteAction)
+	s.Step(`^I call ExecuteAction "([^"]*)" with a traced request$`, f.iCallExecuteActionWithATracedRequest)
+	s.Step(`^the replication group action gateway request carries the trace context$`, f.theReplicationGroupActionGatewayRequestCarriesTheTraceContext)
+	s.Step(`^the replication pairs are in initial copy$`, f.theReplicationPairsAreInInitialCopy)
+	s.Step(`^I call CreateVolumesFromProtectionGroupSnapshot with prefix "([^"]*)"$`, f.iCallCreateVolumesFromProtectionGroupSnapshot)
+	s.Step(`^(\d+) volumes are restored on system "([^"]*)"$`, f.volumesAreRestoredOnSystem)
+	s.Step(`^the ExecuteAction status attribute "([^"]*)" is "([^"]*)"$`, f.theExecuteActionStatusAttributeIs)
+	s.Step(`^I call DescribeStorageProtectionGroup$`, f.iCallDescribeStorageProtectionGroup)
+	s.Step(`^the protection group is "([^"]*)" with test failover "([^"]*)"$`, f.theProtectionGroupIsWithTestFailover)
+	s.Step(`^the replication metric "([^"]*)" is not published$`, f.theReplicationMetricIsNotPublished)
+	s.Step(`^I call ModifyStorageProtectionGroup with RPO (-?\d+)$`, f.iCallModifyStorageProtectionGroupWithRPO)
+	s.Step(`^I call AddVolumeToProtectionGroup$`, f.iCallAddVolumeToProtectionGroup)
+	s.Step(`^volume "([^"]*)" on system "([^"]*)" exists "([^"]*)"$`, f.volumeOnSystemExists)
+	s.Step(`^the added volume is replicated to "([^"]*)"$`, f.theAddedVolumeIsReplicatedTo)
+	s.Step(`^the described status attribute "([^"]*)" is "([^"]*)"$`, f.theDescribedStatusAttributeIs)
+	s.Step(`^the replication metric "([^"]*)" is "([^"]*)"$`, f.theReplicationMetricIs)
+	s.Step(`^I call getReplicationGroupStatus with mode "([^"]*)" last synced (-?\d+) seconds ago and RPO (\d+)$`, f.iCallGetReplicationGroupStatusWithModeLastSyncAndRPO)
+	s.Step(`^the replication group status attribute "([^"]*)" is "([^"]*)"$`, f.theReplicationGroupStatusAttributeIs)
 	s.Step(`^I enable quota for filesystem$`, f.iCallEnableFSQuota)
 	s.Step(`^I disable quota for filesystem$`, f.iCallDisableFSQuota)
 	s.Step(`^I set quota with path "([^"]*)" softLimit "([^"]*)" graceperiod "([^"]*)"$`, f.iCallSetQuotaParams)
+	s.Step(`^I enable replication for the NFS volume$`, f.iEnableReplicationForTheNFSVolume)
 	s.Step(`^I specify NoPath$`, f.iSpecifyNoPath)
 	s.Step(`^I specify NoSoftLimit`, f.iSpecifyNoSoftLimit)
 	s.Step(`^I specify NoGracePeriod`, f.iSpecifyNoGracePeriod)
@@ -4679,6 +6723,22 @@ func FeatureContext(s *godog.ScenarioContext) {
 	s.Step(`^I call externalAccessAlreadyAdded with externalAccess "([^"]*)"`, f.iCallexternalAccessAlreadyAdded)
 	s.Step(`^an NFSExport instance with nfsexporthost "([^"]*)"`, f.iCallGivenNFSExport)
 	s.Step(`^I specify External Access "([^"]*)"`, f.iSpecifyExternalAccess)
+	s.Step(`^I call getStaleNFSExportHosts with valid host "([^"]*)" and externalAccess "([^"]*)"$`, f.iCallGetStaleNFSExportHosts)
+	s.Step(`^I clear the CreateVolume request$`, f.iClearTheCreateVolumeRequest)
+	s.Step(`^the VolumeGroupSnapshot has (\d+) snapshots$`, f.theVolumeGroupSnapshotHasSnapshots)
+	s.Step(`^the filesystem "([^"]*)" does not exist$`, f.theFilesystemDoesNotExist)
+	s.Step(`^I call checkCreationTimeSkew with times "([^"]*)" and max skew "([^"]*)"$`, f.iCallCheckCreationTimeSkew)
+	s.Step(`^I set the NFS default mount options "([^"]*)"$`, f.iSetTheNFSDefaultMountOptions)
+	s.Step(`^I set the NFS allowed mount options "([^"]*)"$`, f.iSetTheNFSAllowedMountOptions)
+	s.Step(`^I set the mount flags "([^"]*)"$`, f.iSetTheMountFlags)
+	s.Step(`^I call mergeNFSMountOptions with defaults "([^"]*)" and mount flags "([^"]*)"$`, f.iCallMergeNFSMountOptions)
+	s.Step(`^I call checkNASNFSVersion with version "([^"]*)" nfsv3 "([^"]*)" nfsv4 "([^"]*)"$`, f.iCallCheckNASNFSVersion)
+	s.Step(`^a VolumeAttachment for the volume on node "([^"]*)"$`, f.aVolumeAttachmentForTheVolumeOnNode)
+	s.Step(`^I delete the VolumeAttachment$`, f.iDeleteTheVolumeAttachment)
+	s.Step(`^a pod using the ephemeral volume on node "([^"]*)"$`, f.aPodUsingTheEphemeralVolumeOnNode)
+	s.Step(`^I delete the pod using the ephemeral volume$`, f.iDeleteThePodUsingTheEphemeralVolume)
+	s.Step(`^I call reconcileNFSExports with dry run "([^"]*)"$`, f.iCallReconcileNFSExportsWithDryRun)
+	s.Step(`^the NFS export reconcile reports (\d+) stale hosts$`, f.theNFSExportReconcileReportsStaleHosts)
 
 	s.After(func(ctx context.Context, _ *godog.Scenario, _ error) (context.Context, error) {
 		if f.server != nil {
@@ -23,6 +23,8 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/dell/goscaleio"
 	types "github.com/dell/goscaleio/types/v1"
@@ -113,13 +115,22 @@ var (
 var (
 	scaleioRouter                 http.Handler
 	testControllerHasNoConnection bool
-	count                         int
+	// requiredGatewayPassword is the only password the login accepts when it is set
+	requiredGatewayPassword string
+	count                   int
+	// fileSystemsMutex serializes the file system handlers, volume group snapshots create
+	// the filesystem snapshots concurrently
+	fileSystemsMutex sync.Mutex
 )
 
+// gatewaySessionExpired rejects the requests with the token of the last login until the next login
+var gatewaySessionExpired bool
+
 var inducedError error
 
 const (
 	remoteRCGID            = "d303184900000001"
+	secondRCGID            = "d303184900000002"
 	unmarkedForReplication = "UnmarkedForReplication"
 	defaultVolumeSize      = "33554432"
 	defaultConsistencyMode = goscaleio.Consistent
@@ -130,6 +141,11 @@ func getHandler() http.Handler {
 	handler := http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
 			log.Printf("handler called: %s %s", r.Method, r.URL)
+			if gatewaySessionExpired && r.URL.Path != "/api/login" {
+				w.WriteHeader(http.StatusUnauthorized)
+				returnJSONFile("features", "authorization_failure.json", w, nil)
+				return
+			}
 			if scaleioRouter == nil {
 				getRouter().ServeHTTP(w, r)
 			}
@@ -139,6 +155,7 @@ func getHandler() http.Handler {
 	fileSystemIDName = make(map[string]string)
 	fileSystemIDToSizeTotal = make(map[string]string)
 	fileSystemIDParentID = make(map[string]string)
+	fileSystemIDCreationTimestamp = make(map[string]string)
 	nfsExportIDName = make(map[string]string)
 	fileSystemNameToID = make(map[string]string)
 	nfsExportNameID = make(map[string]string)
@@ -158,6 +175,11 @@ func getHandler() http.Handler {
 	treeQuotaIDToSoftLimit = make(map[string]string)
 	treeQuotaIDToGracePeriod = make(map[string]string)
 	treeQuotaIDToHardLimit = make(map[string]string)
+	replicationPairInitialCopyState = "Done"
+	replicationGroupActions = nil
+	replicationGroupState = "Normal"
+	createVolumeTraceparent = ""
+	gatewayActionTraceparent = ""
 	debug = false
 	stepHandlersErrors.FindVolumeIDError = false
 	stepHandlersErrors.GetVolByIDError = false
@@ -289,7 +311,7 @@ func handleGetSystemLimits(w http.ResponseWriter, _ *http.Request) {
 // handleLogin implements GET /api/login
 func handleLogin(w http.ResponseWriter, r *http.Request) {
 	u, p, ok := r.BasicAuth()
-	if !ok || len(strings.TrimSpace(u)) < 1 || len(strings.TrimSpace(p)) < 1 {
+	if !ok || len(strings.TrimSpace(u)) < 1 || len(strings.TrimSpace(p)) < 1 || (requiredGatewayPassword != "" && p != requiredGatewayPassword) {
 		w.Header().Set("WWW-Authenticate", "Basic realm=Restricted")
 		w.WriteHeader(http.StatusUnauthorized)
 		returnJSONFile("features", "authorization_failure.json", w, nil)
@@ -299,6 +321,7 @@ func handleLogin(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusRequestTimeout)
 		return
 	}
+	gatewaySessionExpired = false
 	w.Write([]byte("YWRtaW46MTU0MTU2MjIxOTI5MzpmODkxNDVhN2NkYzZkNGNkYjYxNGE0OGRkZGE3Zjk4MA"))
 }
 
@@ -338,6 +361,9 @@ func handleSystemInstances(w http.ResponseWriter, _ *http.Request) {
 }
 
 func handleNFSSnapshots(w http.ResponseWriter, r *http.Request) {
+	fileSystemsMutex.Lock()
+	defer fileSystemsMutex.Unlock()
+
 	switch r.Method {
 	case http.MethodPost:
 
@@ -358,6 +384,8 @@ func handleNFSSnapshots(w http.ResponseWriter, r *http.Request) {
 		fileSystemIDName[resp.ID] = req.Name
 		fileSystemNameToID[req.Name] = resp.ID
 		fileSystemIDParentID[resp.ID] = id
+		// the creationTime of the volumes in volume.json.template, the snapshots of a group are taken together
+		fileSystemIDCreationTimestamp[resp.ID] = time.Unix(1542129719, 0).UTC().Format(time.RFC3339)
 		sizeTotal := fileSystemIDToSizeTotal[id]
 		fileSystemIDToSizeTotal[resp.ID] = sizeTotal
 
@@ -703,6 +731,9 @@ func handleGetNFSExports(w http.ResponseWriter, r *http.Request) {
 }
 
 func handleFileSystems(w http.ResponseWriter, r *http.Request) {
+	fileSystemsMutex.Lock()
+	defer fileSystemsMutex.Unlock()
+
 	if fileSystemIDName == nil {
 		fileSystemIDName = make(map[string]string)
 		fileSystemNameToID = make(map[string]string)
@@ -775,6 +806,7 @@ func handleFileSystems(w http.ResponseWriter, r *http.Request) {
 				replacementMap["__NAME__"] = fs["name"]
 				replacementMap["__SIZE_IN_Total__"] = fs["size_total"]
 				replacementMap["__PARENT_ID__"] = fs["parent_id"]
+				replacementMap["__CREATION_TIMESTAMP__"] = fileSystemIDCreationTimestamp[fs["id"]]
 				replacementMap["__IS_QUOTA_ENABLED__"] = strconv.FormatBool(isQuotaEnabled)
 				data := returnJSONFile("features", "filesystem.json.template", nil, replacementMap)
 				fs := new(types.FileSystem)
@@ -798,6 +830,7 @@ func handleFileSystems(w http.ResponseWriter, r *http.Request) {
 			replacementMap["__NAME__"] = name
 			replacementMap["__SIZE_IN_Total__"] = fileSystemIDToSizeTotal[id]
 			replacementMap["__PARENT_ID__"] = fileSystemIDParentID[id]
+			replacementMap["__CREATION_TIMESTAMP__"] = fileSystemIDCreationTimestamp[id]
 			replacementMap["__IS_QUOTA_ENABLED__"] = strconv.FormatBool(isQuotaEnabled)
 			data := returnJSONFile("features", "filesystem.json.template", nil, replacementMap)
 			fs := new(types.FileSystem)
@@ -849,6 +882,9 @@ func handleRestoreSnapshotNFS(w http.ResponseWriter, r *http.Request) {
 }
 
 func handleGetFileSystems(w http.ResponseWriter, r *http.Request) {
+	fileSystemsMutex.Lock()
+	defer fileSystemsMutex.Unlock()
+
 	switch r.Method {
 
 	// Post is CreateVolume; here just return a volume id encoded from the name
@@ -881,6 +917,7 @@ func handleGetFileSystems(w http.ResponseWriter, r *http.Request) {
 			replacementMap["__SIZE_IN_Total__"] = fs["size_total"]
 			replacementMap["__IS_QUOTA_ENABLED__"] = strconv.FormatBool(isQuotaEnabled)
 			replacementMap["__PARENT_ID__"] = fs["parent_id"]
+			replacementMap["__CREATION_TIMESTAMP__"] = fileSystemIDCreationTimestamp[fs["id"]]
 			if fs["parent_id"] != "" {
 				if inducedError.Error() == "GetSnashotByIdError" {
 					writeError(w, "could not find snapshot id", http.StatusNotFound, codes.NotFound)
@@ -892,6 +929,7 @@ func handleGetFileSystems(w http.ResponseWriter, r *http.Request) {
 			replacementMap["__NAME__"] = fileSystemIDName[id]
 			replacementMap["__SIZE_IN_Total__"] = fileSystemIDToSizeTotal[id]
 			replacementMap["__PARENT_ID__"] = fileSystemIDParentID[id]
+			replacementMap["__CREATION_TIMESTAMP__"] = fileSystemIDCreationTimestamp[id]
 			replacementMap["__IS_QUOTA_ENABLED__"] = strconv.FormatBool(isQuotaEnabled)
 			if fileSystemIDParentID[id] != "" {
 				if inducedError.Error() == "GetSnashotByIdError" {
@@ -983,7 +1021,14 @@ func handlePeerMdmInstances(w http.ResponseWriter, _ *http.Request) {
 		writeError(w, "PeerMdmError", http.StatusRequestTimeout, codes.Internal)
 		return
 	}
-	returnJSONFile("features", "get_peer_mdms.json", w, nil)
+	var replacements map[string]string
+	switch inducedError.Error() {
+	case "PeerMdmNotJoined":
+		replacements = map[string]string{`"Joined"`: `"Disconnected"`, `"SUCCESS"`: `"FAILED"`}
+	case "PeerMdmNotPeered":
+		replacements = map[string]string{arrayID2: "16dbbf5617523656"}
+	}
+	returnJSONFile("features", "get_peer_mdms.json", w, replacements)
 }
 
 func returnJSONFile(directory, filename string, w http.ResponseWriter, replacements map[string]string) (jsonBytes []byte) {
@@ -1044,6 +1089,9 @@ var fileSystemIDName map[string]string
 // Map of FileSystem ID to parentID
 var fileSystemIDParentID map[string]string
 
+// Map of FileSystem ID to the creation timestamp of the snapshots
+var fileSystemIDCreationTimestamp map[string]string
+
 // Map of NFSExport ID to name
 var nfsExportIDName map[string]string
 
@@ -1090,6 +1138,18 @@ var fileSystemIDToSizeTotal map[string]string
 // Replication group state to replace for.
 var replicationGroupState string
 
+// Replication pair initial copy state to replace for.
+var replicationPairInitialCopyState string
+
+// Actions executed on replication consistency groups, in order.
+var replicationGroupActions []string
+
+// Trace context header of the last create volume request.
+var createVolumeTraceparent string
+
+// gatewayActionTraceparent is the trace context of the last replication consistency group action request
+var gatewayActionTraceparent string
+
 // Map of Tree quota ID
 var treeQuotaID map[string]string
 
@@ -1162,6 +1222,7 @@ func handleVolumeInstances(w http.ResponseWriter, r *http.Request) {
 			writeError(w, "create volume induced error", http.StatusRequestTimeout, codes.Internal)
 			return
 		}
+		createVolumeTraceparent = r.Header.Get("traceparent")
 
 		req := types.VolumeParam{}
 		decoder := json.NewDecoder(r.Body)
@@ -1433,6 +1494,10 @@ func handleAction(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
+		if inducedError.Error() == "GetGroupSnapshotError" {
+			// the snapshots are created, reading them back fails
+			stepHandlersErrors.GetVolByIDError = true
+		}
 		if stepHandlersErrors.WrongVolIDError {
 			returnJSONFile("features", "create_snapshot2.json", w, nil)
 		}
@@ -1440,6 +1505,7 @@ func handleAction(w http.ResponseWriter, r *http.Request) {
 	case "removeVolume":
 		if stepHandlersErrors.RemoveVolumeError {
 			writeError(w, "inducedError", http.StatusRequestTimeout, codes.Internal)
+			return
 		}
 
 		if name, ok := volumeIDToName[id]; ok {
@@ -1495,6 +1561,9 @@ func handleAction(w http.ResponseWriter, r *http.Request) {
 		targetSystem := systemArrays[r.Host].replicationSystem
 		remoteVolume := pairs[id]["remoteVolumeId"]
 		targetSystem.volumes[remoteVolume]["volumeReplicationState"] = unmarkedForReplication
+		if localVolume, ok := systemArrays[r.Host].volumes[pairs[id]["localVolumeId"]]; ok {
+			localVolume["volumeReplicationState"] = unmarkedForReplication
+		}
 
 		delete(pairs, id)
 
@@ -1515,7 +1584,8 @@ func handleAction(w http.ResponseWriter, r *http.Request) {
 
 		for _, pair := range remoteSystem.replicationPairs {
 			if pair["replicationConsistencyGroupId"] == remoteConsistencyGroup {
-				volID := uuid.New().String()
+				// PowerFlex volume IDs are hexadecimal strings
+				volID := strings.ReplaceAll(uuid.New().String(), "-", "")
 				volName := "snapshot-" + pair["localVolumeId"]
 				remoteSystem.volumes[volID] = make(map[string]string)
 				remoteSystem.volumes[volID]["name"] = volName
@@ -1535,20 +1605,53 @@ func handleAction(w http.ResponseWriter, r *http.Request) {
 	case "switchoverReplicationConsistencyGroup":
 		fallthrough
 	case "failoverReplicationConsistencyGroup":
+		if inducedError.Error() == "SecondGroupFailoverError" && id == secondRCGID {
+			writeError(w, "could not fail over second RCG", http.StatusRequestTimeout, codes.Internal)
+			return
+		}
 		fallthrough
 	case "restoreReplicationConsistencyGroup":
 		fallthrough
 	case "reverseReplicationConsistencyGroup":
 		fallthrough
 	case "resumeReplicationConsistencyGroup":
+		if inducedError.Error() == "SecondGroupResumeError" && id == secondRCGID && action == "resumeReplicationConsistencyGroup" {
+			writeError(w, "could not resume second RCG", http.StatusRequestTimeout, codes.Internal)
+			return
+		}
 		fallthrough
 	case "pauseReplicationConsistencyGroup":
+		if inducedError.Error() == "SecondGroupPauseError" && id == secondRCGID {
+			writeError(w, "could not pause second RCG", http.StatusRequestTimeout, codes.Internal)
+			return
+		}
 		fallthrough
 	case "syncNowReplicationConsistencyGroup":
 		if inducedError.Error() == "ExecuteActionError" {
 			writeError(w, "could not execute RCG action", http.StatusRequestTimeout, codes.Internal)
 			return
 		}
+		replicationGroupActions = append(replicationGroupActions, strings.TrimSuffix(action, "ReplicationConsistencyGroup"))
+	case "modifyReplicationConsistencyGroupRpo":
+		if inducedError.Error() == "ExecuteActionError" {
+			writeError(w, "could not execute RCG action", http.StatusRequestTimeout, codes.Internal)
+			return
+		}
+		req := types.ReplicationConsistencyGroupCreatePayload{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		systemArrays[r.Host].replicationConsistencyGroups[id]["rpoInSeconds"] = req.RpoInSeconds
+		replicationGroupActions = append(replicationGroupActions, action+"("+req.RpoInSeconds+")")
+	case "testFailoverReplicationConsistencyGroup", "testFailoverStopReplicationConsistencyGroup":
+		if inducedError.Error() == "ExecuteActionError" {
+			writeError(w, "could not execute RCG action", http.StatusRequestTimeout, codes.Internal)
+			return
+		}
+		gatewayActionTraceparent = r.Header.Get("traceparent")
+		replicationGroupActions = append(replicationGroupActions, strings.TrimSuffix(action, "ReplicationConsistencyGroup"))
+		replicationGroupState = "Normal"
+		if action == "testFailoverReplicationConsistencyGroup" {
+			replicationGroupState = "TestFailover"
+		}
 	}
 }
 
@@ -1657,6 +1760,7 @@ func handleRelationships(w http.ResponseWriter, r *http.Request) {
 			replacementMap["__SOURCE_VOLUME__"] = pair["localVolumeId"]
 			replacementMap["__DESTINATION_VOLUME__"] = pair["remoteVolumeId"]
 			replacementMap["__RP_GROUP__"] = pair["replicationConsistencyGroupId"]
+			replacementMap["__INIT_COPY__"] = replicationPairInitialCopyState
 
 			data := returnJSONFile("features", "replication_pair.template", nil, replacementMap)
 			pair := new(types.ReplicationPair)
@@ -1761,9 +1865,17 @@ func handleInstances(w http.ResponseWriter, r *http.Request) {
 		replacementMap["__PROTECTION_DOMAIN__"] = group["protectionDomainId"]
 		replacementMap["__RM_PROTECTION_DOMAIN__"] = group["remoteProtectionDomainId"]
 		replacementMap["__REP_DIR__"] = group["replicationDirection"]
+		replacementMap["__REMOTE_ID__"] = group["remoteId"]
+		replacementMap["__REMOTE_MDM_ID__"] = group["remoteMdmId"]
+		replacementMap["__RPO__"] = rcgRPO(group)
 
+		replacementMap["__FO_TYPE__"] = "None"
+		replacementMap["__P_MODE__"] = "None"
 		if replicationGroupState == "Normal" {
 			replacementMap["__STATE__"] = "Ok"
+		} else if replicationGroupState == "TestFailover" {
+			replacementMap["__STATE__"] = "Ok"
+			replacementMap["__FO_TYPE__"] = "TestFailover"
 		} else {
 			replacementMap["__STATE__"] = "StoppedByUser"
 			if replicationGroupState == "Failover" {
@@ -1779,6 +1891,14 @@ func handleInstances(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// rcgRPO returns the RPO of a replication consistency group, 60 seconds when it was not set
+func rcgRPO(group map[string]string) string {
+	if group["rpoInSeconds"] == "" {
+		return "60"
+	}
+	return group["rpoInSeconds"]
+}
+
 // There are times when a struct {"id":"01234567890"} is sent for an id.
 // This function extracts the id value
 func extractIDFromStruct(id string) string {
@@ -1888,7 +2008,7 @@ func handleReplicationConsistencyGroupInstances(w http.ResponseWriter, r *http.R
 		array.replicationConsistencyGroups[remoteRCGID]["protectionDomainId"] = req.RemoteProtectionDomainID
 		array.replicationConsistencyGroups[remoteRCGID]["remoteProtectionDomainId"] = req.ProtectionDomainID
 		array.replicationConsistencyGroups[remoteRCGID]["rpoInSeconds"] = req.RpoInSeconds
-		array.replicationConsistencyGroups[remoteRCGID]["remoteMdmId"] = array.ID
+		array.replicationConsistencyGroups[remoteRCGID]["remoteMdmId"] = systemArrays[r.Host].ID
 		array.replicationConsistencyGroups[remoteRCGID]["replicationDirection"] = "RemoteToLocal"
 
 		if debug {
@@ -1930,6 +2050,9 @@ func handleReplicationConsistencyGroupInstances(w http.ResponseWriter, r *http.R
 			replacementMap["__PROTECTION_DOMAIN__"] = group["protectionDomainId"]
 			replacementMap["__RM_PROTECTION_DOMAIN__"] = group["remoteProtectionDomainId"]
 			replacementMap["__REP_DIR__"] = group["replicationDirection"]
+			replacementMap["__REMOTE_ID__"] = group["remoteId"]
+			replacementMap["__REMOTE_MDM_ID__"] = group["remoteMdmId"]
+			replacementMap["__RPO__"] = rcgRPO(group)
 
 			data := returnJSONFile("features", "replication_consistency_group.template", nil, replacementMap)
 
@@ -2045,6 +2168,7 @@ func handleReplicationPairInstances(w http.ResponseWriter, r *http.Request) {
 			replacementMap["__SOURCE_VOLUME__"] = pair["localVolumeId"]
 			replacementMap["__DESTINATION_VOLUME__"] = pair["remoteVolumeId"]
 			replacementMap["__RP_GROUP__"] = pair["replicationConsistencyGroupId"]
+			replacementMap["__INIT_COPY__"] = replicationPairInitialCopyState
 
 			log.Printf("replicatPair replacementMap %v\n", replacementMap)
 			data := returnJSONFile("features", "replication_pair.template", nil, replacementMap)
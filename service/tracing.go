@@ -0,0 +1,141 @@
+// Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/dell/csi-vxflexos/v2/core"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultTracingSampleRatio is the fraction of the requests traced when no sample ratio is configured
+	defaultTracingSampleRatio = 1.0
+
+	// csiRequestIDKey is the metadata key of the request ID set by the CSI sidecars
+	csiRequestIDKey = "csi.requestid"
+)
+
+// setupTracing exports the traces of the driver to the OTLP/HTTP endpoint, sampling the configured ratio of the
+// requests that are not already part of a sampled trace. Tracing stays disabled when no endpoint is configured.
+func (s *service) setupTracing(ctx context.Context) error {
+	if s.opts.TracingEndpoint == "" {
+		return nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(s.opts.TracingEndpoint))
+	if err != nil {
+		return err
+	}
+	res := resource.NewSchemaless(
+		attribute.String("service.name", Name),
+		attribute.String("service.version", core.SemVer),
+		attribute.String("csi.mode", s.mode),
+	)
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(s.opts.TracingSampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	Log.Infof("tracing enabled, endpoint: %s, sample ratio: %v", s.opts.TracingEndpoint, s.opts.TracingSampleRatio)
+	return nil
+}
+
+// metadataCarrier adapts gRPC metadata to the trace context propagation
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// TracingInterceptor starts a span for every CSI and extension RPC, continuing the trace of the caller when the
+// request carries one. The span is in the context of the RPC, so the gateway calls made for it become its children.
+func TracingInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	attributes := []attribute.KeyValue{
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.method", info.FullMethod),
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+		if ids := md.Get(csiRequestIDKey); len(ids) > 0 {
+			attributes = append(attributes, attribute.String(csiRequestIDKey, ids[0]))
+		}
+	}
+
+	ctx, span := otel.Tracer(Name).Start(ctx, info.FullMethod,
+		trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attributes...))
+	defer span.End()
+
+	resp, err := handler(ctx, req)
+	span.SetAttributes(attribute.String("rpc.grpc.status_code", status.Code(err).String()))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	return resp, err
+}
+
+// traceGatewayCall records a PowerFlex gateway call that took from start to end as a child span of the span in
+// ctx. Every gateway call made through gatewayCall or callGatewayAPI is recorded, the calls made outside of a
+// traced request are not.
+func traceGatewayCall(ctx context.Context, systemID, operation string, start, end time.Time, err error) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return
+	}
+	_, span := otel.Tracer(Name).Start(ctx, "PowerFlex "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(start),
+		trace.WithAttributes(attribute.String("powerflex.system_id", systemID), attribute.String("powerflex.operation", operation)))
+	if err != nil {
+		span.RecordError(err, trace.WithTimestamp(end))
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	span.End(trace.WithTimestamp(end))
+}
+
+// injectTraceContext adds the trace context of ctx to the headers of a gateway request. goscaleio sends no
+// headers of the caller except the metadata of CreateVolume, so only the CreateVolume requests and the requests
+// of callGatewayAPI carry the trace context. The other gateway calls are recorded as spans by the driver only.
+func injectTraceContext(ctx context.Context, headers propagation.TextMapCarrier) {
+	otel.GetTextMapPropagator().Inject(ctx, headers)
+}
@@ -0,0 +1,242 @@
+// Copyright © 2024 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/dell/csi-vxflexos/v2/k8sutils"
+	sio "github.com/dell/goscaleio"
+	siotypes "github.com/dell/goscaleio/types/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	metricVolumeReadIOPS        = "powerflex_volume_read_iops"
+	metricVolumeWriteIOPS       = "powerflex_volume_write_iops"
+	metricVolumeReadBandwidth   = "powerflex_volume_read_bytes_per_second"
+	metricVolumeWriteBandwidth  = "powerflex_volume_write_bytes_per_second"
+	metricVolumeReadLatency     = "powerflex_volume_read_latency_seconds"
+	metricVolumeWriteLatency    = "powerflex_volume_write_latency_seconds"
+	metricStoragePoolCapacity   = "powerflex_storage_pool_capacity_bytes"
+	metricStoragePoolUsed       = "powerflex_storage_pool_used_bytes"
+	metricStoragePoolAvailable  = "powerflex_storage_pool_available_bytes"
+	microsecondsInSecond        = 1000000.0
+	volumeMetricsCollectTimeout = 5 * time.Minute
+)
+
+// volumeMetricFamilies are the metrics replaced by every collection of the volume metrics
+var volumeMetricFamilies = []string{
+	metricVolumeReadIOPS, metricVolumeWriteIOPS,
+	metricVolumeReadBandwidth, metricVolumeWriteBandwidth,
+	metricVolumeReadLatency, metricVolumeWriteLatency,
+	metricStoragePoolCapacity, metricStoragePoolUsed, metricStoragePoolAvailable,
+}
+
+// volumeClaim is the Kubernetes identity of a PowerFlex volume
+type volumeClaim struct {
+	pv        string
+	pvc       string
+	namespace string
+}
+
+// runVolumeMetricsCollector periodically collects the performance metrics of the volumes and the capacity
+// metrics of the storage pools of every array
+func (s *service) runVolumeMetricsCollector(ctx context.Context, interval time.Duration) {
+	Log.Infof("volume metrics collector started, interval: %s", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			Log.Info("volume metrics collector stopped")
+			return
+		case <-ticker.C:
+			collectCtx, cancel := context.WithTimeout(ctx, volumeMetricsCollectTimeout)
+			s.collectVolumeMetrics(collectCtx)
+			cancel()
+		}
+	}
+}
+
+// collectVolumeMetrics gathers the IOPS, bandwidth and latency of the volumes backing PersistentVolumes of this
+// driver and the capacity of the storage pools of every array. Volumes are labelled with their PV, PVC and
+// namespace. The previous values are replaced as a whole, so deleted volumes and unreachable arrays drop out.
+func (s *service) collectVolumeMetrics(ctx context.Context) {
+	claims := s.getVolumeClaims(ctx)
+
+	arrays := s.arrayConfigs()
+	systemIDs := make([]string, 0, len(arrays))
+	for systemID := range arrays {
+		systemIDs = append(systemIDs, systemID)
+	}
+	sort.Strings(systemIDs)
+
+	collected := newMetricsRegistry()
+	for _, systemID := range systemIDs {
+		if err := s.requireProbe(ctx, systemID); err != nil {
+			Log.WithError(err).Warnf("volume metrics collector skipping system %s", systemID)
+			continue
+		}
+		s.collectStoragePoolMetrics(ctx, collected, systemID)
+		if len(claims[systemID]) > 0 {
+			s.collectSystemVolumeMetrics(ctx, collected, systemID, claims[systemID])
+		}
+	}
+	driverMetrics.replaceFamilies(collected, volumeMetricFamilies...)
+}
+
+// getVolumeClaims maps the system ID and volume ID of the PersistentVolumes of this driver to their PV and PVC
+func (s *service) getVolumeClaims(ctx context.Context) map[string]map[string]volumeClaim {
+	claims := make(map[string]map[string]volumeClaim)
+	if K8sClientset == nil {
+		if err := k8sutils.CreateKubeClientSet(); err != nil {
+			Log.WithError(err).Error("unable to create k8s clientset for volume metrics")
+			return claims
+		}
+		K8sClientset = k8sutils.Clientset
+	}
+
+	pvs, err := K8sClientset.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		Log.WithError(err).Error("unable to list PersistentVolumes for volume metrics")
+		return claims
+	}
+	for _, pv := range pvs.Items {
+		if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != Name {
+			continue
+		}
+		handle := pv.Spec.CSI.VolumeHandle
+		systemID := s.getSystemIDFromCsiVolumeID(handle)
+		if systemID == "" {
+			systemID = s.defaultSystemID()
+		}
+		claim := volumeClaim{pv: pv.Name}
+		if pv.Spec.ClaimRef != nil {
+			claim.pvc = pv.Spec.ClaimRef.Name
+			claim.namespace = pv.Spec.ClaimRef.Namespace
+		}
+		if claims[systemID] == nil {
+			claims[systemID] = make(map[string]volumeClaim)
+		}
+		claims[systemID][getVolumeIDFromCsiVolumeID(handle)] = claim
+	}
+	return claims
+}
+
+// collectSystemVolumeMetrics records the performance of the volumes of one array that back a PersistentVolume
+func (s *service) collectSystemVolumeMetrics(ctx context.Context, collected *metricsRegistry, systemID string, claims map[string]volumeClaim) {
+	adminClient := s.adminClientOf(systemID)
+	var vols []*siotypes.Volume
+	err := s.gatewayCall(ctx, systemID, "GetVolume", func() (err error) {
+		vols, err = adminClient.GetVolume("", "", "", "", false)
+		return err
+	})
+	if err != nil {
+		Log.WithError(err).Warnf("volume metrics collector could not list volumes on system %s", systemID)
+		return
+	}
+
+	for _, vol := range vols {
+		claim, ok := claims[vol.ID]
+		if !ok {
+			continue
+		}
+		volume := sio.NewVolume(adminClient)
+		volume.Volume = vol
+		var stats *siotypes.VolumeStatistics
+		err := s.gatewayCall(ctx, systemID, "GetVolumeStatistics", func() (err error) {
+			stats, err = volume.GetVolumeStatistics()
+			return err
+		})
+		if err != nil {
+			Log.WithError(err).Warnf("volume metrics collector could not get statistics of volume %s on system %s", vol.ID, systemID)
+			continue
+		}
+
+		labels := metricLabels{
+			"system_id":             systemID,
+			"volume_id":             vol.ID,
+			"volume_name":           vol.Name,
+			"persistentvolume":      claim.pv,
+			"persistentvolumeclaim": claim.pvc,
+			"namespace":             claim.namespace,
+		}
+		collected.setGauge(metricVolumeReadIOPS, "Read operations per second of the volume", labels, perSecond(stats.UserDataReadBwc.NumOccured, stats.UserDataReadBwc))
+		collected.setGauge(metricVolumeWriteIOPS, "Write operations per second of the volume", labels, perSecond(stats.UserDataWriteBwc.NumOccured, stats.UserDataWriteBwc))
+		collected.setGauge(metricVolumeReadBandwidth, "Bytes read per second from the volume", labels, perSecond(stats.UserDataReadBwc.TotalWeightInKb*bytesInKiB, stats.UserDataReadBwc))
+		collected.setGauge(metricVolumeWriteBandwidth, "Bytes written per second to the volume", labels, perSecond(stats.UserDataWriteBwc.TotalWeightInKb*bytesInKiB, stats.UserDataWriteBwc))
+		collected.setGauge(metricVolumeReadLatency, "Average read latency of the volume seen by the SDCs in seconds", labels, averageLatency(stats.UserDataSdcReadLatency))
+		collected.setGauge(metricVolumeWriteLatency, "Average write latency of the volume seen by the SDCs in seconds", labels, averageLatency(stats.UserDataSdcWriteLatency))
+	}
+}
+
+// collectStoragePoolMetrics records the capacity of the storage pools of one array
+func (s *service) collectStoragePoolMetrics(ctx context.Context, collected *metricsRegistry, systemID string) {
+	system := s.systemOf(systemID)
+	if system == nil {
+		Log.Warnf("volume metrics collector skipping storage pools of system %s, system not found", systemID)
+		return
+	}
+	var pools []siotypes.StoragePool
+	err := s.gatewayCall(ctx, systemID, "GetAllStoragePools", func() (err error) {
+		pools, err = system.GetAllStoragePools()
+		return err
+	})
+	if err != nil {
+		Log.WithError(err).Warnf("volume metrics collector could not list storage pools on system %s", systemID)
+		return
+	}
+
+	for i := range pools {
+		pool := sio.NewStoragePoolEx(s.adminClientOf(systemID), &pools[i])
+		var stats *siotypes.Statistics
+		err := s.gatewayCall(ctx, systemID, "GetStoragePoolStatistics", func() (err error) {
+			stats, err = pool.GetStatistics()
+			return err
+		})
+		if err != nil {
+			Log.WithError(err).Warnf("volume metrics collector could not get statistics of storage pool %s on system %s", pools[i].Name, systemID)
+			continue
+		}
+
+		labels := metricLabels{
+			"system_id":            systemID,
+			"storage_pool":         pools[i].Name,
+			"protection_domain_id": pools[i].ProtectionDomainID,
+		}
+		collected.setGauge(metricStoragePoolCapacity, "Maximum capacity of the storage pool in bytes", labels, float64(stats.MaxCapacityInKb*bytesInKiB))
+		collected.setGauge(metricStoragePoolUsed, "Capacity in use in the storage pool in bytes", labels, float64(stats.CapacityInUseInKb*bytesInKiB))
+		collected.setGauge(metricStoragePoolAvailable, "Capacity available for volume allocation in the storage pool in bytes", labels,
+			float64(stats.CapacityAvailableForVolumeAllocationInKb*bytesInKiB))
+	}
+}
+
+// perSecond returns the rate of value over the sampling window of a bandwidth counter
+func perSecond(value int, bwc siotypes.BWC) float64 {
+	if bwc.NumSeconds <= 0 {
+		return 0
+	}
+	return float64(value) / float64(bwc.NumSeconds)
+}
+
+// averageLatency returns the average latency in seconds of a latency counter, whose weight is in microseconds
+func averageLatency(bwc siotypes.BWC) float64 {
+	if bwc.NumOccured <= 0 {
+		return 0
+	}
+	return float64(bwc.TotalWeightInKb) / float64(bwc.NumOccured) / microsecondsInSecond
+}